@@ -0,0 +1,209 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nuthan-ms/codecontext/internal/git"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// annotationKindOrder lists annotation kinds from the most to the least urgent
+var annotationKindOrder = []string{"FIXME", "HACK", "XXX", "TODO", "DEPRECATED"}
+
+// CodeAnnotation is a TODO, FIXME, HACK, XXX or @deprecated comment with the file and symbol it
+// belongs to
+type CodeAnnotation struct {
+	Kind     string         `json:"kind"`
+	Text     string         `json:"text"`
+	Author   string         `json:"author,omitempty"`
+	Date     time.Time      `json:"date,omitempty"`
+	File     string         `json:"file"`
+	Line     int            `json:"line"`
+	Symbol   string         `json:"symbol,omitempty"`
+	SymbolId types.SymbolId `json:"symbol_id,omitempty"`
+}
+
+// AnnotationFilter selects annotations; empty fields match everything
+type AnnotationFilter struct {
+	Kinds    []string // TODO, FIXME, HACK, XXX or DEPRECATED, in any case
+	FilePath string   // Only annotations in files whose path contains this
+	Author   string   // Only annotations whose author contains this, ignoring case
+}
+
+// FindAnnotations lists the annotations of the graph matching a filter, ordered by file and line
+func FindAnnotations(graph *types.CodeGraph, filter AnnotationFilter) []CodeAnnotation {
+	kinds := make(map[string]bool, len(filter.Kinds))
+	for _, kind := range filter.Kinds {
+		kinds[strings.ToUpper(strings.TrimPrefix(kind, "@"))] = true
+	}
+
+	annotations := make([]CodeAnnotation, 0)
+	for filePath, fileNode := range graph.Files {
+		if filter.FilePath != "" && !strings.Contains(filePath, filter.FilePath) {
+			continue
+		}
+		for _, annotation := range fileNode.Annotations {
+			if len(kinds) > 0 && !kinds[annotation.Kind] {
+				continue
+			}
+			if filter.Author != "" && !strings.Contains(strings.ToLower(annotation.Author), strings.ToLower(filter.Author)) {
+				continue
+			}
+			entry := CodeAnnotation{
+				Kind:     annotation.Kind,
+				Text:     annotation.Text,
+				Author:   annotation.Author,
+				Date:     annotation.Date,
+				File:     filePath,
+				Line:     annotation.Location.Line,
+				SymbolId: annotation.Symbol,
+			}
+			if symbol := graph.Symbols[annotation.Symbol]; symbol != nil {
+				entry.Symbol = symbol.Name
+			}
+			annotations = append(annotations, entry)
+		}
+	}
+
+	sort.Slice(annotations, func(i, j int) bool {
+		if annotations[i].File != annotations[j].File {
+			return annotations[i].File < annotations[j].File
+		}
+		return annotations[i].Line < annotations[j].Line
+	})
+	return annotations
+}
+
+// blameCacheEntry holds the blame of a file's annotation lines, valid while the file and the
+// repository's HEAD are unchanged
+type blameCacheEntry struct {
+	head    string
+	modTime time.Time
+	size    int64
+	lines   []int
+	blame   map[int]git.BlameLine
+}
+
+// blameAnnotations sets the author and date of annotations from git blame, for the files of roots
+// inside a git repository. Files git cannot blame, such as untracked ones, keep the owner named in
+// the comment, if any. Results are cached between analyses, so only files that changed since the
+// last one are blamed again.
+func (gb *GraphBuilder) blameAnnotations(ctx context.Context, roots []types.SourceRoot) {
+	if !gb.annotationBlame {
+		return
+	}
+	if gb.blameCache == nil {
+		gb.blameCache = make(map[string]*blameCacheEntry)
+	}
+
+	for _, root := range roots {
+		gitAnalyzer, err := git.NewGitAnalyzer(root.Path)
+		if err != nil {
+			continue
+		}
+		// A commit changes the blame of files that were not modified
+		head, _ := gitAnalyzer.ExecuteGitCommand(ctx, "rev-parse", "HEAD")
+
+		for filePath, fileNode := range gb.graph.Files {
+			if ctx.Err() != nil {
+				return
+			}
+			if len(fileNode.Annotations) == 0 || !withinDir(filePath, root.Path) {
+				continue
+			}
+			absolute, err := filepath.Abs(filePath)
+			if err != nil {
+				continue
+			}
+			info, err := os.Stat(absolute)
+			if err != nil {
+				continue
+			}
+
+			lines := make([]int, 0, len(fileNode.Annotations))
+			for _, annotation := range fileNode.Annotations {
+				lines = append(lines, annotation.Location.Line)
+			}
+
+			entry := gb.blameCache[absolute]
+			if entry == nil || entry.head != string(head) || !entry.modTime.Equal(info.ModTime()) ||
+				entry.size != info.Size() || !slices.Equal(entry.lines, lines) {
+				blame, err := gitAnalyzer.BlameLines(ctx, absolute, lines)
+				if err != nil {
+					continue
+				}
+				entry = &blameCacheEntry{head: string(head), modTime: info.ModTime(), size: info.Size(), lines: lines, blame: blame}
+				gb.blameCache[absolute] = entry
+			}
+
+			for _, annotation := range fileNode.Annotations {
+				if line, ok := entry.blame[annotation.Location.Line]; ok {
+					// An owner named in TODO(owner) is who the note is addressed to
+					if annotation.Author == "" {
+						annotation.Author = line.Author
+					}
+					annotation.Date = line.Timestamp
+				}
+			}
+		}
+	}
+}
+
+// WriteAnnotationReport writes annotations as markdown, grouped by file. A positive limit caps the
+// number of annotations listed.
+func WriteAnnotationReport(sb *strings.Builder, annotations []CodeAnnotation, limit int) {
+	if len(annotations) == 0 {
+		sb.WriteString("*No TODO, FIXME, HACK, XXX or @deprecated comments found.*\n")
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, annotation := range annotations {
+		counts[annotation.Kind]++
+	}
+	summary := make([]string, 0, len(counts))
+	for _, kind := range annotationKindOrder {
+		if counts[kind] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("Found %d annotations: %s.\n", len(annotations), strings.Join(summary, ", ")))
+
+	file := ""
+	for i, annotation := range annotations {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("\n*... and %d more annotations*\n", len(annotations)-limit))
+			break
+		}
+		if annotation.File != file {
+			file = annotation.File
+			sb.WriteString(fmt.Sprintf("\n### `%s`\n\n", file))
+		}
+
+		details := make([]string, 0, 2)
+		if annotation.Author != "" {
+			details = append(details, annotation.Author)
+		}
+		if !annotation.Date.IsZero() {
+			details = append(details, annotation.Date.Format("2006-01-02"))
+		}
+		line := fmt.Sprintf("- **%s** line %d", annotation.Kind, annotation.Line)
+		if annotation.Symbol != "" {
+			line += fmt.Sprintf(" in `%s`", annotation.Symbol)
+		}
+		if len(details) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+		}
+		if annotation.Text != "" {
+			line += ": " + annotation.Text
+		}
+		sb.WriteString(line + "\n")
+	}
+}
@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuthan-ms/codecontext/internal/git"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestFindAnnotations(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/store.ts", "typescript")
+	addTestFile(graph, "cmd/main.go", "go")
+	fetch := addTestSymbol(graph, "src/store.ts", "fetch", types.SymbolTypeMethod, 3)
+
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	graph.Files["src/store.ts"].Annotations = []*types.Annotation{
+		{Kind: "TODO", Text: "handle errors", Symbol: fetch.Id, Author: "Alice", Date: date, Location: types.FileLocation{Line: 4}},
+		{Kind: "DEPRECATED", Text: "use get()", Symbol: fetch.Id, Location: types.FileLocation{Line: 2}},
+	}
+	graph.Files["cmd/main.go"].Annotations = []*types.Annotation{
+		{Kind: "FIXME", Text: "exit code", Author: "bob", Location: types.FileLocation{Line: 10}},
+	}
+
+	all := FindAnnotations(graph, AnnotationFilter{})
+	if len(all) != 3 {
+		t.Fatalf("expected 3 annotations, got %+v", all)
+	}
+	// Ordered by file, then line
+	if all[0].File != "cmd/main.go" || all[1].Line != 2 || all[2].Line != 4 || all[2].Symbol != "fetch" {
+		t.Errorf("unexpected order or symbols: %+v", all)
+	}
+
+	filters := []struct {
+		name     string
+		filter   AnnotationFilter
+		expected []string // Kinds of the annotations found
+	}{
+		{"kinds", AnnotationFilter{Kinds: []string{"fixme", "@deprecated"}}, []string{"FIXME", "DEPRECATED"}},
+		{"file", AnnotationFilter{FilePath: "src/"}, []string{"DEPRECATED", "TODO"}},
+		{"author", AnnotationFilter{Author: "alice"}, []string{"TODO"}},
+	}
+	for _, tt := range filters {
+		t.Run(tt.name, func(t *testing.T) {
+			found := FindAnnotations(graph, tt.filter)
+			kinds := make([]string, 0, len(found))
+			for _, annotation := range found {
+				kinds = append(kinds, annotation.Kind)
+			}
+			if strings.Join(kinds, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, kinds)
+			}
+		})
+	}
+
+	var sb strings.Builder
+	WriteAnnotationReport(&sb, all, 2)
+	report := sb.String()
+	for _, expected := range []string{
+		"Found 3 annotations: 1 FIXME, 1 TODO, 1 DEPRECATED.",
+		"### `cmd/main.go`",
+		"- **FIXME** line 10 (bob): exit code",
+		"- **DEPRECATED** line 2 in `fetch`: use get()",
+		"*... and 1 more annotations*",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("report missing %q:\n%s", expected, report)
+		}
+	}
+
+	sb.Reset()
+	WriteAnnotationReport(&sb, all[2:], 0)
+	if !strings.Contains(sb.String(), "- **TODO** line 4 in `fetch` (Alice, 2024-03-01): handle errors") {
+		t.Errorf("unexpected report:\n%s", sb.String())
+	}
+}
+
+func TestBlameAnnotationsCache(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "store.go", "package store\n\n// TODO: handle errors\nfunc Load() {}\n")
+	writeTestFile(t, dir, "owned.go", "package store\n\n// TODO(bob): retry\nfunc Retry() {}\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=Ana", "-c", "user.email=ana@example.com", "add", "."},
+		{"-c", "user.name=Ana", "-c", "user.email=ana@example.com", "commit", "-q", "-m", "Add store"},
+	} {
+		command := exec.Command("git", args...)
+		command.Dir = dir
+		if output, err := command.CombinedOutput(); err != nil {
+			t.Skipf("skipping test: git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	author := func(builder *GraphBuilder) string {
+		graph, err := builder.AnalyzeDirectory(dir)
+		if err != nil {
+			t.Fatalf("AnalyzeDirectory() error = %v", err)
+		}
+		annotations := FindAnnotations(graph, AnnotationFilter{FilePath: "store.go"})
+		if len(annotations) != 1 {
+			t.Fatalf("expected one annotation, got %+v", annotations)
+		}
+		return annotations[0].Author
+	}
+
+	builder := NewGraphBuilder()
+	if got := author(builder); got != "Ana" {
+		t.Fatalf("Author = %q, expected Ana from git blame", got)
+	}
+	// The owner named in TODO(owner) wins over the blamed author
+	graph, err := NewGraphBuilder().AnalyzeDirectory(dir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectory() error = %v", err)
+	}
+	if owned := FindAnnotations(graph, AnnotationFilter{FilePath: "owned.go"}); len(owned) != 1 || owned[0].Author != "bob" || owned[0].Date.IsZero() {
+		t.Errorf("expected bob's TODO with the blamed date, got %+v", owned)
+	}
+
+	// An unchanged file reuses the cached blame
+	for _, entry := range builder.blameCache {
+		entry.blame[3] = git.BlameLine{Author: "cached"}
+	}
+	if got := author(builder); got != "cached" {
+		t.Errorf("Author = %q, expected the cached blame", got)
+	}
+
+	// A modified file is blamed again
+	writeTestFile(t, dir, "store.go", "package store\n\n// TODO: handle errors\nfunc Load() {}\n\nfunc Save() {}\n")
+	if got := author(builder); got != "Ana" {
+		t.Errorf("Author = %q, expected Ana after the file changed", got)
+	}
+
+	disabled := NewGraphBuilder()
+	disabled.SetAnnotationBlame(false)
+	if got := author(disabled); got != "" {
+		t.Errorf("Author = %q, expected none with blame turned off", got)
+	}
+}
@@ -0,0 +1,355 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/diff"
+	"github.com/nuthan-ms/codecontext/internal/parser"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// apiSnapshotVersion is the format version written to API snapshots
+const apiSnapshotVersion = 1
+
+// Semantic version levels, from the smallest release an API change allows to the largest
+const (
+	SemverPatch = "patch"
+	SemverMinor = "minor"
+	SemverMajor = "major"
+)
+
+// semverRank orders semantic version levels
+var semverRank = map[string]int{SemverPatch: 0, SemverMinor: 1, SemverMajor: 2}
+
+// apiSymbolTypes are the symbol types making up a package's public API
+var apiSymbolTypes = map[types.SymbolType]bool{
+	types.SymbolTypeFunction:  true,
+	types.SymbolTypeMethod:    true,
+	types.SymbolTypeClass:     true,
+	types.SymbolTypeInterface: true,
+	types.SymbolTypeType:      true,
+	types.SymbolTypeVariable:  true,
+	types.SymbolTypeConstant:  true,
+	types.SymbolTypeComponent: true,
+	types.SymbolTypeHook:      true,
+}
+
+// goReceiverPattern matches the receiver of a Go method signature: func (s *Server) Name(
+var goReceiverPattern = regexp.MustCompile(`^func\s*\(\s*(?:\w+\s+)?\*?\s*(\w+)(?:\[[^\]]*\])?\s*\)\s*`)
+
+// APISnapshot records the exported symbols of every package, so the public API of two versions can
+// be compared
+type APISnapshot struct {
+	Version  int                    `json:"version"`
+	Packages map[string][]APISymbol `json:"packages"` // Package directory, relative to the analyzed root
+}
+
+// APISymbol is an exported symbol of a package
+type APISymbol struct {
+	Name       string `json:"name"` // Type.Member for methods
+	Kind       string `json:"kind"`
+	Signature  string `json:"signature,omitempty"` // Whitespace collapsed; Go signatures drop func and the receiver
+	Visibility string `json:"visibility"`
+	Language   string `json:"language"`
+	File       string `json:"file"` // Relative to the analyzed root
+	Line       int    `json:"line"`
+}
+
+// APIChange is a difference between the public API of two snapshots
+type APIChange struct {
+	Package      string `json:"package"`
+	Name         string `json:"name"`
+	Kind         string `json:"kind"`
+	Change       string `json:"change"` // added, removed or changed
+	Level        string `json:"level"`  // Semantic version level the change requires
+	OldSignature string `json:"old_signature,omitempty"`
+	NewSignature string `json:"new_signature,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// APIReport lists the API changes between two snapshots and the release they require
+type APIReport struct {
+	Level   string      `json:"level"`
+	Changes []APIChange `json:"changes"`
+}
+
+// NewAPISnapshot records the exported symbols of the non-test, non-generated files in the graph.
+// Packages are directories relative to the analyzed root, prefixed with the root label when
+// several roots were analyzed; Go main packages are left out since they cannot be imported.
+func NewAPISnapshot(graph *types.CodeGraph) *APISnapshot {
+	ra := NewRelationshipAnalyzer(graph)
+	snapshot := &APISnapshot{Version: apiSnapshotVersion, Packages: make(map[string][]APISymbol)}
+
+	goMainDirs := make(map[string]bool)
+	for filePath, fileNode := range graph.Files {
+		for _, symbolId := range fileNode.Symbols {
+			if symbol := graph.Symbols[symbolId]; symbol != nil && fileNode.Language == "go" && symbol.Name == "main" {
+				goMainDirs[filepath.Dir(filePath)] = true
+			}
+		}
+	}
+
+	for filePath, fileNode := range graph.Files {
+		if fileNode.IsTest || fileNode.IsGenerated || (fileNode.Language == "go" && goMainDirs[filepath.Dir(filePath)]) {
+			continue
+		}
+		relative := apiRelativePath(graph, filePath)
+		pkg := filepath.ToSlash(filepath.Dir(relative))
+
+		for _, symbolId := range fileNode.Symbols {
+			symbol := graph.Symbols[symbolId]
+			if symbol == nil || !apiSymbolTypes[symbol.Type] || !parser.IsExported(symbol) || !isIdentifier(symbol.Name) {
+				continue
+			}
+			name, signature := symbol.Name, strings.Join(strings.Fields(symbol.Signature), " ")
+			if fileNode.Language == "go" {
+				if receiver := goReceiverPattern.FindStringSubmatch(signature); receiver != nil {
+					name = receiver[1] + "." + name
+					signature = signature[len(receiver[0]):]
+				}
+				signature = strings.TrimPrefix(signature, "func ")
+			} else if symbol.Type == types.SymbolTypeMethod {
+				if container := ra.containingType(fileNode, symbol); container != nil {
+					name = container.Name + "." + name
+				}
+			}
+			snapshot.Packages[pkg] = append(snapshot.Packages[pkg], APISymbol{
+				Name:       name,
+				Kind:       string(symbol.Type),
+				Signature:  signature,
+				Visibility: symbol.Visibility,
+				Language:   symbol.Language,
+				File:       filepath.ToSlash(relative),
+				Line:       symbol.Location.StartLine,
+			})
+		}
+	}
+
+	for _, symbols := range snapshot.Packages {
+		sort.Slice(symbols, func(i, j int) bool {
+			if symbols[i].Name != symbols[j].Name {
+				return symbols[i].Name < symbols[j].Name
+			}
+			return symbols[i].File < symbols[j].File
+		})
+	}
+	return snapshot
+}
+
+// apiRelativePath returns a file's path relative to the root it was analyzed under
+func apiRelativePath(graph *types.CodeGraph, filePath string) string {
+	if graph.Metadata == nil {
+		return filePath
+	}
+	if isMultiRoot(graph) {
+		for _, root := range graph.Metadata.Roots {
+			if withinDir(filePath, root.Path) {
+				if relative, err := filepath.Rel(root.Path, filePath); err == nil {
+					return filepath.Join(root.Label, relative)
+				}
+			}
+		}
+	}
+	if relative, err := filepath.Rel(graph.Metadata.ProjectPath, filePath); err == nil && !strings.HasPrefix(relative, "..") {
+		return relative
+	}
+	return filePath
+}
+
+// containingType returns the class, interface or type of a file whose body encloses a symbol
+func (ra *RelationshipAnalyzer) containingType(fileNode *types.FileNode, symbol *types.Symbol) *types.Symbol {
+	var container *types.Symbol
+	for _, symbolId := range fileNode.Symbols {
+		candidate := ra.graph.Symbols[symbolId]
+		if candidate == nil || candidate == symbol || !typeSymbolTypes[candidate.Type] {
+			continue
+		}
+		if candidate.Location.StartLine <= symbol.Location.StartLine && candidate.Location.EndLine >= symbol.Location.EndLine {
+			// The innermost enclosing type wins
+			if container == nil || candidate.Location.StartLine > container.Location.StartLine {
+				container = candidate
+			}
+		}
+	}
+	return container
+}
+
+// LoadAPISnapshot reads a snapshot written as JSON
+func LoadAPISnapshot(path string) (*APISnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API snapshot: %w", err)
+	}
+	var snapshot APISnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid API snapshot %s: %w", path, err)
+	}
+	if snapshot.Version != apiSnapshotVersion {
+		return nil, fmt.Errorf("unsupported API snapshot version %d in %s", snapshot.Version, path)
+	}
+	return &snapshot, nil
+}
+
+// CompareAPISnapshots classifies the differences between an old and a new API: removed symbols and
+// breaking signature or visibility changes require a major release, added symbols and compatible
+// signature changes that add parameters a minor one, and anything else a patch.
+func CompareAPISnapshots(oldSnapshot, newSnapshot *APISnapshot) *APIReport {
+	differ := diff.NewSemanticDiffer(diff.DefaultConfig())
+	report := &APIReport{Level: SemverPatch, Changes: make([]APIChange, 0)}
+	record := func(change APIChange) {
+		report.Changes = append(report.Changes, change)
+		if semverRank[change.Level] > semverRank[report.Level] {
+			report.Level = change.Level
+		}
+	}
+
+	packages := make(map[string]bool)
+	for pkg := range oldSnapshot.Packages {
+		packages[pkg] = true
+	}
+	for pkg := range newSnapshot.Packages {
+		packages[pkg] = true
+	}
+	for _, pkg := range sortedKeys(packages) {
+		oldSymbols, newSymbols := apiSymbolsByKey(oldSnapshot.Packages[pkg]), apiSymbolsByKey(newSnapshot.Packages[pkg])
+		keys := make(map[string]bool)
+		for key := range oldSymbols {
+			keys[key] = true
+		}
+		for key := range newSymbols {
+			keys[key] = true
+		}
+
+		for _, key := range sortedKeys(keys) {
+			pairs, removed, added := matchAPISymbols(oldSymbols[key], newSymbols[key])
+			for _, oldSymbol := range removed {
+				record(APIChange{Package: pkg, Name: oldSymbol.Name, Kind: oldSymbol.Kind, Change: "removed", Level: SemverMajor, OldSignature: oldSymbol.Signature})
+			}
+			for _, pair := range pairs {
+				oldSymbol, newSymbol := pair[0], pair[1]
+				if oldSymbol.Signature == newSymbol.Signature && oldSymbol.Visibility == newSymbol.Visibility {
+					continue
+				}
+
+				change := APIChange{Package: pkg, Name: newSymbol.Name, Kind: newSymbol.Kind, Change: "changed", OldSignature: oldSymbol.Signature, NewSignature: newSymbol.Signature}
+				oldParsed, newParsed := oldSymbol.symbol(), newSymbol.symbol()
+				switch {
+				case differ.BreakingChange(oldParsed, newParsed) != "":
+					change.Level, change.Reason = SemverMajor, differ.BreakingChange(oldParsed, newParsed)
+				case len(diff.SplitParameters(newSymbol.Signature)) > len(diff.SplitParameters(oldSymbol.Signature)):
+					change.Level, change.Reason = SemverMinor, "optional parameters added"
+				case oldSymbol.Visibility != newSymbol.Visibility:
+					change.Level, change.Reason = SemverMinor, fmt.Sprintf("visibility widened from %s to %s", oldSymbol.Visibility, newSymbol.Visibility)
+				default:
+					change.Level, change.Reason = SemverPatch, "compatible signature change"
+				}
+				record(change)
+			}
+			for _, newSymbol := range added {
+				record(APIChange{Package: pkg, Name: newSymbol.Name, Kind: newSymbol.Kind, Change: "added", Level: SemverMinor, NewSignature: newSymbol.Signature})
+			}
+		}
+	}
+
+	// Most significant changes first, keeping package and name order within a level
+	sort.SliceStable(report.Changes, func(i, j int) bool {
+		return semverRank[report.Changes[i].Level] > semverRank[report.Changes[j].Level]
+	})
+	return report
+}
+
+// apiSymbolsByKey groups a package's symbols by name, kind and file, so same-named symbols of
+// different files stay apart. Go files of a package share one namespace, so Go symbols are grouped
+// without their file and still match after moving between files. Overloads share a group.
+func apiSymbolsByKey(symbols []APISymbol) map[string][]APISymbol {
+	byKey := make(map[string][]APISymbol, len(symbols))
+	for _, symbol := range symbols {
+		file := symbol.File
+		if symbol.Language == "go" {
+			file = ""
+		}
+		key := symbol.Name + "\x00" + symbol.Kind + "\x00" + file
+		byKey[key] = append(byKey[key], symbol)
+	}
+	return byKey
+}
+
+// matchAPISymbols pairs the old and new overloads of a symbol. Overloads with the same signature
+// match first and the rest pair up in declaration order; the leftovers were removed or added.
+func matchAPISymbols(oldGroup, newGroup []APISymbol) ([][2]APISymbol, []APISymbol, []APISymbol) {
+	pairs := make([][2]APISymbol, 0, len(oldGroup))
+	unmatched := append([]APISymbol(nil), newGroup...)
+	changed := make([]APISymbol, 0)
+	for _, oldSymbol := range oldGroup {
+		matched := false
+		for i, newSymbol := range unmatched {
+			if newSymbol.Signature == oldSymbol.Signature {
+				pairs = append(pairs, [2]APISymbol{oldSymbol, newSymbol})
+				unmatched = append(unmatched[:i], unmatched[i+1:]...)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			changed = append(changed, oldSymbol)
+		}
+	}
+
+	for len(changed) > 0 && len(unmatched) > 0 {
+		pairs = append(pairs, [2]APISymbol{changed[0], unmatched[0]})
+		changed, unmatched = changed[1:], unmatched[1:]
+	}
+	return pairs, changed, unmatched
+}
+
+// symbol converts a snapshot entry back to the symbol form the semantic differ compares
+func (as APISymbol) symbol() *types.Symbol {
+	return &types.Symbol{Name: as.Name, Kind: as.Kind, Signature: as.Signature, Visibility: as.Visibility, Language: as.Language}
+}
+
+// SemverAtLeast reports whether level is the same as or larger than threshold
+func SemverAtLeast(level, threshold string) bool {
+	return semverRank[level] >= semverRank[threshold]
+}
+
+// WriteAPIReport writes an API comparison as markdown. A positive limit caps the list of changes.
+func WriteAPIReport(sb *strings.Builder, report *APIReport, limit int) {
+	if len(report.Changes) == 0 {
+		sb.WriteString("*No public API changes found; a patch release is enough.*\n")
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, change := range report.Changes {
+		counts[change.Level]++
+	}
+	sb.WriteString(fmt.Sprintf("**Required release: %s** (%d major, %d minor, %d patch changes)\n\n",
+		report.Level, counts[SemverMajor], counts[SemverMinor], counts[SemverPatch]))
+	sb.WriteString("| Level | Change | Symbol | Package | Details |\n")
+	sb.WriteString("|-------|--------|--------|---------|---------|\n")
+	for i, change := range report.Changes {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("\n*... and %d more changes*\n", len(report.Changes)-limit))
+			break
+		}
+		details := change.Reason
+		switch change.Change {
+		case "changed":
+			details = fmt.Sprintf("%s: `%s` → `%s`", change.Reason, change.OldSignature, change.NewSignature)
+		case "removed":
+			details = fmt.Sprintf("`%s`", change.OldSignature)
+		case "added":
+			details = fmt.Sprintf("`%s`", change.NewSignature)
+		}
+		if details == "``" {
+			details = ""
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | `%s` (%s) | `%s` | %s |\n", change.Level, change.Change, change.Name, change.Kind, change.Package, details))
+	}
+}
@@ -0,0 +1,186 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// addTestAPISymbol adds a symbol with a signature and visibility to a file of the graph
+func addTestAPISymbol(graph *types.CodeGraph, filePath, name string, symbolType types.SymbolType, line int, signature, visibility string) *types.Symbol {
+	symbol := addTestSymbol(graph, filePath, name, symbolType, line)
+	symbol.Signature = signature
+	symbol.Visibility = visibility
+	return symbol
+}
+
+func TestNewAPISnapshot(t *testing.T) {
+	root := "/repo"
+	graph := newEmptyTestGraph()
+	graph.Metadata.ProjectPath = root
+
+	store := filepath.Join(root, "pkg", "store", "store.go")
+	addTestFile(graph, store, "go")
+	addTestAPISymbol(graph, store, "Store", types.SymbolTypeType, 3, "", "public")
+	addTestAPISymbol(graph, store, "Get", types.SymbolTypeMethod, 5, "func (s  *Store) Get(key string)\n\t(int, error)", "public")
+	addTestAPISymbol(graph, store, "New", types.SymbolTypeFunction, 9, "func New() *Store", "public")
+	addTestAPISymbol(graph, store, "helper", types.SymbolTypeFunction, 12, "func helper()", "private")
+
+	storeTest := filepath.Join(root, "pkg", "store", "store_test.go")
+	addTestFile(graph, storeTest, "go").IsTest = true
+	addTestAPISymbol(graph, storeTest, "TestGet", types.SymbolTypeFunction, 5, "func TestGet(t *testing.T)", "public")
+
+	command := filepath.Join(root, "cmd", "tool", "main.go")
+	addTestFile(graph, command, "go")
+	addTestAPISymbol(graph, command, "main", types.SymbolTypeFunction, 3, "func main()", "private")
+	addTestAPISymbol(graph, command, "Run", types.SymbolTypeFunction, 7, "func Run()", "public")
+
+	client := filepath.Join(root, "src", "Client.java")
+	addTestFile(graph, client, "java")
+	class := addTestAPISymbol(graph, client, "Client", types.SymbolTypeClass, 1, "", "public")
+	class.Location.EndLine = 10
+	addTestAPISymbol(graph, client, "fetch", types.SymbolTypeMethod, 4, "(String url) throws IOException", "public")
+
+	snapshot := NewAPISnapshot(graph)
+
+	names := make(map[string][]string)
+	for pkg, symbols := range snapshot.Packages {
+		for _, symbol := range symbols {
+			names[pkg] = append(names[pkg], symbol.Name+" "+symbol.Signature)
+		}
+	}
+	expected := map[string][]string{
+		"pkg/store": {"New New() *Store", "Store ", "Store.Get Get(key string) (int, error)"},
+		"src":       {"Client ", "Client.fetch (String url) throws IOException"},
+	}
+	if len(names) != len(expected) {
+		t.Fatalf("expected packages %v, got %v", expected, names)
+	}
+	for pkg, symbols := range expected {
+		if strings.Join(names[pkg], "|") != strings.Join(symbols, "|") {
+			t.Errorf("package %s: expected %q, got %q", pkg, symbols, names[pkg])
+		}
+	}
+	if symbol := snapshot.Packages["pkg/store"][0]; symbol.File != "pkg/store/store.go" || symbol.Line != 9 {
+		t.Errorf("expected relative location, got %+v", symbol)
+	}
+
+	// Snapshots round-trip through their JSON form
+	path := filepath.Join(t.TempDir(), "api.json")
+	data, _ := json.Marshal(snapshot)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadAPISnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadAPISnapshot failed: %v", err)
+	}
+	if report := CompareAPISnapshots(snapshot, loaded); len(report.Changes) != 0 || report.Level != SemverPatch {
+		t.Errorf("expected no changes after a round trip, got %+v", report)
+	}
+}
+
+func TestCompareAPISnapshots(t *testing.T) {
+	symbol := func(name, kind, signature, visibility string) APISymbol {
+		return APISymbol{Name: name, Kind: kind, Signature: signature, Visibility: visibility, Language: "python"}
+	}
+	oldSnapshot := &APISnapshot{Version: 1, Packages: map[string][]APISymbol{
+		"lib": {
+			symbol("fetch", "function", "(url: str) -> bytes", "public"),
+			symbol("parse", "function", "(text: str) -> dict", "public"),
+			symbol("render", "function", "(page: Page) -> str", "public"),
+			symbol("Cache", "class", "", "public"),
+			symbol("Stable", "class", "", "public"),
+		},
+	}}
+
+	tests := []struct {
+		name    string
+		changes []APISymbol
+		level   string
+		reason  string
+	}{
+		{"unchanged", nil, SemverPatch, ""},
+		{"required parameter added", []APISymbol{symbol("fetch", "function", "(url: str, timeout: int) -> bytes", "public")}, SemverMajor, "required parameters added"},
+		{"optional parameter added", []APISymbol{symbol("fetch", "function", "(url: str, timeout: int = 10) -> bytes", "public")}, SemverMinor, "optional parameters added"},
+		{"return type changed", []APISymbol{symbol("parse", "function", "(text: str) -> list", "public")}, SemverMajor, "return type changed"},
+		{"visibility reduced", []APISymbol{symbol("Cache", "class", "", "protected")}, SemverMajor, "visibility reduced from public to protected"},
+		{"parameter renamed", []APISymbol{symbol("render", "function", "(p: Page) -> str", "public")}, SemverPatch, "compatible signature change"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symbols := make([]APISymbol, 0)
+			for _, original := range oldSnapshot.Packages["lib"] {
+				for _, changed := range tt.changes {
+					if changed.Name == original.Name {
+						original = changed
+					}
+				}
+				symbols = append(symbols, original)
+			}
+			report := CompareAPISnapshots(oldSnapshot, &APISnapshot{Version: 1, Packages: map[string][]APISymbol{"lib": symbols}})
+			if report.Level != tt.level {
+				t.Errorf("expected level %s, got %s (%+v)", tt.level, report.Level, report.Changes)
+			}
+			if tt.reason != "" && (len(report.Changes) != 1 || report.Changes[0].Reason != tt.reason) {
+				t.Errorf("expected one change because %q, got %+v", tt.reason, report.Changes)
+			}
+		})
+	}
+
+	// Removals outrank additions, and the report lists them first
+	newSnapshot := &APISnapshot{Version: 1, Packages: map[string][]APISymbol{
+		"lib": {symbol("Stable", "class", "", "public"), symbol("Cache", "class", "", "public")},
+		"ext": {symbol("plugin", "function", "() -> None", "public")},
+	}}
+	report := CompareAPISnapshots(oldSnapshot, newSnapshot)
+	if report.Level != SemverMajor || len(report.Changes) != 4 {
+		t.Fatalf("expected 3 removals and 1 addition, got %+v", report)
+	}
+	if report.Changes[0].Change != "removed" || report.Changes[3].Change != "added" || report.Changes[3].Package != "ext" {
+		t.Errorf("unexpected change order: %+v", report.Changes)
+	}
+
+	var sb strings.Builder
+	WriteAPIReport(&sb, report, 2)
+	for _, expected := range []string{
+		"**Required release: major** (3 major, 1 minor, 0 patch changes)",
+		"| major | removed | `fetch` (function) | `lib` | `(url: str) -> bytes` |",
+		"*... and 2 more changes*",
+	} {
+		if !strings.Contains(sb.String(), expected) {
+			t.Errorf("report missing %q:\n%s", expected, sb.String())
+		}
+	}
+
+	// Overloads and same-named symbols of different files are compared one by one
+	member := func(name, file, signature string) APISymbol {
+		return APISymbol{Name: name, Kind: "method", Signature: signature, Visibility: "public", Language: "java", File: file}
+	}
+	oldSnapshot = &APISnapshot{Version: 1, Packages: map[string][]APISymbol{"lib": {
+		member("Store.get", "lib/Store.java", "Item get(String key)"),
+		member("Store.get", "lib/Store.java", "Item get(String key, Item fallback)"),
+		member("Store.get", "lib/Stores.java", "Item get(String key)"),
+	}}}
+	newSnapshot = &APISnapshot{Version: 1, Packages: map[string][]APISymbol{"lib": {
+		member("Store.get", "lib/Store.java", "Item get(String key)"),
+		member("Store.get", "lib/Store.java", "Item get(String key, Item fallback)"),
+		member("Store.get", "lib/Store.java", "Item get(String key, int retries)"),
+		member("Store.get", "lib/Stores.java", "Optional<Item> get(String key)"),
+	}}}
+	report = CompareAPISnapshots(oldSnapshot, newSnapshot)
+	changes := make([]string, 0)
+	for _, change := range report.Changes {
+		changes = append(changes, fmt.Sprintf("%s %s %s", change.Level, change.Change, change.NewSignature))
+	}
+	expected := []string{"major changed Optional<Item> get(String key)", "minor added Item get(String key, int retries)"}
+	if strings.Join(changes, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("changes = %v, expected %v", changes, expected)
+	}
+}
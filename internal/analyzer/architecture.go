@@ -0,0 +1,224 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// ArchitectureConfig declares the layers of a codebase and which layers each may import
+type ArchitectureConfig struct {
+	Layers []ArchitectureLayer `json:"layers" mapstructure:"layers"`
+	Rules  []ArchitectureRule  `json:"rules" mapstructure:"rules"`
+}
+
+// ArchitectureLayer groups the files matching any of its path patterns. A file belongs to the first
+// layer that matches it.
+type ArchitectureLayer struct {
+	Name  string   `json:"name" mapstructure:"name"`
+	Paths []string `json:"paths" mapstructure:"paths"`
+}
+
+// ArchitectureRule restricts the imports of one layer. Deny lists forbidden layers; a non-empty Allow
+// list forbids every other layer. Imports within a layer and of unlayered files are always allowed.
+type ArchitectureRule struct {
+	From  string   `json:"from" mapstructure:"from"`
+	Allow []string `json:"allow,omitempty" mapstructure:"allow"`
+	Deny  []string `json:"deny,omitempty" mapstructure:"deny"`
+}
+
+// LayerViolation is an import that breaks an architecture rule
+type LayerViolation struct {
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	ImportPath string `json:"import_path"`
+	Target     string `json:"target"` // Imported file, or package directory for package imports
+	FromLayer  string `json:"from_layer"`
+	ToLayer    string `json:"to_layer"`
+	Rule       string `json:"rule"`
+}
+
+// Enabled reports whether any layers are declared
+func (config ArchitectureConfig) Enabled() bool {
+	return len(config.Layers) > 0
+}
+
+// LayerOf returns the layer a file or directory belongs to, or "" if it matches none
+func (config ArchitectureConfig) LayerOf(path string) string {
+	for _, layer := range config.Layers {
+		if matchesAnyPathPattern(layer.Paths, path) {
+			return layer.Name
+		}
+	}
+	return ""
+}
+
+// Validate checks that every layer has a name and paths and that rules only name declared layers,
+// since a misspelt layer would otherwise silently disable its rule
+func (config ArchitectureConfig) Validate() error {
+	declared := make(map[string]bool, len(config.Layers))
+	for i, layer := range config.Layers {
+		if layer.Name == "" {
+			return fmt.Errorf("layer %d has no name", i+1)
+		}
+		if declared[layer.Name] {
+			return fmt.Errorf("layer %q is declared more than once", layer.Name)
+		}
+		if len(layer.Paths) == 0 {
+			return fmt.Errorf("layer %q has no paths", layer.Name)
+		}
+		declared[layer.Name] = true
+	}
+
+	for _, rule := range config.Rules {
+		if !declared[rule.From] {
+			return fmt.Errorf("rule from unknown layer %q", rule.From)
+		}
+		if len(rule.Allow) == 0 && len(rule.Deny) == 0 {
+			return fmt.Errorf("rule from %q has neither allow nor deny", rule.From)
+		}
+		for _, layer := range append(append([]string{}, rule.Allow...), rule.Deny...) {
+			if !declared[layer] {
+				return fmt.Errorf("rule from %q names unknown layer %q", rule.From, layer)
+			}
+		}
+	}
+	return nil
+}
+
+// Describe renders a rule as a sentence, e.g. "domain must not import infrastructure"
+func (rule ArchitectureRule) Describe() string {
+	parts := make([]string, 0, 2)
+	if len(rule.Allow) > 0 {
+		parts = append(parts, fmt.Sprintf("%s may only import %s", rule.From, strings.Join(rule.Allow, ", ")))
+	}
+	if len(rule.Deny) > 0 {
+		parts = append(parts, fmt.Sprintf("%s must not import %s", rule.From, strings.Join(rule.Deny, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// forbids reports whether the rule disallows importing a layer
+func (rule ArchitectureRule) forbids(layer string) bool {
+	for _, denied := range rule.Deny {
+		if denied == layer {
+			return true
+		}
+	}
+	if len(rule.Allow) == 0 {
+		return false
+	}
+	for _, allowed := range rule.Allow {
+		if allowed == layer {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckArchitecture checks the resolved import edges of an analyzed graph against the configured
+// rules and returns the violations ordered by file and line
+func CheckArchitecture(graph *types.CodeGraph, config ArchitectureConfig) []LayerViolation {
+	violations := make([]LayerViolation, 0)
+	if !config.Enabled() {
+		return violations
+	}
+
+	seen := make(map[string]bool)
+	for _, edge := range graph.Edges {
+		if edge.Type != string(RelationshipImport) {
+			continue
+		}
+		source := strings.TrimPrefix(string(edge.From), "file-")
+		target := strings.TrimPrefix(string(edge.To), "file-")
+		if node := graph.Nodes[edge.To]; node != nil && node.Type == "package" {
+			target = node.FilePath
+		} else if graph.Files[target] == nil {
+			// External modules have no layer
+			continue
+		}
+
+		fromLayer, toLayer := config.LayerOf(source), config.LayerOf(target)
+		if fromLayer == "" || toLayer == "" || fromLayer == toLayer {
+			continue
+		}
+
+		importPath, _ := edge.Metadata["import_path"].(string)
+		for _, rule := range config.Rules {
+			if rule.From != fromLayer || !rule.forbids(toLayer) {
+				continue
+			}
+			// Package imports also produce file edges; report each import of a layer once
+			key := source + "\x00" + importPath + "\x00" + toLayer
+			if seen[key] {
+				break
+			}
+			seen[key] = true
+
+			violations = append(violations, LayerViolation{
+				File:       source,
+				Line:       importLine(graph.Files[source], importPath),
+				ImportPath: importPath,
+				Target:     target,
+				FromLayer:  fromLayer,
+				ToLayer:    toLayer,
+				Rule:       rule.Describe(),
+			})
+			break
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		if violations[i].Line != violations[j].Line {
+			return violations[i].Line < violations[j].Line
+		}
+		return violations[i].Target < violations[j].Target
+	})
+	return violations
+}
+
+// importLine returns the line of a file's import with the given path
+func importLine(fileNode *types.FileNode, importPath string) int {
+	if fileNode == nil {
+		return 0
+	}
+	for _, imp := range fileNode.Imports {
+		if imp.Path == importPath {
+			return imp.Location.Line
+		}
+	}
+	return 0
+}
+
+// matchPathGlob matches a slash-separated path against a pattern whose segments are filepath.Match
+// patterns, where a "**" segment matches any number of segments
+func matchPathGlob(pattern, path string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(filepath.ToSlash(path), "/"))
+}
+
+func matchGlobSegments(patterns, segments []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+	if patterns[0] == "**" {
+		for skip := 0; skip <= len(segments); skip++ {
+			if matchGlobSegments(patterns[1:], segments[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, _ := filepath.Match(patterns[0], segments[0]); !matched {
+		return false
+	}
+	return matchGlobSegments(patterns[1:], segments[1:])
+}
@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckArchitecture(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/domain/user.ts", "typescript", "../infrastructure/db", "./order")
+	graph.Files["src/domain/user.ts"].Imports[0].Location.Line = 2
+	addTestFile(graph, "src/domain/order.ts", "typescript")
+	addTestFile(graph, "src/application/service.ts", "typescript", "../domain/user", "../ui/view", "../shared/log")
+	graph.Files["src/application/service.ts"].Imports[1].Location.Line = 3
+	addTestFile(graph, "src/infrastructure/db.ts", "typescript")
+	addTestFile(graph, "src/ui/view.ts", "typescript")
+	addTestFile(graph, "src/shared/log.ts", "typescript")
+
+	if _, err := NewRelationshipAnalyzer(graph).AnalyzeAllRelationships(); err != nil {
+		t.Fatalf("AnalyzeAllRelationships() error = %v", err)
+	}
+
+	config := ArchitectureConfig{
+		Layers: []ArchitectureLayer{
+			{Name: "domain", Paths: []string{"src/domain/**"}},
+			{Name: "application", Paths: []string{"src/application/*.ts"}},
+			{Name: "infrastructure", Paths: []string{"infrastructure/**"}},
+			{Name: "ui", Paths: []string{"**/ui/**"}},
+		},
+		Rules: []ArchitectureRule{
+			{From: "domain", Deny: []string{"infrastructure"}},
+			{From: "application", Allow: []string{"domain"}},
+		},
+	}
+
+	if layer := config.LayerOf("/repo/src/domain/model/user.ts"); layer != "domain" {
+		t.Errorf("LayerOf(nested domain file) = %q, expected domain", layer)
+	}
+	if layer := config.LayerOf("src/shared/log.ts"); layer != "" {
+		t.Errorf("LayerOf(unlayered file) = %q, expected none", layer)
+	}
+
+	// Same-layer and unlayered imports are allowed
+	violations := CheckArchitecture(graph, config)
+	if len(violations) != 2 {
+		t.Fatalf("CheckArchitecture() = %+v, expected 2 violations", violations)
+	}
+	expected := []LayerViolation{
+		{File: "src/application/service.ts", Line: 3, ImportPath: "../ui/view", Target: "src/ui/view.ts",
+			FromLayer: "application", ToLayer: "ui", Rule: "application may only import domain"},
+		{File: "src/domain/user.ts", Line: 2, ImportPath: "../infrastructure/db", Target: "src/infrastructure/db.ts",
+			FromLayer: "domain", ToLayer: "infrastructure", Rule: "domain must not import infrastructure"},
+	}
+	for i, violation := range violations {
+		if violation != expected[i] {
+			t.Errorf("violation %d = %+v, expected %+v", i, violation, expected[i])
+		}
+	}
+
+	if got := CheckArchitecture(graph, ArchitectureConfig{}); len(got) != 0 {
+		t.Errorf("CheckArchitecture() without layers = %+v, expected none", got)
+	}
+
+	generator := NewMarkdownGenerator(graph)
+	if strings.Contains(generator.GenerateContextMap(), "## 🏛️ Architecture") {
+		t.Error("architecture section rendered without configured layers")
+	}
+	generator.SetArchitecture(config)
+	content := generator.GenerateContextMap()
+	if !strings.Contains(content, "### Layer Violations (2)") ||
+		!strings.Contains(content, "- `src/domain/user.ts:2` imports `../infrastructure/db` (domain → infrastructure)") {
+		t.Errorf("unexpected architecture section:\n%s", content)
+	}
+}
+
+func TestArchitectureConfigValidate(t *testing.T) {
+	layers := []ArchitectureLayer{
+		{Name: "domain", Paths: []string{"src/domain/**"}},
+		{Name: "infrastructure", Paths: []string{"src/infrastructure/**"}},
+	}
+
+	tests := []struct {
+		name    string
+		config  ArchitectureConfig
+		wantErr string
+	}{
+		{"valid", ArchitectureConfig{Layers: layers, Rules: []ArchitectureRule{{From: "domain", Deny: []string{"infrastructure"}}}}, ""},
+		{"no layers", ArchitectureConfig{}, ""},
+		{"misspelt deny", ArchitectureConfig{Layers: layers, Rules: []ArchitectureRule{{From: "domain", Deny: []string{"infrastucture"}}}},
+			`rule from "domain" names unknown layer "infrastucture"`},
+		{"misspelt allow", ArchitectureConfig{Layers: layers, Rules: []ArchitectureRule{{From: "domain", Allow: []string{"domian"}}}},
+			`rule from "domain" names unknown layer "domian"`},
+		{"unknown from", ArchitectureConfig{Layers: layers, Rules: []ArchitectureRule{{From: "ui", Deny: []string{"domain"}}}},
+			`rule from unknown layer "ui"`},
+		{"empty rule", ArchitectureConfig{Layers: layers, Rules: []ArchitectureRule{{From: "domain"}}},
+			`rule from "domain" has neither allow nor deny`},
+		{"layer without paths", ArchitectureConfig{Layers: []ArchitectureLayer{{Name: "domain"}}}, `layer "domain" has no paths`},
+		{"duplicate layer", ArchitectureConfig{Layers: append(layers, ArchitectureLayer{Name: "domain", Paths: []string{"lib/**"}})},
+			`layer "domain" is declared more than once`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, expected none", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, expected %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// CallSite is the location of a single call from one symbol to another
+type CallSite struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// Call resolution strategies, from most to least certain
+const (
+	CallResolutionLocal   = "local"   // Callee defined in the calling file
+	CallResolutionImport  = "import"  // Callee reached through an import of the calling file
+	CallResolutionPackage = "package" // Callee defined in the same package directory
+	CallResolutionGlobal  = "global"  // Only symbol with that name in the codebase
+)
+
+// callableSymbolTypes lists symbol types a call can target, in order of preference
+var callableSymbolTypes = map[types.SymbolType]int{
+	types.SymbolTypeFunction:  0,
+	types.SymbolTypeMethod:    0,
+	types.SymbolTypeHook:      0,
+	types.SymbolTypeComponent: 1,
+	types.SymbolTypeClass:     1,
+	types.SymbolTypeType:      1,
+	types.SymbolTypeVariable:  2,
+}
+
+// methodSymbolLanguages lists languages whose methods are extracted as method symbols
+var methodSymbolLanguages = map[string]bool{"go": true, "java": true, "javascript": true, "typescript": true}
+
+// selfReceivers name the enclosing object inside methods
+var selfReceivers = map[string]bool{"this": true, "self": true, "super": true, "Self": true, "cls": true}
+
+// resolveCall finds the symbol a call site in filePath refers to
+func (ra *RelationshipAnalyzer) resolveCall(call *types.Call, filePath string) (*types.Symbol, string) {
+	fileNode := ra.graph.Files[filePath]
+	if fileNode == nil {
+		return nil, ""
+	}
+	preferMethod := call.Receiver != ""
+	receiverRoot := callReceiverRoot(call.Receiver)
+
+	// Plain calls and calls on this/self usually target the calling file
+	if call.Receiver == "" || selfReceivers[receiverRoot] {
+		if target := ra.pickCallTarget([]string{filePath}, call.Callee, preferMethod); target != nil {
+			return target, CallResolutionLocal
+		}
+	}
+
+	// Calls qualified by an import binding (pkg.Func, ns.fn, module.func) or naming an imported specifier
+	if files := ra.importedFilesFor(fileNode, call); len(files) > 0 {
+		if target := ra.pickCallTarget(files, call.Callee, preferMethod); target != nil {
+			return target, CallResolutionImport
+		}
+	}
+	// Calls through a standard library or third-party import, such as os.ReadFile, have no target here
+	if ra.bindsExternalImport(fileNode, call) {
+		return nil, ""
+	}
+
+	// Fully qualified Rust paths such as crate::util::fmt resolve like use paths
+	if strings.Contains(call.Receiver, "::") && fileNode.Language == "rust" {
+		resolution := ra.resolveImport(&types.Import{Path: call.Receiver}, filePath)
+		if target := ra.pickCallTarget(resolution.Files, call.Callee, preferMethod); target != nil {
+			return target, CallResolutionImport
+		}
+	}
+
+	// Go and Java packages span every file of a directory
+	if fileNode.Language == "go" || fileNode.Language == "java" {
+		siblings := ra.filesInDir(filepath.Dir(filePath), fileNode.Language, nil)
+		if target := ra.pickCallTarget(siblings, call.Callee, preferMethod); target != nil {
+			return target, CallResolutionPackage
+		}
+	}
+
+	// Method calls on values whose type lives in an imported file
+	if call.Receiver != "" && !selfReceivers[receiverRoot] {
+		files := make([]string, 0)
+		for _, imp := range fileNode.Imports {
+			files = append(files, ra.resolveImport(imp, filePath).Files...)
+		}
+		if target := ra.pickCallTarget(files, call.Callee, true); target != nil {
+			return target, CallResolutionImport
+		}
+	}
+
+	// Fall back to a name that is unique across the codebase
+	var unique *types.Symbol
+	for _, symbol := range ra.symbolsNamed(call.Callee) {
+		if symbol.Language != fileNode.Language {
+			continue
+		}
+		// Method calls on unknown receivers only match methods, e.g. arr.push never hits a function push.
+		// Python and Rust extract methods as functions, so they only exclude classes and variables.
+		if preferMethod && symbol.Type != types.SymbolTypeMethod &&
+			(methodSymbolLanguages[symbol.Language] || symbol.Type != types.SymbolTypeFunction) {
+			continue
+		}
+		if unique != nil {
+			return nil, ""
+		}
+		unique = symbol
+	}
+	if unique != nil {
+		return unique, CallResolutionGlobal
+	}
+	return nil, ""
+}
+
+// importedFilesFor returns the files behind the imports a call refers to, either through its
+// receiver (an alias, package or module name) or because the callee itself was imported
+func (ra *RelationshipAnalyzer) importedFilesFor(fileNode *types.FileNode, call *types.Call) []string {
+	files := make([]string, 0)
+	for _, imp := range fileNode.Imports {
+		if !importBindsCall(imp, call) {
+			continue
+		}
+		files = append(files, ra.resolveImport(imp, fileNode.Path).Files...)
+	}
+	return files
+}
+
+// bindsExternalImport reports whether a call is made through imports that all resolve outside the
+// analyzed tree, either by its receiver or the receiver's first segment (http in http.DefaultClient.Do)
+// or by naming an imported specifier
+func (ra *RelationshipAnalyzer) bindsExternalImport(fileNode *types.FileNode, call *types.Call) bool {
+	receiverRoot := callReceiverRoot(call.Receiver)
+	if selfReceivers[receiverRoot] {
+		return false
+	}
+	rootCall := &types.Call{Callee: call.Callee, Receiver: receiverRoot}
+
+	bound := false
+	for _, imp := range fileNode.Imports {
+		if !importBindsCall(imp, call) && (call.Receiver == "" || !importBindsCall(imp, rootCall)) {
+			continue
+		}
+		if len(ra.resolveImport(imp, fileNode.Path).Files) > 0 {
+			return false
+		}
+		bound = true
+	}
+	return bound
+}
+
+// importBindsCall reports whether an import introduces the name a call is made through
+func importBindsCall(imp *types.Import, call *types.Call) bool {
+	if call.Receiver == "" {
+		// from x import callee, import { callee } from "x", import static a.B.callee, use a::callee
+		for _, specifier := range imp.Specifiers {
+			if specifier == call.Callee {
+				return true
+			}
+		}
+		return imp.Alias == call.Callee || lastImportSegment(imp.Path) == call.Callee
+	}
+
+	receiver := call.Receiver
+	if imp.Alias != "" {
+		return receiver == imp.Alias
+	}
+	for _, specifier := range imp.Specifiers {
+		if specifier == receiver {
+			return true
+		}
+	}
+	// Python "import a.b" is used as a.b.func; Go, Java and Rust refer to the last path segment
+	return receiver == imp.Path || receiver == lastImportSegment(imp.Path)
+}
+
+// pickCallTarget chooses the best symbol named callee defined in files
+func (ra *RelationshipAnalyzer) pickCallTarget(files []string, callee string, preferMethod bool) *types.Symbol {
+	if len(files) == 0 {
+		return nil
+	}
+	inFiles := make(map[string]bool, len(files))
+	for _, file := range files {
+		inFiles[file] = true
+	}
+
+	var best *types.Symbol
+	bestRank := 0
+	for _, symbol := range ra.symbolsNamed(callee) {
+		if !inFiles[ra.symbolFile(symbol.Id)] {
+			continue
+		}
+		rank := callableSymbolTypes[symbol.Type] * 2
+		if (symbol.Type == types.SymbolTypeMethod) != preferMethod {
+			rank++
+		}
+		if best == nil || rank < bestRank {
+			best, bestRank = symbol, rank
+		}
+	}
+	return best
+}
+
+// symbolsNamed returns the callable symbols with a name, sorted by id for stable results
+func (ra *RelationshipAnalyzer) symbolsNamed(name string) []*types.Symbol {
+	ra.indexSymbols()
+	return ra.callableByName[name]
+}
+
+// symbolFile returns the file defining a symbol
+func (ra *RelationshipAnalyzer) symbolFile(id types.SymbolId) string {
+	ra.indexSymbols()
+	return ra.symbolFiles[id]
+}
+
+// indexSymbols builds the name and file lookups used by call, type and reference resolution
+func (ra *RelationshipAnalyzer) indexSymbols() {
+	if ra.callableByName != nil {
+		return
+	}
+
+	ra.callableByName = make(map[string][]*types.Symbol)
+	ra.typesByName = make(map[string][]*types.Symbol)
+	ra.symbolsByName = make(map[string][]*types.Symbol)
+	ra.symbolFiles = make(map[types.SymbolId]string)
+	for filePath, fileNode := range ra.graph.Files {
+		for _, symbolId := range fileNode.Symbols {
+			ra.symbolFiles[symbolId] = filePath
+		}
+	}
+	for _, symbol := range ra.graph.Symbols {
+		if _, callable := callableSymbolTypes[symbol.Type]; callable {
+			ra.callableByName[symbol.Name] = append(ra.callableByName[symbol.Name], symbol)
+		}
+		if typeSymbolTypes[symbol.Type] && !isRustImplBlock(symbol) {
+			ra.typesByName[symbol.Name] = append(ra.typesByName[symbol.Name], symbol)
+		}
+		if symbol.Type != types.SymbolTypeImport {
+			ra.symbolsByName[symbol.Name] = append(ra.symbolsByName[symbol.Name], symbol)
+		}
+	}
+	for _, index := range []map[string][]*types.Symbol{ra.callableByName, ra.typesByName, ra.symbolsByName} {
+		for _, symbols := range index {
+			sort.Slice(symbols, func(i, j int) bool { return symbols[i].Id < symbols[j].Id })
+		}
+	}
+}
+
+// addCallEdge records a call site on the edge between caller and callee, creating it on first use
+func (ra *RelationshipAnalyzer) addCallEdge(filePath string, call *types.Call, target *types.Symbol, resolution string) bool {
+	from := types.NodeId(fmt.Sprintf("file-%s", filePath))
+	source := string(call.Caller)
+	if call.Caller != "" {
+		from = types.NodeId(fmt.Sprintf("symbol-%s", call.Caller))
+	} else {
+		source = filePath
+	}
+
+	site := CallSite{File: filePath, Line: call.Location.Line, Column: call.Location.Column}
+	edgeId := types.EdgeId(fmt.Sprintf("call-%s-%s", source, target.Id))
+	if edge, exists := ra.graph.Edges[edgeId]; exists {
+		edge.Metadata["call_sites"] = append(edge.Metadata["call_sites"].([]CallSite), site)
+		edge.Weight++
+		return false
+	}
+
+	ra.graph.Edges[edgeId] = &types.GraphEdge{
+		Id:     edgeId,
+		From:   from,
+		To:     types.NodeId(fmt.Sprintf("symbol-%s", target.Id)),
+		Type:   string(RelationshipCalls),
+		Weight: 1.0,
+		Metadata: map[string]interface{}{
+			"callee":      call.Callee,
+			"receiver":    call.Receiver,
+			"resolution":  resolution,
+			"source_file": filePath,
+			"target_file": ra.symbolFile(target.Id),
+			"call_sites":  []CallSite{site},
+		},
+	}
+	return true
+}
+
+// callReceiverRoot returns the first element of a receiver expression
+func callReceiverRoot(receiver string) string {
+	if index := strings.IndexAny(receiver, ".:"); index >= 0 {
+		return receiver[:index]
+	}
+	return receiver
+}
+
+// lastImportSegment returns the name an import path is referred to by, e.g. "store" for
+// "github.com/acme/app/store", "Bar" for "com.acme.Bar" and "fmt" for "crate::util::fmt"
+func lastImportSegment(importPath string) string {
+	path := strings.TrimRight(importPath, "/")
+	if index := strings.LastIndexAny(path, "/.:"); index >= 0 {
+		// Go major version suffixes are not part of the package name
+		if segment := path[index+1:]; isMajorVersionSuffix(segment) {
+			return lastImportSegment(path[:index])
+		}
+		path = path[index+1:]
+	}
+	return path
+}
+
+// isMajorVersionSuffix reports whether a path segment is a Go module major version such as v2
+func isMajorVersionSuffix(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+	for _, r := range segment[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
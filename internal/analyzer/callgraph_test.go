@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// addTestSymbol registers a symbol defined in filePath
+func addTestSymbol(graph *types.CodeGraph, filePath, name string, symbolType types.SymbolType, line int) *types.Symbol {
+	symbol := &types.Symbol{
+		Id:       types.SymbolId(fmt.Sprintf("%s-%s-%d", symbolType, filePath, line)),
+		Name:     name,
+		Type:     symbolType,
+		Location: types.Location{StartLine: line, EndLine: line},
+		Language: graph.Files[filePath].Language,
+	}
+	graph.Symbols[symbol.Id] = symbol
+	graph.Files[filePath].Symbols = append(graph.Files[filePath].Symbols, symbol.Id)
+	return symbol
+}
+
+// addTestCall records a call site made by caller in filePath
+func addTestCall(graph *types.CodeGraph, filePath string, caller *types.Symbol, receiver, callee string, line int) {
+	call := &types.Call{Callee: callee, Receiver: receiver, Location: types.FileLocation{Line: line, Column: 5}}
+	if caller != nil {
+		call.Caller = caller.Id
+	}
+	graph.Files[filePath].Calls = append(graph.Files[filePath].Calls, call)
+}
+
+func TestCallGraphResolution(t *testing.T) {
+	graph := newEmptyTestGraph()
+
+	// TypeScript: local, named import and namespace import calls
+	addTestFile(graph, "src/app.ts", "typescript", "./format", "./db")
+	graph.Files["src/app.ts"].Imports[0].Specifiers = []string{"formatName"}
+	graph.Files["src/app.ts"].Imports[1].Alias = "db"
+	addTestFile(graph, "src/format.ts", "typescript")
+	addTestFile(graph, "src/db.ts", "typescript")
+
+	run := addTestSymbol(graph, "src/app.ts", "run", types.SymbolTypeFunction, 3)
+	validate := addTestSymbol(graph, "src/app.ts", "validate", types.SymbolTypeFunction, 10)
+	formatName := addTestSymbol(graph, "src/format.ts", "formatName", types.SymbolTypeFunction, 1)
+	query := addTestSymbol(graph, "src/db.ts", "query", types.SymbolTypeFunction, 1)
+
+	addTestCall(graph, "src/app.ts", run, "", "validate", 4)
+	addTestCall(graph, "src/app.ts", run, "", "validate", 6)
+	addTestCall(graph, "src/app.ts", run, "", "formatName", 5)
+	addTestCall(graph, "src/app.ts", run, "db", "query", 7)
+	addTestCall(graph, "src/app.ts", run, "items", "push", 8) // builtin, must stay unresolved
+	addTestCall(graph, "src/app.ts", nil, "", "run", 20)
+
+	// Go: calls across files of the same package
+	addTestFile(graph, "pkg/server/server.go", "go")
+	addTestFile(graph, "pkg/server/routes.go", "go")
+	handle := addTestSymbol(graph, "pkg/server/server.go", "Handle", types.SymbolTypeMethod, 12)
+	register := addTestSymbol(graph, "pkg/server/routes.go", "register", types.SymbolTypeMethod, 4)
+	addTestCall(graph, "pkg/server/server.go", handle, "s", "register", 13)
+
+	// Go: standard library calls never reach a local method of the same name
+	addTestFile(graph, "cmd/tool/main.go", "go", "os", "net/http")
+	addTestFile(graph, "internal/testutils/fs.go", "go")
+	mainFunc := addTestSymbol(graph, "cmd/tool/main.go", "main", types.SymbolTypeFunction, 5)
+	readFile := addTestSymbol(graph, "internal/testutils/fs.go", "ReadFile", types.SymbolTypeMethod, 10)
+	do := addTestSymbol(graph, "internal/testutils/fs.go", "Do", types.SymbolTypeMethod, 20)
+	addTestCall(graph, "cmd/tool/main.go", mainFunc, "os", "ReadFile", 6)
+	addTestCall(graph, "cmd/tool/main.go", mainFunc, "http.DefaultClient", "Do", 7)
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{ByType: make(map[RelationshipType]int)}
+	analyzer.analyzeCallRelationships(metrics)
+
+	tests := []struct {
+		name       string
+		source     string
+		target     *types.Symbol
+		resolution string
+		sites      int
+	}{
+		{"local function", string(run.Id), validate, CallResolutionLocal, 2},
+		{"named import", string(run.Id), formatName, CallResolutionImport, 1},
+		{"namespace import", string(run.Id), query, CallResolutionImport, 1},
+		{"top-level call", "src/app.ts", run, CallResolutionLocal, 1},
+		{"same go package", string(handle.Id), register, CallResolutionPackage, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edge := graph.Edges[types.EdgeId(fmt.Sprintf("call-%s-%s", tt.source, tt.target.Id))]
+			if edge == nil {
+				t.Fatalf("expected calls edge from %s to %s", tt.source, tt.target.Name)
+			}
+			if edge.Type != string(RelationshipCalls) {
+				t.Errorf("edge type = %s, expected calls", edge.Type)
+			}
+			if edge.Metadata["resolution"] != tt.resolution {
+				t.Errorf("resolution = %v, expected %s", edge.Metadata["resolution"], tt.resolution)
+			}
+			sites := edge.Metadata["call_sites"].([]CallSite)
+			if len(sites) != tt.sites || edge.Weight != float64(tt.sites) {
+				t.Errorf("call sites = %d (weight %.0f), expected %d", len(sites), edge.Weight, tt.sites)
+			}
+		})
+	}
+
+	for _, target := range []*types.Symbol{readFile, do} {
+		if edge := graph.Edges[types.EdgeId(fmt.Sprintf("call-%s-%s", mainFunc.Id, target.Id))]; edge != nil {
+			t.Errorf("standard library call linked to the local %s method", target.Name)
+		}
+	}
+
+	if metrics.ByType[RelationshipCalls] != len(tests) {
+		t.Errorf("calls metric = %d, expected %d", metrics.ByType[RelationshipCalls], len(tests))
+	}
+}
+
+func TestLastImportSegment(t *testing.T) {
+	tests := map[string]string{
+		"github.com/acme/app/store": "store",
+		"github.com/acme/client/v2": "client",
+		"com.acme.util.Strings":     "Strings",
+		"crate::util::fmt":          "fmt",
+		"./components/Button":       "Button",
+		"fmt":                       "fmt",
+	}
+
+	for importPath, expected := range tests {
+		if result := lastImportSegment(importPath); result != expected {
+			t.Errorf("lastImportSegment(%q) = %q, expected %q", importPath, result, expected)
+		}
+	}
+}
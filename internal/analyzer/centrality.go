@@ -0,0 +1,185 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// PageRank parameters
+const (
+	pageRankDamping    = 0.85
+	pageRankIterations = 100
+	pageRankTolerance  = 1e-9
+)
+
+// centralityEdgeTypes are the edges PageRank follows. Package dependencies and documentation links
+// are derived from them and would count the same dependency twice.
+var centralityEdgeTypes = map[string]bool{
+	string(RelationshipImport):     true,
+	string(RelationshipCalls):      true,
+	string(RelationshipReferences): true,
+	string(RelationshipExtends):    true,
+	string(RelationshipImplements): true,
+}
+
+// fileNodeId returns the graph node id used for a file
+func fileNodeId(filePath string) types.NodeId {
+	return types.NodeId(fmt.Sprintf("file-%s", filePath))
+}
+
+// ensureFileNode adds a file node to the graph if it is missing, so edges from and to files have
+// both endpoints in Nodes
+func (ra *RelationshipAnalyzer) ensureFileNode(filePath string, fileNode *types.FileNode) *types.GraphNode {
+	nodeId := fileNodeId(filePath)
+	if node, exists := ra.graph.Nodes[nodeId]; exists {
+		return node
+	}
+
+	node := &types.GraphNode{
+		Id:       nodeId,
+		Type:     "file",
+		Label:    filepath.Base(filePath),
+		FilePath: filePath,
+		Metadata: map[string]interface{}{
+			"language": fileNode.Language,
+		},
+	}
+	ra.graph.Nodes[nodeId] = node
+	return node
+}
+
+// computeCentrality runs PageRank over the import, call, reference and inheritance edges and stores
+// the result in Importance. A file's rank includes the rank of the symbols it declares. Scores are
+// scaled so the most central file and the most central symbol each score 1.
+func (ra *RelationshipAnalyzer) computeCentrality() {
+	for filePath, fileNode := range ra.graph.Files {
+		ra.ensureFileNode(filePath, fileNode)
+	}
+
+	ranks, degrees := pageRank(ra.graph)
+	for nodeId, node := range ra.graph.Nodes {
+		node.Importance = ranks[nodeId]
+		node.Connections = degrees[nodeId]
+	}
+
+	for filePath, fileNode := range ra.graph.Files {
+		node := ra.graph.Nodes[fileNodeId(filePath)]
+		for _, symbolId := range fileNode.Symbols {
+			if symbolNode := ra.graph.Nodes[types.NodeId(fmt.Sprintf("symbol-%s", symbolId))]; symbolNode != nil {
+				node.Importance += ranks[symbolNode.Id]
+			}
+		}
+	}
+
+	normalizeImportance(ra.graph, "file")
+	normalizeImportance(ra.graph, "symbol")
+	normalizeImportance(ra.graph, "package")
+}
+
+// pageRank computes the weighted PageRank of every node and the number of edges touching it,
+// over the edges of centralityEdgeTypes. Edges to nodes outside the graph, such as external
+// modules, are ignored. Rank held by nodes without outgoing edges is spread evenly so the scores
+// keep summing to one.
+func pageRank(graph *types.CodeGraph) (map[types.NodeId]float64, map[types.NodeId]int) {
+	nodes := make([]types.NodeId, 0, len(graph.Nodes))
+	for nodeId := range graph.Nodes {
+		nodes = append(nodes, nodeId)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+
+	ranks := make(map[types.NodeId]float64, len(nodes))
+	degrees := make(map[types.NodeId]int, len(nodes))
+	if len(nodes) == 0 {
+		return ranks, degrees
+	}
+
+	outgoing := make(map[types.NodeId]map[types.NodeId]float64)
+	outWeight := make(map[types.NodeId]float64)
+	for _, edge := range graph.Edges {
+		if !centralityEdgeTypes[edge.Type] || graph.Nodes[edge.From] == nil || graph.Nodes[edge.To] == nil || edge.From == edge.To {
+			continue
+		}
+		weight := math.Max(edge.Weight, 1)
+		if outgoing[edge.From] == nil {
+			outgoing[edge.From] = make(map[types.NodeId]float64)
+		}
+		outgoing[edge.From][edge.To] += weight
+		outWeight[edge.From] += weight
+		degrees[edge.From]++
+		degrees[edge.To]++
+	}
+
+	count := float64(len(nodes))
+	for _, nodeId := range nodes {
+		ranks[nodeId] = 1 / count
+	}
+
+	for iteration := 0; iteration < pageRankIterations; iteration++ {
+		dangling := 0.0
+		for _, nodeId := range nodes {
+			if outWeight[nodeId] == 0 {
+				dangling += ranks[nodeId]
+			}
+		}
+
+		base := (1-pageRankDamping)/count + pageRankDamping*dangling/count
+		next := make(map[types.NodeId]float64, len(nodes))
+		for _, nodeId := range nodes {
+			next[nodeId] = base
+		}
+		for _, from := range nodes {
+			for to, weight := range outgoing[from] {
+				next[to] += pageRankDamping * ranks[from] * weight / outWeight[from]
+			}
+		}
+
+		delta := 0.0
+		for _, nodeId := range nodes {
+			delta += math.Abs(next[nodeId] - ranks[nodeId])
+		}
+		ranks = next
+		if delta < pageRankTolerance {
+			break
+		}
+	}
+
+	return ranks, degrees
+}
+
+// normalizeImportance scales the importance of every node of a type so the highest is 1
+func normalizeImportance(graph *types.CodeGraph, nodeType string) {
+	highest := 0.0
+	for _, node := range graph.Nodes {
+		if node.Type == nodeType {
+			highest = math.Max(highest, node.Importance)
+		}
+	}
+	if highest == 0 {
+		return
+	}
+	for _, node := range graph.Nodes {
+		if node.Type == nodeType {
+			node.Importance /= highest
+		}
+	}
+}
+
+// FileImportance returns the centrality of a file, or 0 before relationships are analyzed
+func FileImportance(graph *types.CodeGraph, filePath string) float64 {
+	if node := graph.Nodes[fileNodeId(filePath)]; node != nil {
+		return node.Importance
+	}
+	return 0
+}
+
+// SymbolImportance returns the centrality of a symbol, or 0 before relationships are analyzed
+func SymbolImportance(graph *types.CodeGraph, symbolId types.SymbolId) float64 {
+	if node := graph.Nodes[types.NodeId(fmt.Sprintf("symbol-%s", symbolId))]; node != nil {
+		return node.Importance
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestComputeCentrality(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/app.ts", "typescript", "./db", "./util")
+	graph.Files["src/app.ts"].Imports[1].Specifiers = []string{"format"}
+	addTestFile(graph, "src/db.ts", "typescript", "./util")
+	graph.Files["src/db.ts"].Imports[0].Specifiers = []string{"format"}
+	addTestFile(graph, "src/util.ts", "typescript")
+
+	run := addTestSymbol(graph, "src/app.ts", "run", types.SymbolTypeFunction, 3)
+	query := addTestSymbol(graph, "src/db.ts", "query", types.SymbolTypeFunction, 2)
+	format := addTestSymbol(graph, "src/util.ts", "format", types.SymbolTypeFunction, 1)
+	for _, symbol := range graph.Symbols {
+		nodeId := types.NodeId(fmt.Sprintf("symbol-%s", symbol.Id))
+		graph.Nodes[nodeId] = &types.GraphNode{Id: nodeId, Type: "symbol", Label: symbol.Name}
+	}
+	addTestCall(graph, "src/app.ts", run, "", "format", 4)
+	addTestCall(graph, "src/app.ts", run, "", "query", 5)
+	addTestCall(graph, "src/db.ts", query, "", "format", 3)
+
+	// Documentation links do not make the code they mention more central
+	docNode := types.NodeId("doc-README.md:1")
+	graph.Nodes[docNode] = &types.GraphNode{Id: docNode, Type: "doc_section", Label: "README"}
+	graph.Edges["documents-readme-app"] = &types.GraphEdge{
+		Id: "documents-readme-app", From: docNode, To: fileNodeId("src/app.ts"), Type: string(RelationshipDocuments), Weight: 50,
+	}
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics, err := analyzer.AnalyzeAllRelationships()
+	if err != nil {
+		t.Fatalf("AnalyzeAllRelationships() error = %v", err)
+	}
+
+	// Every import edge ends at a file node
+	for _, filePath := range []string{"src/app.ts", "src/db.ts", "src/util.ts"} {
+		if graph.Nodes[fileNodeId(filePath)] == nil {
+			t.Fatalf("expected a file node for %s", filePath)
+		}
+	}
+
+	if FileImportance(graph, "src/util.ts") != 1 {
+		t.Errorf("util.ts importance = %v, expected it to be the most central file", FileImportance(graph, "src/util.ts"))
+	}
+	if !(FileImportance(graph, "src/db.ts") > FileImportance(graph, "src/app.ts")) {
+		t.Errorf("db.ts (%v) should outrank app.ts (%v)", FileImportance(graph, "src/db.ts"), FileImportance(graph, "src/app.ts"))
+	}
+	if SymbolImportance(graph, format.Id) != 1 || !(SymbolImportance(graph, query.Id) > SymbolImportance(graph, run.Id)) {
+		t.Errorf("unexpected symbol importance: format=%v query=%v run=%v",
+			SymbolImportance(graph, format.Id), SymbolImportance(graph, query.Id), SymbolImportance(graph, run.Id))
+	}
+	if connections := graph.Nodes[fileNodeId("src/util.ts")].Connections; connections != 2 {
+		t.Errorf("util.ts connections = %d, expected 2 incoming imports", connections)
+	}
+	if connections := graph.Nodes[docNode].Connections; connections != 0 {
+		t.Errorf("doc section connections = %d, expected documents edges to be left out", connections)
+	}
+
+	if len(metrics.HotspotFiles) == 0 || metrics.HotspotFiles[0].FilePath != "src/util.ts" || metrics.HotspotFiles[0].Score != 100 {
+		t.Errorf("expected util.ts to lead the hotspots, got %+v", metrics.HotspotFiles)
+	}
+
+	// The markdown tables list the most central code first
+	content := NewMarkdownGenerator(graph).GenerateContextMap()
+	if strings.Index(content, "| `src/util.ts`") > strings.Index(content, "| `src/app.ts`") {
+		t.Error("expected util.ts before app.ts in the file table")
+	}
+	if strings.Index(content, "| `format`") > strings.Index(content, "| `run`") {
+		t.Error("expected format before run in the symbol table")
+	}
+}
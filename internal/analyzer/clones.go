@@ -0,0 +1,191 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/parser"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Clone group kinds
+const (
+	CloneKindExact = "exact" // Identical bodies once identifiers and literals are abstracted
+	CloneKindNear  = "near"  // Bodies whose token shingles mostly overlap
+)
+
+// Clone detection defaults
+const (
+	DefaultCloneMinTokens  = 50
+	DefaultCloneSimilarity = 0.8
+)
+
+// minHashBands splits MinHash signatures into bands; functions sharing any band are compared
+const minHashBands = 16
+
+// CloneOptions configures clone detection
+type CloneOptions struct {
+	MinTokens  int     `json:"min_tokens,omitempty"` // Smallest function body considered, in tokens
+	Similarity float64 `json:"similarity,omitempty"` // Lowest estimated similarity of near clones, 0 to 1
+}
+
+// CloneGroup is a set of functions that are copies of each other
+type CloneGroup struct {
+	Kind       string      `json:"kind"`
+	Similarity float64     `json:"similarity"` // Lowest similarity between linked members
+	Tokens     int         `json:"tokens"`     // Size of the largest member
+	Members    []SymbolRef `json:"members"`
+}
+
+// FindClones groups functions and methods with duplicated bodies, largest duplication first. Test and
+// generated files are skipped.
+func FindClones(graph *types.CodeGraph, options CloneOptions) []CloneGroup {
+	if options.MinTokens <= 0 {
+		options.MinTokens = DefaultCloneMinTokens
+	}
+	if options.Similarity <= 0 {
+		options.Similarity = DefaultCloneSimilarity
+	}
+
+	type candidate struct {
+		symbol *types.Symbol
+		ref    SymbolRef
+	}
+	candidates := make([]candidate, 0)
+	for filePath, fileNode := range graph.Files {
+		if fileNode.IsTest || fileNode.IsGenerated {
+			continue
+		}
+		for _, symbolId := range fileNode.Symbols {
+			symbol := graph.Symbols[symbolId]
+			if symbol == nil || symbol.Fingerprint == nil || symbol.Fingerprint.Tokens < options.MinTokens {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				symbol: symbol,
+				ref:    SymbolRef{Id: symbol.Id, Name: symbol.Name, Type: symbol.Type, File: filePath, Line: symbol.Location.StartLine},
+			})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].symbol.Id < candidates[j].symbol.Id })
+
+	// Exact clones share a hash; near clones are linked pairwise and grouped transitively
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	similarity := make([]float64, len(candidates))
+	for i := range similarity {
+		similarity[i] = 1
+	}
+	link := func(i, j int, score float64) {
+		rootI, rootJ := find(i), find(j)
+		low := min(score, similarity[rootI], similarity[rootJ])
+		if rootI != rootJ {
+			parent[rootJ] = rootI
+		}
+		similarity[rootI] = low
+	}
+
+	byHash := make(map[string]int)
+	for i, c := range candidates {
+		if first, exists := byHash[c.symbol.Fingerprint.Hash]; exists {
+			link(first, i, 1)
+		} else {
+			byHash[c.symbol.Fingerprint.Hash] = i
+		}
+	}
+
+	// Locality-sensitive hashing: only functions agreeing on a whole band are compared
+	buckets := make(map[string][]int)
+	for i, c := range candidates {
+		signature := c.symbol.Fingerprint.MinHash
+		rows := len(signature) / minHashBands
+		if rows == 0 {
+			continue
+		}
+		for band := 0; band < minHashBands; band++ {
+			key := fmt.Sprintf("%d:%v", band, signature[band*rows:(band+1)*rows])
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+	compared := make(map[[2]int]bool)
+	for _, bucket := range buckets {
+		for a := 0; a < len(bucket); a++ {
+			for b := a + 1; b < len(bucket); b++ {
+				pair := [2]int{bucket[a], bucket[b]}
+				if compared[pair] {
+					continue
+				}
+				compared[pair] = true
+				if score := parser.Similarity(candidates[pair[0]].symbol.Fingerprint, candidates[pair[1]].symbol.Fingerprint); score >= options.Similarity {
+					link(pair[0], pair[1], score)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range candidates {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	groups := make([]CloneGroup, 0)
+	for root, indexes := range members {
+		if len(indexes) < 2 {
+			continue
+		}
+		group := CloneGroup{Kind: CloneKindExact, Similarity: similarity[root], Members: make([]SymbolRef, 0, len(indexes))}
+		hash := candidates[indexes[0]].symbol.Fingerprint.Hash
+		for _, i := range indexes {
+			fingerprint := candidates[i].symbol.Fingerprint
+			if fingerprint.Hash != hash {
+				group.Kind = CloneKindNear
+			}
+			group.Tokens = max(group.Tokens, fingerprint.Tokens)
+			group.Members = append(group.Members, candidates[i].ref)
+		}
+		sortSymbolRefs(group.Members)
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		left, right := groups[i].Tokens*(len(groups[i].Members)-1), groups[j].Tokens*(len(groups[j].Members)-1)
+		if left != right {
+			return left > right
+		}
+		return groups[i].Members[0].Id < groups[j].Members[0].Id
+	})
+	return groups
+}
+
+// WriteCloneReport writes clone groups as markdown. A positive limit caps the number of groups.
+func WriteCloneReport(sb *strings.Builder, groups []CloneGroup, limit int) {
+	if len(groups) == 0 {
+		sb.WriteString("*No duplicated functions found.*\n")
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("Found %d groups of duplicated functions.\n\n", len(groups)))
+	for i, group := range groups {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("*... and %d more groups*\n", len(groups)-limit))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("### Group %d (%s, %d copies, %d tokens, %.0f%% similar)\n\n",
+			i+1, group.Kind, len(group.Members), group.Tokens, group.Similarity*100))
+		for _, member := range group.Members {
+			sb.WriteString(fmt.Sprintf("- `%s` (%s) in `%s:%d`\n", member.Name, member.Type, member.File, member.Line))
+		}
+		sb.WriteString("\n")
+	}
+}
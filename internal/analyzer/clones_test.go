@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// testFingerprint builds a 64 value signature whose first `shared` values match every other
+// fingerprint built with the same base
+func testFingerprint(hash string, tokens int, base uint64, shared int) *types.Fingerprint {
+	signature := make([]uint64, 64)
+	for i := range signature {
+		if i < shared {
+			signature[i] = base + uint64(i)
+		} else {
+			signature[i] = uint64(len(hash))<<32 + uint64(hash[len(hash)-1])<<16 + uint64(i)
+		}
+	}
+	return &types.Fingerprint{Hash: hash, Tokens: tokens, MinHash: signature}
+}
+
+func TestFindClones(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/a.go", "go")
+	addTestFile(graph, "src/b.go", "go")
+	addTestFile(graph, "src/a_test.go", "go")
+	graph.Files["src/a_test.go"].IsTest = true
+
+	// Exact copies across files, one of them in a test file
+	addTestSymbol(graph, "src/a.go", "parse", types.SymbolTypeFunction, 3).Fingerprint = testFingerprint("h1", 120, 1000, 64)
+	addTestSymbol(graph, "src/b.go", "parseAll", types.SymbolTypeFunction, 10).Fingerprint = testFingerprint("h1", 120, 1000, 64)
+	addTestSymbol(graph, "src/a_test.go", "parseHelper", types.SymbolTypeFunction, 8).Fingerprint = testFingerprint("h1", 120, 1000, 64)
+
+	// Near copies sharing 60 of 64 values, and a third sharing too few to pass the threshold
+	addTestSymbol(graph, "src/a.go", "render", types.SymbolTypeFunction, 20).Fingerprint = testFingerprint("h2", 80, 5000, 60)
+	addTestSymbol(graph, "src/b.go", "renderAll", types.SymbolTypeMethod, 30).Fingerprint = testFingerprint("h3", 90, 5000, 60)
+	addTestSymbol(graph, "src/b.go", "renderOne", types.SymbolTypeMethod, 40).Fingerprint = testFingerprint("h4", 90, 5000, 32)
+
+	// Identical but too small to matter
+	addTestSymbol(graph, "src/a.go", "get", types.SymbolTypeFunction, 50).Fingerprint = testFingerprint("h5", 10, 9000, 64)
+	addTestSymbol(graph, "src/b.go", "getAll", types.SymbolTypeFunction, 60).Fingerprint = testFingerprint("h5", 10, 9000, 64)
+
+	groups := FindClones(graph, CloneOptions{})
+	if len(groups) != 2 {
+		t.Fatalf("FindClones() returned %d groups, expected 2: %+v", len(groups), groups)
+	}
+
+	exact := groups[0]
+	if exact.Kind != CloneKindExact || exact.Similarity != 1 || exact.Tokens != 120 || len(exact.Members) != 2 {
+		t.Errorf("unexpected exact group: %+v", exact)
+	}
+	if exact.Members[0].Name != "parse" || exact.Members[1].File != "src/b.go" || exact.Members[1].Line != 10 {
+		t.Errorf("unexpected exact group members: %+v", exact.Members)
+	}
+
+	near := groups[1]
+	if near.Kind != CloneKindNear || near.Tokens != 90 || len(near.Members) != 2 {
+		t.Errorf("unexpected near group: %+v", near)
+	}
+	if near.Similarity < 0.9 || near.Similarity >= 1 {
+		t.Errorf("near group similarity = %.2f, expected about 0.94", near.Similarity)
+	}
+
+	// Lowering the thresholds pulls in the small copies and the looser near copy
+	if groups := FindClones(graph, CloneOptions{MinTokens: 5, Similarity: 0.4}); len(groups) != 3 || len(groups[0].Members) != 3 {
+		t.Errorf("FindClones() with low thresholds = %+v, expected 3 groups led by the render copies with renderOne joined", groups)
+	}
+
+	var sb strings.Builder
+	WriteCloneReport(&sb, groups, 1)
+	report := sb.String()
+	for _, expected := range []string{
+		"Found 2 groups of duplicated functions.",
+		"### Group 1 (exact, 2 copies, 120 tokens, 100% similar)",
+		"- `parseAll` (function) in `src/b.go:10`",
+		"*... and 1 more groups*",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("expected %q in report:\n%s", expected, report)
+		}
+	}
+
+	sb.Reset()
+	WriteCloneReport(&sb, nil, 0)
+	if !strings.Contains(sb.String(), "No duplicated functions found") {
+		t.Errorf("unexpected empty report: %s", sb.String())
+	}
+}
@@ -0,0 +1,331 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Cycle group types
+const (
+	CycleTypeImport  = "import"  // Files importing each other
+	CycleTypePackage = "package" // Directories whose files import each other
+)
+
+// DependencyLink is a single dependency from one file or package to another
+type DependencyLink struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// dependencyGraph is a directed graph of files or package directories with sorted adjacency lists
+type dependencyGraph map[string][]string
+
+// detectCircularDependencies reports every group of mutually dependent files and package directories
+func (ra *RelationshipAnalyzer) detectCircularDependencies(metrics *RelationshipMetrics) {
+	files, packages := ra.buildDependencyGraphs()
+	metrics.CircularDeps = append(metrics.CircularDeps, findCycleGroups(files, CycleTypeImport)...)
+	metrics.CircularDeps = append(metrics.CircularDeps, findCycleGroups(packages, CycleTypePackage)...)
+}
+
+// buildDependencyGraphs derives the file and directory dependency graphs from resolved imports
+func (ra *RelationshipAnalyzer) buildDependencyGraphs() (dependencyGraph, dependencyGraph) {
+	fileEdges := make(map[string]map[string]bool)
+	packageEdges := make(map[string]map[string]bool)
+	addEdge := func(edges map[string]map[string]bool, from, to string) {
+		if from == to {
+			return
+		}
+		if edges[from] == nil {
+			edges[from] = make(map[string]bool)
+		}
+		edges[from][to] = true
+	}
+
+	for filePath, fileNode := range ra.graph.Files {
+		for _, imp := range fileNode.Imports {
+			for _, targetFile := range ra.resolveImport(imp, filePath).Files {
+				addEdge(fileEdges, filePath, targetFile)
+				addEdge(packageEdges, filepath.Dir(filePath), filepath.Dir(targetFile))
+			}
+		}
+	}
+
+	toGraph := func(edges map[string]map[string]bool) dependencyGraph {
+		graph := make(dependencyGraph, len(edges))
+		for from, targets := range edges {
+			for to := range targets {
+				graph[from] = append(graph[from], to)
+			}
+			sort.Strings(graph[from])
+		}
+		return graph
+	}
+	return toGraph(fileEdges), toGraph(packageEdges)
+}
+
+// findCycleGroups returns one entry per strongly connected component with more than one member,
+// listing the shortest cycle through each member and a set of edges whose removal breaks every cycle
+func findCycleGroups(graph dependencyGraph, cycleType string) []CircularDependency {
+	groups := make([]CircularDependency, 0)
+	for _, component := range stronglyConnectedComponents(graph) {
+		if len(component) < 2 {
+			continue
+		}
+
+		subgraph := graph.restrict(component)
+		cycles := subgraph.minimalCycles()
+		group := CircularDependency{
+			Files:           component,
+			Type:            cycleType,
+			Cycles:          cycles,
+			SuggestedBreaks: subgraph.suggestBreaks(),
+		}
+		if len(cycles) > 0 {
+			group.Path = cycles[0]
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Files[0] < groups[j].Files[0] })
+	return groups
+}
+
+// stronglyConnectedComponents runs Tarjan's algorithm and returns each component's sorted members
+func stronglyConnectedComponents(graph dependencyGraph) [][]string {
+	nodes := graph.nodes()
+	index := make(map[string]int, len(nodes))
+	lowlink := make(map[string]int, len(nodes))
+	onStack := make(map[string]bool, len(nodes))
+	stack := make([]string, 0)
+	components := make([][]string, 0)
+	next := 0
+
+	var connect func(node string)
+	connect = func(node string) {
+		index[node] = next
+		lowlink[node] = next
+		next++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, target := range graph[node] {
+			if _, visited := index[target]; !visited {
+				connect(target)
+				lowlink[node] = min(lowlink[node], lowlink[target])
+			} else if onStack[target] {
+				lowlink[node] = min(lowlink[node], index[target])
+			}
+		}
+
+		// node is the root of a component: pop its members off the stack
+		if lowlink[node] == index[node] {
+			component := make([]string, 0)
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				component = append(component, member)
+				if member == node {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range nodes {
+		if _, visited := index[node]; !visited {
+			connect(node)
+		}
+	}
+	return components
+}
+
+// nodes returns every node of the graph, including nodes that only appear as targets, sorted
+func (g dependencyGraph) nodes() []string {
+	seen := make(map[string]bool)
+	for from, targets := range g {
+		seen[from] = true
+		for _, to := range targets {
+			seen[to] = true
+		}
+	}
+	nodes := make([]string, 0, len(seen))
+	for node := range seen {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// restrict returns the subgraph induced by members
+func (g dependencyGraph) restrict(members []string) dependencyGraph {
+	inside := make(map[string]bool, len(members))
+	for _, member := range members {
+		inside[member] = true
+	}
+	subgraph := make(dependencyGraph, len(members))
+	for _, from := range members {
+		for _, to := range g[from] {
+			if inside[to] {
+				subgraph[from] = append(subgraph[from], to)
+			}
+		}
+	}
+	return subgraph
+}
+
+// without returns a copy of the graph with one edge removed
+func (g dependencyGraph) without(link DependencyLink) dependencyGraph {
+	copied := make(dependencyGraph, len(g))
+	for from, targets := range g {
+		for _, to := range targets {
+			if from != link.From || to != link.To {
+				copied[from] = append(copied[from], to)
+			}
+		}
+	}
+	return copied
+}
+
+// shortestCycleThrough finds the shortest cycle starting and ending at start using breadth-first search
+func (g dependencyGraph) shortestCycleThrough(start string) []string {
+	parent := make(map[string]string)
+	queue := make([]string, 0)
+	for _, target := range g[start] {
+		if _, seen := parent[target]; !seen {
+			parent[target] = start
+			queue = append(queue, target)
+		}
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == start {
+			// Walk the parents back to rebuild start -> ... -> start
+			path := []string{start}
+			for current := parent[start]; current != start; current = parent[current] {
+				path = append(path, current)
+			}
+			path = append(path, start)
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		for _, target := range g[node] {
+			if _, seen := parent[target]; !seen {
+				parent[target] = node
+				queue = append(queue, target)
+			}
+		}
+	}
+	return nil
+}
+
+// minimalCycles returns the distinct shortest cycles through each node, shortest first. Each cycle
+// starts at its smallest member and repeats it at the end.
+func (g dependencyGraph) minimalCycles() [][]string {
+	seen := make(map[string]bool)
+	cycles := make([][]string, 0)
+	for _, node := range g.nodes() {
+		cycle := g.shortestCycleThrough(node)
+		if cycle == nil {
+			continue
+		}
+		cycle = rotateCycle(cycle)
+		key := strings.Join(cycle, "\x00")
+		if !seen[key] {
+			seen[key] = true
+			cycles = append(cycles, cycle)
+		}
+	}
+
+	sort.SliceStable(cycles, func(i, j int) bool {
+		if len(cycles[i]) != len(cycles[j]) {
+			return len(cycles[i]) < len(cycles[j])
+		}
+		return strings.Join(cycles[i], "\x00") < strings.Join(cycles[j], "\x00")
+	})
+	return cycles
+}
+
+// suggestBreaks greedily picks the edge shared by the most minimal cycles until the graph is acyclic.
+// Removing an edge leaves every cycle avoiding it intact, so only the nodes whose shortest cycle ran
+// through the removed edge are searched again.
+func (g dependencyGraph) suggestBreaks() []DependencyLink {
+	breaks := make([]DependencyLink, 0)
+	remaining := g
+	shortest := make(map[string][]string) // Node -> shortest cycle through it
+	for _, node := range g.nodes() {
+		if cycle := g.shortestCycleThrough(node); cycle != nil {
+			shortest[node] = cycle
+		}
+	}
+
+	for len(shortest) > 0 {
+		// Nodes on the same cycle share it, so each distinct cycle is counted once
+		seen := make(map[string]bool)
+		counts := make(map[DependencyLink]int)
+		for _, cycle := range shortest {
+			key := strings.Join(rotateCycle(cycle), "\x00")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			for i := 0; i+1 < len(cycle); i++ {
+				counts[DependencyLink{From: cycle[i], To: cycle[i+1]}]++
+			}
+		}
+
+		var best DependencyLink
+		bestCount := 0
+		for link, count := range counts {
+			if count > bestCount || (count == bestCount && (link.From < best.From || (link.From == best.From && link.To < best.To))) {
+				best, bestCount = link, count
+			}
+		}
+
+		breaks = append(breaks, best)
+		remaining = remaining.without(best)
+		for node, cycle := range shortest {
+			if !cycleContains(cycle, best) {
+				continue
+			}
+			if updated := remaining.shortestCycleThrough(node); updated != nil {
+				shortest[node] = updated
+			} else {
+				delete(shortest, node)
+			}
+		}
+	}
+	return breaks
+}
+
+// cycleContains reports whether a closed path runs along an edge
+func cycleContains(cycle []string, link DependencyLink) bool {
+	for i := 0; i+1 < len(cycle); i++ {
+		if cycle[i] == link.From && cycle[i+1] == link.To {
+			return true
+		}
+	}
+	return false
+}
+
+// rotateCycle rotates a closed path so that it starts and ends at its smallest member
+func rotateCycle(cycle []string) []string {
+	members := cycle[:len(cycle)-1]
+	smallest := 0
+	for i, member := range members {
+		if member < members[smallest] {
+			smallest = i
+		}
+	}
+	rotated := make([]string, 0, len(cycle))
+	rotated = append(rotated, members[smallest:]...)
+	rotated = append(rotated, members[:smallest]...)
+	return append(rotated, members[smallest])
+}
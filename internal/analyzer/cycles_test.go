@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindCycleGroups(t *testing.T) {
+	// a <-> b and b -> c -> a overlap on a -> b; d -> e is acyclic
+	graph := dependencyGraph{
+		"a": {"b"},
+		"b": {"a", "c"},
+		"c": {"a"},
+		"d": {"e"},
+	}
+
+	groups := findCycleGroups(graph, CycleTypeImport)
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 cycle group, got %d: %+v", len(groups), groups)
+	}
+
+	group := groups[0]
+	if !reflect.DeepEqual(group.Files, []string{"a", "b", "c"}) {
+		t.Errorf("Files = %v, expected [a b c]", group.Files)
+	}
+	expectedCycles := [][]string{{"a", "b", "a"}, {"a", "b", "c", "a"}}
+	if !reflect.DeepEqual(group.Cycles, expectedCycles) {
+		t.Errorf("Cycles = %v, expected %v", group.Cycles, expectedCycles)
+	}
+	if !reflect.DeepEqual(group.Path, expectedCycles[0]) {
+		t.Errorf("Path = %v, expected the shortest cycle", group.Path)
+	}
+	// a -> b lies on both cycles, so removing it alone is enough
+	if !reflect.DeepEqual(group.SuggestedBreaks, []DependencyLink{{From: "a", To: "b"}}) {
+		t.Errorf("SuggestedBreaks = %v, expected [a -> b]", group.SuggestedBreaks)
+	}
+}
+
+func TestDetectCircularDependenciesOverlapping(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "app/__init__.py", "python")
+	addTestFile(graph, "app/a.py", "python", "app.b")
+	addTestFile(graph, "app/b.py", "python", "app.a", "app.c")
+	addTestFile(graph, "app/c.py", "python", "app.b")
+	// No file imports itself back, but the pkg and lib directories depend on each other
+	addTestFile(graph, "pkg/__init__.py", "python")
+	addTestFile(graph, "pkg/x.py", "python", "lib.y")
+	addTestFile(graph, "pkg/z.py", "python")
+	addTestFile(graph, "lib/__init__.py", "python")
+	addTestFile(graph, "lib/y.py", "python", "pkg.z")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{
+		ByType:       make(map[RelationshipType]int),
+		CircularDeps: make([]CircularDependency, 0),
+	}
+	analyzer.detectCircularDependencies(metrics)
+
+	if len(metrics.CircularDeps) != 2 {
+		t.Fatalf("expected a file cycle group and a package cycle group, got %+v", metrics.CircularDeps)
+	}
+
+	files := metrics.CircularDeps[0]
+	if files.Type != CycleTypeImport || !reflect.DeepEqual(files.Files, []string{"app/a.py", "app/b.py", "app/c.py"}) {
+		t.Errorf("unexpected file cycle group: %+v", files)
+	}
+	if len(files.Cycles) != 2 || len(files.SuggestedBreaks) != 2 {
+		t.Errorf("expected both overlapping cycles and two breaks, got %v and %v", files.Cycles, files.SuggestedBreaks)
+	}
+
+	packages := metrics.CircularDeps[1]
+	if packages.Type != CycleTypePackage || !reflect.DeepEqual(packages.Path, []string{"lib", "pkg", "lib"}) {
+		t.Errorf("unexpected package cycle group: %+v", packages)
+	}
+}
+
+func TestSuggestBreaksDenseGraph(t *testing.T) {
+	// Every node imports every other one, so each pair forms a cycle and many longer cycles overlap
+	nodes := []string{"a", "b", "c", "d", "e", "f"}
+	graph := make(dependencyGraph)
+	for _, from := range nodes {
+		for _, to := range nodes {
+			if from != to {
+				graph[from] = append(graph[from], to)
+			}
+		}
+	}
+
+	breaks := graph.suggestBreaks()
+	remaining := graph
+	for _, link := range breaks {
+		remaining = remaining.without(link)
+	}
+	if cycles := remaining.minimalCycles(); len(cycles) != 0 {
+		t.Errorf("graph still has cycles %v after breaking %v", cycles, breaks)
+	}
+	// One direction of each of the 15 pairs has to go
+	if len(breaks) != 15 {
+		t.Errorf("expected 15 breaks, got %d: %v", len(breaks), breaks)
+	}
+}
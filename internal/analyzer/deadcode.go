@@ -0,0 +1,281 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/parser"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// DeadCodeOptions configures dead code detection
+type DeadCodeOptions struct {
+	AllowSymbols []string `json:"allow_symbols,omitempty"` // Name patterns of public API and framework-invoked symbols
+	AllowFiles   []string `json:"allow_files,omitempty"`   // Path patterns of files whose symbols are public API
+}
+
+// SymbolRef identifies a symbol in a report
+type SymbolRef struct {
+	Id   types.SymbolId   `json:"id"`
+	Name string           `json:"name"`
+	Type types.SymbolType `json:"type"`
+	File string           `json:"file"`
+	Line int              `json:"line"`
+}
+
+// DeadCodeReport lists code nothing appears to use
+type DeadCodeReport struct {
+	EntryPoints          []SymbolRef `json:"entry_points"`
+	UnusedExports        []SymbolRef `json:"unused_exports"`        // Exported symbols with no reference from another symbol or import
+	UnimportedFiles      []string    `json:"unimported_files"`      // Files no other file imports or uses
+	UnreachableFunctions []SymbolRef `json:"unreachable_functions"` // Functions no entry point reaches; empty without entry points
+}
+
+// deadCodeUsageEdges are the edge types that count as using their target symbol
+var deadCodeUsageEdges = map[string]bool{
+	string(RelationshipCalls):      true,
+	string(RelationshipReferences): true,
+	string(RelationshipExtends):    true,
+	string(RelationshipImplements): true,
+}
+
+// deadCodeExportTypes are the symbol types checked for unused exports. Variables, constants and
+// methods are left out because reads of them and dynamic dispatch are not tracked.
+var deadCodeExportTypes = map[types.SymbolType]bool{
+	types.SymbolTypeFunction:  true,
+	types.SymbolTypeClass:     true,
+	types.SymbolTypeInterface: true,
+	types.SymbolTypeType:      true,
+	types.SymbolTypeComponent: true,
+}
+
+// frameworkInvokedTypes are symbol types a framework calls without a reference in the code
+var frameworkInvokedTypes = map[types.SymbolType]bool{
+	types.SymbolTypeRoute:      true,
+	types.SymbolTypeMiddleware: true,
+	types.SymbolTypeLifecycle:  true,
+}
+
+// FindDeadCode reports exported symbols nothing references, files nothing imports and functions
+// unreachable from the detected entry points. The graph must already have its relationships analyzed.
+func FindDeadCode(graph *types.CodeGraph, options DeadCodeOptions) *DeadCodeReport {
+	ra := NewRelationshipAnalyzer(graph)
+	report := &DeadCodeReport{
+		EntryPoints:          make([]SymbolRef, 0),
+		UnusedExports:        make([]SymbolRef, 0),
+		UnimportedFiles:      make([]string, 0),
+		UnreachableFunctions: make([]SymbolRef, 0),
+	}
+
+	symbolRef := func(symbol *types.Symbol) SymbolRef {
+		return SymbolRef{Id: symbol.Id, Name: symbol.Name, Type: symbol.Type, File: ra.symbolFile(symbol.Id), Line: symbol.Location.StartLine}
+	}
+	allowed := func(symbol *types.Symbol) bool {
+		return matchesAnyPattern(options.AllowSymbols, symbol.Name) || matchesAnyPathPattern(options.AllowFiles, ra.symbolFile(symbol.Id))
+	}
+
+	// Symbols used from elsewhere, and files used by other files
+	used := make(map[types.NodeId]bool)
+	usedFiles := make(map[string]bool)
+	for _, edge := range graph.Edges {
+		if edge.From == edge.To {
+			continue
+		}
+		source, _ := edge.Metadata["source_file"].(string)
+		target, _ := edge.Metadata["target_file"].(string)
+		switch {
+		case deadCodeUsageEdges[edge.Type]:
+			used[edge.To] = true
+			if source != "" && target != "" && source != target {
+				usedFiles[target] = true
+			}
+		case edge.Type == string(RelationshipImport):
+			// Imports reach files directly or through the package node of their directory
+			if file := ra.extractFileFromNodeId(edge.To); file != "" {
+				usedFiles[file] = true
+			}
+			if node := graph.Nodes[edge.To]; node != nil && node.Type == "package" {
+				usedFiles[node.FilePath] = true
+			}
+		}
+	}
+	importedNames := ra.importedSpecifiers()
+
+	// Entry points seed reachability; allow-listed and framework-invoked symbols are roots as well
+	reachable := make(map[types.NodeId]bool)
+	queue := make([]types.NodeId, 0)
+	visit := func(nodeId types.NodeId) {
+		if !reachable[nodeId] {
+			reachable[nodeId] = true
+			queue = append(queue, nodeId)
+		}
+	}
+	entryFiles := make(map[string]bool)
+	routeHandlers := make(map[types.SymbolId]bool)
+	for _, route := range FindRoutes(graph) {
+		routeHandlers[route.HandlerId] = true
+	}
+
+	symbols := make([]*types.Symbol, 0, len(graph.Symbols))
+	for _, symbol := range graph.Symbols {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].Id < symbols[j].Id })
+
+	for _, symbol := range symbols {
+		filePath := ra.symbolFile(symbol.Id)
+		fileNode := graph.Files[filePath]
+		if fileNode == nil {
+			continue
+		}
+		nodeId := types.NodeId(fmt.Sprintf("symbol-%s", symbol.Id))
+		if isEntryPoint(symbol, fileNode) || routeHandlers[symbol.Id] {
+			report.EntryPoints = append(report.EntryPoints, symbolRef(symbol))
+			entryFiles[filePath] = true
+			visit(nodeId)
+		} else if allowed(symbol) || frameworkInvokedTypes[symbol.Type] || symbol.Type == types.SymbolTypeMethod {
+			// Methods are reached through dynamic dispatch the call graph cannot follow
+			visit(nodeId)
+		}
+	}
+	for _, edge := range graph.Edges {
+		// Top-level code runs when its file loads
+		if edge.Type == string(RelationshipCalls) && strings.HasPrefix(string(edge.From), "file-") {
+			visit(edge.To)
+		}
+	}
+
+	outgoing := make(map[types.NodeId][]types.NodeId)
+	for _, edge := range graph.Edges {
+		if deadCodeUsageEdges[edge.Type] {
+			outgoing[edge.From] = append(outgoing[edge.From], edge.To)
+		}
+	}
+	for len(queue) > 0 {
+		nodeId := queue[0]
+		queue = queue[1:]
+		for _, target := range outgoing[nodeId] {
+			visit(target)
+		}
+	}
+
+	for _, symbol := range symbols {
+		filePath := ra.symbolFile(symbol.Id)
+		fileNode := graph.Files[filePath]
+		if fileNode == nil || fileNode.IsTest || fileNode.IsGenerated || allowed(symbol) || !isIdentifier(symbol.Name) {
+			continue
+		}
+		nodeId := types.NodeId(fmt.Sprintf("symbol-%s", symbol.Id))
+
+		// Go types are used in bodies, literals and fields without an import naming them, which the
+		// graph does not track, so only Go functions are checked
+		checkExport := deadCodeExportTypes[symbol.Type] && (fileNode.Language != "go" || symbol.Type == types.SymbolTypeFunction)
+		if checkExport && parser.IsExported(symbol) && !used[nodeId] &&
+			!importedNames[filePath+"\x00"+symbol.Name] && !isEntryPoint(symbol, fileNode) {
+			report.UnusedExports = append(report.UnusedExports, symbolRef(symbol))
+		}
+		if len(report.EntryPoints) > 0 && symbol.Type == types.SymbolTypeFunction && !reachable[nodeId] {
+			report.UnreachableFunctions = append(report.UnreachableFunctions, symbolRef(symbol))
+		}
+	}
+
+	for filePath, fileNode := range graph.Files {
+		if fileNode.IsTest || fileNode.IsGenerated || len(fileNode.Symbols) == 0 || entryFiles[filePath] ||
+			usedFiles[filePath] || usedFiles[filepath.Dir(filePath)] || matchesAnyPathPattern(options.AllowFiles, filePath) {
+			continue
+		}
+		report.UnimportedFiles = append(report.UnimportedFiles, filePath)
+	}
+	sort.Strings(report.UnimportedFiles)
+	for _, symbols := range [][]SymbolRef{report.EntryPoints, report.UnusedExports, report.UnreachableFunctions} {
+		sortSymbolRefs(symbols)
+	}
+
+	return report
+}
+
+// sortSymbolRefs orders symbols by file and line
+func sortSymbolRefs(symbols []SymbolRef) {
+	sort.Slice(symbols, func(i, j int) bool {
+		if symbols[i].File != symbols[j].File {
+			return symbols[i].File < symbols[j].File
+		}
+		return symbols[i].Line < symbols[j].Line
+	})
+}
+
+// importedSpecifiers returns file + name keys for every name imported by specifier from a graph file
+func (ra *RelationshipAnalyzer) importedSpecifiers() map[string]bool {
+	names := make(map[string]bool)
+	for filePath, fileNode := range ra.graph.Files {
+		for _, imp := range fileNode.Imports {
+			if len(imp.Specifiers) == 0 {
+				continue
+			}
+			for _, target := range ra.resolveImport(imp, filePath).Files {
+				for _, specifier := range imp.Specifiers {
+					names[target+"\x00"+specifier] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// isEntryPoint reports whether a symbol is run by the toolchain rather than called from the code:
+// main functions, Go init functions, HTTP handlers and everything declared in test files
+func isEntryPoint(symbol *types.Symbol, fileNode *types.FileNode) bool {
+	if fileNode.IsTest {
+		return symbol.Type == types.SymbolTypeFunction || symbol.Type == types.SymbolTypeMethod
+	}
+	switch symbol.Type {
+	case types.SymbolTypeFunction, types.SymbolTypeMethod:
+		return symbol.Name == "main" || (fileNode.Language == "go" && symbol.Name == "init") ||
+			httpHandlerFramework(symbol, fileNode) != ""
+	}
+	return false
+}
+
+// isIdentifier filters out anonymous functions, whose extracted names are placeholders
+func isIdentifier(name string) bool {
+	if name == "" || name == "unknown" {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r == '$' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r > 127) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAnyPattern reports whether value matches one of the glob patterns
+func matchesAnyPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyPathPattern reports whether a path, or any trailing run of its segments, matches one of
+// the glob patterns, so "api/*.go" matches "/repo/internal/api/server.go". A "**" segment matches any
+// number of segments.
+func matchesAnyPathPattern(patterns []string, path string) bool {
+	path = filepath.ToSlash(path)
+	for {
+		for _, pattern := range patterns {
+			if matchPathGlob(pattern, path) {
+				return true
+			}
+		}
+		index := strings.Index(path, "/")
+		if index < 0 {
+			return false
+		}
+		path = path[index+1:]
+	}
+}
@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/internal/parser"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestFindDeadCode(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/main.ts", "typescript", "./app")
+	graph.Files["src/main.ts"].Imports[0].Specifiers = []string{"run", "Widget"}
+	addTestFile(graph, "src/app.ts", "typescript")
+	addTestFile(graph, "src/legacy.ts", "typescript")
+	addTestFile(graph, "src/api.ts", "typescript")
+	addTestFile(graph, "src/app.test.ts", "typescript", "./app")
+	graph.Files["src/app.test.ts"].IsTest = true
+
+	exported := func(symbol *types.Symbol) *types.Symbol {
+		symbol.Visibility = parser.VisibilityPublic
+		return symbol
+	}
+	main := addTestSymbol(graph, "src/main.ts", "main", types.SymbolTypeFunction, 3)
+	run := exported(addTestSymbol(graph, "src/app.ts", "run", types.SymbolTypeFunction, 1))
+	exported(addTestSymbol(graph, "src/app.ts", "Widget", types.SymbolTypeClass, 5))
+	exported(addTestSymbol(graph, "src/app.ts", "unusedExport", types.SymbolTypeFunction, 10))
+	exported(addTestSymbol(graph, "src/app.ts", "onRequest", types.SymbolTypeFunction, 15))
+	addTestSymbol(graph, "src/app.ts", "helper", types.SymbolTypeFunction, 20)
+	addTestSymbol(graph, "src/app.ts", "orphan", types.SymbolTypeFunction, 25)
+	exported(addTestSymbol(graph, "src/legacy.ts", "old", types.SymbolTypeFunction, 1))
+	exported(addTestSymbol(graph, "src/api.ts", "publicApi", types.SymbolTypeFunction, 1))
+	testRun := addTestSymbol(graph, "src/app.test.ts", "testRun", types.SymbolTypeFunction, 1)
+
+	addTestCall(graph, "src/main.ts", main, "", "run", 4)
+	addTestCall(graph, "src/app.ts", run, "", "helper", 2)
+	addTestCall(graph, "src/app.test.ts", testRun, "", "run", 2)
+
+	if _, err := NewRelationshipAnalyzer(graph).AnalyzeAllRelationships(); err != nil {
+		t.Fatalf("AnalyzeAllRelationships() error = %v", err)
+	}
+
+	report := FindDeadCode(graph, DeadCodeOptions{
+		AllowSymbols: []string{"on*"},
+		AllowFiles:   []string{"src/api.ts"},
+	})
+
+	names := func(symbols []SymbolRef) []string {
+		result := make([]string, 0, len(symbols))
+		for _, symbol := range symbols {
+			result = append(result, symbol.Name)
+		}
+		return result
+	}
+
+	if got := names(report.EntryPoints); !reflect.DeepEqual(got, []string{"testRun", "main"}) {
+		t.Errorf("EntryPoints = %v, expected [testRun main]", got)
+	}
+	// Widget is imported by name, run is called and onRequest is allow-listed
+	if got := names(report.UnusedExports); !reflect.DeepEqual(got, []string{"unusedExport", "old"}) {
+		t.Errorf("UnusedExports = %v, expected [unusedExport old]", got)
+	}
+	if !reflect.DeepEqual(report.UnimportedFiles, []string{"src/legacy.ts"}) {
+		t.Errorf("UnimportedFiles = %v, expected [src/legacy.ts]", report.UnimportedFiles)
+	}
+	if got := names(report.UnreachableFunctions); !reflect.DeepEqual(got, []string{"unusedExport", "orphan", "old"}) {
+		t.Errorf("UnreachableFunctions = %v, expected [unusedExport orphan old]", got)
+	}
+
+	var sb strings.Builder
+	WriteDeadCodeReport(&sb, report, 1)
+	content := sb.String()
+	if !strings.Contains(content, "### Unused Exports (2)") || !strings.Contains(content, "*... and 1 more*") ||
+		!strings.Contains(content, "- `src/legacy.ts`") {
+		t.Errorf("unexpected dead code markdown:\n%s", content)
+	}
+}
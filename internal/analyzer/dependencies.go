@@ -0,0 +1,371 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Dependency is a third-party library declared in a manifest, with the analyzed files importing it
+type Dependency struct {
+	Name      string   `json:"name"`
+	Ecosystem string   `json:"ecosystem"`
+	Version   string   `json:"version,omitempty"` // Version or range declared in the manifest
+	Locked    string   `json:"locked,omitempty"`  // Version pinned by a lockfile, comma-separated when several are installed
+	Manifest  string   `json:"manifest"`
+	Dev       bool     `json:"dev,omitempty"`
+	Files     []string `json:"files"` // Analyzed files importing the dependency
+}
+
+// languageEcosystems maps file languages to the ecosystem their external imports come from
+var languageEcosystems = map[string]string{
+	"go":         EcosystemGo,
+	"javascript": EcosystemNpm,
+	"typescript": EcosystemNpm,
+	"python":     EcosystemPyPI,
+	"rust":       EcosystemCargo,
+	"java":       EcosystemMaven,
+}
+
+// pythonDistributions maps import names to the distributions that provide them when the two differ
+var pythonDistributions = map[string]string{
+	"yaml":      "pyyaml",
+	"PIL":       "pillow",
+	"sklearn":   "scikit_learn",
+	"skimage":   "scikit_image",
+	"bs4":       "beautifulsoup4",
+	"cv2":       "opencv_python",
+	"dateutil":  "python_dateutil",
+	"jwt":       "pyjwt",
+	"dotenv":    "python_dotenv",
+	"attr":      "attrs",
+	"Crypto":    "pycryptodome",
+	"google":    "protobuf",
+	"magic":     "python_magic",
+	"serial":    "pyserial",
+	"usb":       "pyusb",
+	"git":       "gitpython",
+	"jose":      "python_jose",
+	"multipart": "python_multipart",
+}
+
+// dependencyManifest is a parsed manifest with its declared dependencies
+type dependencyManifest struct {
+	Path         string
+	Dir          string
+	Ecosystem    string
+	Dependencies []declaredDependency
+	Locked       map[string][]string // Normalized name -> locked versions
+}
+
+// dependencyInventory discovers the manifests enclosing analyzed files
+type dependencyInventory struct {
+	manifests []*dependencyManifest
+	visited   map[string]bool
+	lockfiles map[string]map[string][]string // Lockfile path -> parsed versions, nil when absent
+	roots     map[string]bool                // Analyzed source roots, where searches stop
+}
+
+// newDependencyInventory creates an empty inventory whose searches stop at the given source roots
+func newDependencyInventory(roots []string) *dependencyInventory {
+	di := &dependencyInventory{
+		visited:   make(map[string]bool),
+		lockfiles: make(map[string]map[string][]string),
+		roots:     make(map[string]bool, len(roots)),
+	}
+	for _, root := range roots {
+		di.roots[filepath.Clean(root)] = true
+	}
+	return di
+}
+
+// scan records the manifests in dir and every directory above it, up to the source root
+func (di *dependencyInventory) scan(dir string) {
+	for !di.visited[dir] {
+		di.visited[dir] = true
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			format, ok := manifestFormatFor(entry.Name())
+			if !ok || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			di.manifests = append(di.manifests, &dependencyManifest{
+				Path:         path,
+				Dir:          dir,
+				Ecosystem:    format.Ecosystem,
+				Dependencies: format.Parse(path),
+				Locked:       di.lockedVersions(dir, format),
+			})
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir || di.roots[dir] {
+			break
+		}
+		dir = parent
+	}
+}
+
+// manifestFormatFor returns the format of a manifest file name; any requirements*.txt is a pip requirements file
+func manifestFormatFor(name string) (manifestFormat, bool) {
+	if strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt") {
+		name = "requirements.txt"
+	}
+	format, ok := manifestFormats[name]
+	return format, ok
+}
+
+// lockedVersions reads the nearest lockfile of a manifest format at or above dir, up to the source
+// root; workspaces keep a single lockfile at their root
+func (di *dependencyInventory) lockedVersions(dir string, format manifestFormat) map[string][]string {
+	for {
+		for _, lockfile := range format.Lockfiles {
+			path := filepath.Join(dir, lockfile.Name)
+			versions, parsed := di.lockfiles[path]
+			if !parsed {
+				if _, err := os.Stat(path); err == nil {
+					versions = lockfile.Parse(path)
+				}
+				di.lockfiles[path] = versions
+			}
+			if versions != nil {
+				locked := make(map[string][]string, len(versions))
+				for lockedName, lockedVersions := range versions {
+					locked[dependencyKey(format.Ecosystem, lockedName)] = lockedVersions
+				}
+				return locked
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir || di.roots[dir] || len(format.Lockfiles) == 0 {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// match finds the dependency an external import of module from filePath refers to, preferring the
+// deepest manifest of the ecosystem that encloses the file and declares it
+func (di *dependencyInventory) match(filePath, ecosystem, module string) (*dependencyManifest, *declaredDependency) {
+	dir := filepath.Dir(filePath)
+	var best *dependencyManifest
+	var bestDependency *declaredDependency
+	for _, manifest := range di.manifests {
+		if manifest.Ecosystem != ecosystem || !withinDir(dir, manifest.Dir) {
+			continue
+		}
+		if best != nil && len(manifest.Dir) <= len(best.Dir) {
+			continue
+		}
+		if dependency := manifest.find(module); dependency != nil {
+			best, bestDependency = manifest, dependency
+		}
+	}
+	return best, bestDependency
+}
+
+// find returns the declared dependency providing an imported module
+func (dm *dependencyManifest) find(module string) *declaredDependency {
+	var found *declaredDependency
+	bestScore := 0
+	for i := range dm.Dependencies {
+		dependency := &dm.Dependencies[i]
+		score := 0
+		switch dm.Ecosystem {
+		case EcosystemGo:
+			// The longest module path containing the package wins
+			if module == dependency.Name || strings.HasPrefix(module, dependency.Name+"/") {
+				score = len(dependency.Name)
+			}
+		case EcosystemNpm:
+			if module == dependency.Name {
+				score = 1
+			}
+		case EcosystemPyPI:
+			key := dependencyKey(EcosystemPyPI, module)
+			if distribution, ok := pythonDistributions[module]; ok {
+				key = distribution
+			}
+			if key == dependencyKey(EcosystemPyPI, dependency.Name) {
+				score = 1
+			}
+		case EcosystemCargo:
+			if module == rustCrateName(dependency.Name) {
+				score = 1
+			}
+		case EcosystemMaven:
+			score = mavenMatchScore(module, dependency.Name)
+		}
+		if score > bestScore {
+			found, bestScore = dependency, score
+		}
+	}
+	return found
+}
+
+// mavenMatchScore scores how likely a Java package comes from a group:artifact dependency. Packages
+// usually start with the group id, or share its leading segments and name the artifact, as
+// com.fasterxml.jackson.databind does for com.fasterxml.jackson.core:jackson-databind.
+func mavenMatchScore(javaPackage, coordinates string) int {
+	group, artifact, _ := strings.Cut(coordinates, ":")
+	packageSegments := strings.Split(javaPackage, ".")
+	groupSegments := strings.Split(group, ".")
+
+	shared := 0
+	for shared < len(groupSegments) && shared < len(packageSegments) && groupSegments[shared] == packageSegments[shared] {
+		shared++
+	}
+	named := false
+	for _, token := range strings.FieldsFunc(artifact, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+		for _, segment := range packageSegments[min(shared, len(packageSegments)):] {
+			if token == segment {
+				named = true
+			}
+		}
+	}
+
+	switch {
+	case shared == len(groupSegments) && named:
+		return 3 * shared
+	case shared == len(groupSegments):
+		return 2 * shared
+	case shared >= 2 && named:
+		return shared
+	default:
+		return 0
+	}
+}
+
+// dependencyKey normalizes a dependency name for comparison: Python distributions compare case
+// and separator insensitively, and crates compare with dashes as underscores
+func dependencyKey(ecosystem, name string) string {
+	switch ecosystem {
+	case EcosystemPyPI:
+		return strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToLower(name))
+	case EcosystemCargo:
+		return rustCrateName(name)
+	default:
+		return name
+	}
+}
+
+// dependencyNodeId returns the graph node id of a dependency declared in a manifest
+func dependencyNodeId(manifest, name string) types.NodeId {
+	return types.NodeId(fmt.Sprintf("dependency-%s-%s", manifest, name))
+}
+
+// linkDependencies adds a node for every dependency declared in the manifests enclosing analyzed
+// files, and points external imports at the dependency providing them
+func (ra *RelationshipAnalyzer) linkDependencies() {
+	roots := make([]string, 0)
+	if metadata := ra.graph.Metadata; metadata != nil {
+		if metadata.ProjectPath != "" {
+			roots = append(roots, metadata.ProjectPath)
+		}
+		for _, root := range metadata.Roots {
+			roots = append(roots, root.Path)
+		}
+	}
+	inventory := newDependencyInventory(roots)
+	dirs := make(map[string]bool)
+	for filePath, fileNode := range ra.graph.Files {
+		if languageEcosystems[fileNode.Language] != "" {
+			dirs[filepath.Dir(filePath)] = true
+		}
+	}
+	for dir := range dirs {
+		inventory.scan(dir)
+	}
+
+	for _, manifest := range inventory.manifests {
+		for _, dependency := range manifest.Dependencies {
+			nodeId := dependencyNodeId(manifest.Path, dependency.Name)
+			ra.graph.Nodes[nodeId] = &types.GraphNode{
+				Id:       nodeId,
+				Type:     "dependency",
+				Label:    dependency.Name,
+				FilePath: manifest.Path,
+				Metadata: map[string]interface{}{
+					"name":      dependency.Name,
+					"ecosystem": manifest.Ecosystem,
+					"version":   dependency.Version,
+					"locked":    strings.Join(manifest.Locked[dependencyKey(manifest.Ecosystem, dependency.Name)], ", "),
+					"dev":       dependency.Dev,
+				},
+			}
+		}
+	}
+
+	for _, edge := range ra.graph.Edges {
+		if edge.Type != string(RelationshipImport) || edge.Metadata["is_external"] != true || edge.Metadata["is_stdlib"] == true {
+			continue
+		}
+		filePath := ra.extractFileFromNodeId(edge.From)
+		fileNode := ra.graph.Files[filePath]
+		if fileNode == nil {
+			continue
+		}
+		module, _ := edge.Metadata["module"].(string)
+		if module == "" {
+			module, _ = edge.Metadata["import_path"].(string)
+		}
+		manifest, dependency := inventory.match(filePath, languageEcosystems[fileNode.Language], module)
+		if dependency == nil {
+			continue
+		}
+		edge.To = dependencyNodeId(manifest.Path, dependency.Name)
+		edge.Metadata["dependency"] = dependency.Name
+	}
+}
+
+// Dependencies lists the third-party dependencies recorded in the graph, most used first
+func Dependencies(graph *types.CodeGraph) []Dependency {
+	byNode := make(map[types.NodeId]*Dependency)
+	for nodeId, node := range graph.Nodes {
+		if node.Type != "dependency" {
+			continue
+		}
+		dependency := &Dependency{Name: node.Label, Manifest: node.FilePath, Files: make([]string, 0)}
+		dependency.Ecosystem, _ = node.Metadata["ecosystem"].(string)
+		dependency.Version, _ = node.Metadata["version"].(string)
+		dependency.Locked, _ = node.Metadata["locked"].(string)
+		dependency.Dev, _ = node.Metadata["dev"].(bool)
+		byNode[nodeId] = dependency
+	}
+
+	importers := make(map[types.NodeId]map[string]bool)
+	for _, edge := range graph.Edges {
+		if byNode[edge.To] == nil {
+			continue
+		}
+		if importers[edge.To] == nil {
+			importers[edge.To] = make(map[string]bool)
+		}
+		importers[edge.To][strings.TrimPrefix(string(edge.From), "file-")] = true
+	}
+
+	dependencies := make([]Dependency, 0, len(byNode))
+	for nodeId, dependency := range byNode {
+		for filePath := range importers[nodeId] {
+			dependency.Files = append(dependency.Files, filePath)
+		}
+		sort.Strings(dependency.Files)
+		dependencies = append(dependencies, *dependency)
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		if len(dependencies[i].Files) != len(dependencies[j].Files) {
+			return len(dependencies[i].Files) > len(dependencies[j].Files)
+		}
+		if dependencies[i].Name != dependencies[j].Name {
+			return dependencies[i].Name < dependencies[j].Name
+		}
+		return dependencies[i].Manifest < dependencies[j].Manifest
+	})
+	return dependencies
+}
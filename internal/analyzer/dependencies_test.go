@@ -0,0 +1,306 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// dependencyNames returns the sorted "name@version" pairs of declared dependencies, marking dev ones
+func dependencyNames(dependencies []declaredDependency) []string {
+	names := make([]string, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		name := dependency.Name + "@" + dependency.Version
+		if dependency.Dev {
+			name += " (dev)"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestParseManifests(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		content  string
+		parse    func(string) []declaredDependency
+		expected []string
+	}{
+		{
+			name: "requirements-dev.txt",
+			content: `# tooling
+-r requirements.txt
+pytest>=8.0  # runner
+black[jupyter]==24.1.0; python_version >= "3.9"
+-e ./local
+`,
+			parse:    parseRequirementsDependencies,
+			expected: []string{"black@==24.1.0 (dev)", "pytest@>=8.0 (dev)"},
+		},
+		{
+			name: "pyproject.toml",
+			content: `[project]
+name = "app"
+dependencies = [
+    "requests>=2.31",  # http
+    "PyYAML",
+]
+
+[project.optional-dependencies]
+test = ["pytest"]
+
+[tool.poetry.dependencies]
+python = "^3.11"
+httpx = { version = "^0.27", extras = ["http2"] }
+
+[tool.poetry.group.lint.dependencies]
+ruff = "^0.4"
+`,
+			parse:    parsePyprojectDependencies,
+			expected: []string{"PyYAML@", "httpx@^0.27", "pytest@ (dev)", "requests@>=2.31", "ruff@^0.4 (dev)"},
+		},
+		{
+			name: "pom.xml",
+			content: `<project>
+  <version>1.0.0</version>
+  <properties><guava.version>33.0-jre</guava.version></properties>
+  <dependencies>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId><version>${guava.version}</version></dependency>
+    <dependency><groupId>org.junit.jupiter</groupId><artifactId>junit-jupiter</artifactId><version>5.10.2</version><scope>test</scope></dependency>
+  </dependencies>
+</project>`,
+			parse:    parsePomDependencies,
+			expected: []string{"com.google.guava:guava@33.0-jre", "org.junit.jupiter:junit-jupiter@5.10.2 (dev)"},
+		},
+		{
+			name: "build.gradle.kts",
+			content: `dependencies {
+    implementation("com.fasterxml.jackson.core:jackson-databind:2.17.0")
+    testImplementation 'junit:junit:4.13.2'
+    implementation(project(":core"))
+    classpath("com.android.tools.build:gradle:8.3.0")
+}`,
+			parse:    parseGradleDependencies,
+			expected: []string{"com.fasterxml.jackson.core:jackson-databind@2.17.0", "junit:junit@4.13.2 (dev)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestFile(t, dir, tt.name, tt.content)
+			if names := dependencyNames(tt.parse(path)); !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("parsed %v, expected %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseLockfiles(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		content  string
+		parse    func(string) map[string][]string
+		expected map[string][]string
+	}{
+		{
+			name: "package-lock.json",
+			content: `{"lockfileVersion": 3, "packages": {
+  "": {"name": "app"},
+  "node_modules/react": {"version": "18.3.1"},
+  "node_modules/@types/node": {"version": "20.12.7"},
+  "node_modules/a/node_modules/react": {"version": "17.0.2"}
+}}`,
+			parse:    parsePackageLock,
+			expected: map[string][]string{"react": {"18.3.1"}, "@types/node": {"20.12.7"}},
+		},
+		{
+			name: "yarn.lock",
+			content: `# yarn lockfile v1
+
+"@babel/core@^7.0.0", "@babel/core@^7.24.0":
+  version "7.24.4"
+
+lodash@^4.17.21:
+  version "4.17.21"
+`,
+			parse:    parseYarnLock,
+			expected: map[string][]string{"@babel/core": {"7.24.4"}, "lodash": {"4.17.21"}},
+		},
+		{
+			name: "pnpm-lock.yaml",
+			content: `lockfileVersion: '9.0'
+
+packages:
+
+  '@vue/shared@3.4.21':
+    resolution: {integrity: sha512-abc}
+
+  vue@3.4.21(typescript@5.4.5):
+    resolution: {integrity: sha512-def}
+
+snapshots:
+
+  vue@3.4.21: {}
+`,
+			parse:    parsePnpmLock,
+			expected: map[string][]string{"@vue/shared": {"3.4.21"}, "vue": {"3.4.21"}},
+		},
+		{
+			name: "Cargo.lock",
+			content: `version = 3
+
+[[package]]
+name = "serde"
+version = "1.0.200"
+
+[[package]]
+name = "syn"
+version = "1.0.109"
+
+[[package]]
+name = "syn"
+version = "2.0.60"
+dependencies = [
+ "serde",
+]
+`,
+			parse:    parseTomlPackageLock,
+			expected: map[string][]string{"serde": {"1.0.200"}, "syn": {"1.0.109", "2.0.60"}},
+		},
+		{
+			name:     "gradle.lockfile",
+			content:  "# Gradle lockfile\ncom.google.guava:guava:33.0.0-jre=compileClasspath,runtimeClasspath\nempty=\n",
+			parse:    parseGradleLock,
+			expected: map[string][]string{"com.google.guava:guava": {"33.0.0-jre"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestFile(t, dir, tt.name, tt.content)
+			if versions := tt.parse(path); !reflect.DeepEqual(versions, tt.expected) {
+				t.Errorf("parsed %v, expected %v", versions, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMavenMatchScore(t *testing.T) {
+	tests := []struct {
+		javaPackage string
+		coordinates string
+		matches     bool
+	}{
+		{"com.google.common.collect", "com.google.guava:guava", false},
+		{"com.fasterxml.jackson.databind", "com.fasterxml.jackson.core:jackson-databind", true},
+		{"org.junit.jupiter.api", "org.junit.jupiter:junit-jupiter", true},
+		{"org.slf4j", "org.slf4j:slf4j-api", true},
+		{"org.apache.commons.io", "org.apache.commons:commons-lang3", true},
+		{"org.apache.kafka.clients", "org.apache.commons:commons-lang3", false},
+	}
+
+	for _, tt := range tests {
+		if score := mavenMatchScore(tt.javaPackage, tt.coordinates); (score > 0) != tt.matches {
+			t.Errorf("mavenMatchScore(%q, %q) = %d, expected a match: %v", tt.javaPackage, tt.coordinates, score, tt.matches)
+		}
+	}
+
+	// The artifact named by the package wins among artifacts of one group
+	if mavenMatchScore("org.apache.commons.io", "org.apache.commons:commons-io") <= mavenMatchScore("org.apache.commons.io", "org.apache.commons:commons-lang3") {
+		t.Error("expected commons-io to score above commons-lang3 for org.apache.commons.io")
+	}
+}
+
+func TestLinkDependencies(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.22\n\nrequire (\n\tgithub.com/spf13/cobra v1.9.1\n\tgithub.com/stretchr/testify v1.9.0\n)\n")
+	web := filepath.Join(dir, "web")
+	writeTestFile(t, web, "package.json", `{"name": "web", "dependencies": {"react": "^18.2.0"}, "devDependencies": {"vitest": "^1.5.0"}}`)
+	writeTestFile(t, dir, "package-lock.json", `{"packages": {"node_modules/react": {"version": "18.3.1"}}}`)
+	scripts := filepath.Join(dir, "scripts")
+	writeTestFile(t, scripts, "requirements.txt", "PyYAML==6.0.1\nrequests\n")
+
+	graph := newEmptyTestGraph()
+	command := filepath.Join(dir, "cmd", "main.go")
+	page := filepath.Join(web, "src", "page.tsx")
+	script := filepath.Join(scripts, "build.py")
+	addTestFile(graph, command, "go", "github.com/spf13/cobra/doc", "fmt", "golang.org/x/sync/errgroup")
+	addTestFile(graph, page, "typescript", "react", "left-pad")
+	addTestFile(graph, script, "python", "yaml", "os")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	if _, err := analyzer.AnalyzeAllRelationships(); err != nil {
+		t.Fatalf("AnalyzeAllRelationships() error = %v", err)
+	}
+
+	cobra := graph.Edges[types.EdgeId("external-import-"+command+"-github.com/spf13/cobra/doc")]
+	if cobra == nil || cobra.To != dependencyNodeId(filepath.Join(dir, "go.mod"), "github.com/spf13/cobra") || cobra.Metadata["dependency"] != "github.com/spf13/cobra" {
+		t.Errorf("cobra import not linked to its dependency: %+v", cobra)
+	}
+	if edge := graph.Edges[types.EdgeId("external-import-"+command+"-golang.org/x/sync/errgroup")]; edge == nil || edge.To != "external-golang.org/x/sync/errgroup" {
+		t.Errorf("undeclared module should stay external: %+v", edge)
+	}
+	if edge := graph.Edges[types.EdgeId("external-import-"+script+"-yaml")]; edge == nil || edge.Metadata["dependency"] != "PyYAML" {
+		t.Errorf("yaml import not linked to PyYAML: %+v", edge)
+	}
+
+	dependencies := Dependencies(graph)
+	byName := make(map[string]Dependency)
+	for _, dependency := range dependencies {
+		byName[dependency.Name] = dependency
+	}
+	if len(dependencies) != 6 {
+		t.Fatalf("expected 6 dependencies, got %+v", dependencies)
+	}
+	if react := byName["react"]; react.Version != "^18.2.0" || react.Locked != "18.3.1" || react.Ecosystem != EcosystemNpm || !reflect.DeepEqual(react.Files, []string{page}) {
+		t.Errorf("unexpected react dependency: %+v", react)
+	}
+	if vitest := byName["vitest"]; !vitest.Dev || len(vitest.Files) != 0 {
+		t.Errorf("unexpected vitest dependency: %+v", vitest)
+	}
+	if testify := byName["github.com/stretchr/testify"]; testify.Version != "v1.9.0" || len(testify.Files) != 0 {
+		t.Errorf("unexpected testify dependency: %+v", testify)
+	}
+
+	content := NewMarkdownGenerator(graph).GenerateContextMap()
+	for _, expected := range []string{
+		"## 🧩 Dependencies",
+		"6 third-party dependencies declared in 3 manifests, 3 imported by analyzed files.",
+		"| `react` | npm | ^18.2.0 | 18.3.1 | `" + page + "` |",
+		"| `vitest` (dev) | npm | ^1.5.0 |  |  |",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("dependencies section missing %q:\n%s", expected, content)
+		}
+	}
+}
+
+func TestLinkDependenciesWithinRoot(t *testing.T) {
+	dir := t.TempDir()
+	// A manifest above the analyzed directory belongs to another project
+	writeTestFile(t, dir, "package.json", `{"name": "outer", "dependencies": {"express": "^4.19.0"}}`)
+	project := filepath.Join(dir, "project")
+	writeTestFile(t, project, "package.json", `{"name": "web", "dependencies": {"react": "^18.2.0"}}`)
+	// npm's lockfile wins over a leftover yarn.lock
+	writeTestFile(t, project, "package-lock.json", `{"packages": {"node_modules/react": {"version": "18.3.1"}}}`)
+	writeTestFile(t, project, "yarn.lock", "react@^18.2.0:\n  version \"18.2.0\"\n")
+
+	graph := newEmptyTestGraph()
+	graph.Metadata.ProjectPath = project
+	addTestFile(graph, filepath.Join(project, "src", "page.tsx"), "typescript", "react", "express")
+	if _, err := NewRelationshipAnalyzer(graph).AnalyzeAllRelationships(); err != nil {
+		t.Fatalf("AnalyzeAllRelationships() error = %v", err)
+	}
+
+	dependencies := Dependencies(graph)
+	if len(dependencies) != 1 || dependencies[0].Name != "react" || dependencies[0].Locked != "18.3.1" {
+		t.Errorf("expected only react locked at 18.3.1, got %+v", dependencies)
+	}
+}
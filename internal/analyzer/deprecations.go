@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/parser"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// deprecationUsageEdges are the edge types whose sites use a deprecated symbol
+var deprecationUsageEdges = map[string]bool{
+	string(RelationshipCalls):      true,
+	string(RelationshipReferences): true,
+}
+
+// DeprecatedSymbol is a symbol marked deprecated, with every site still using it
+type DeprecatedSymbol struct {
+	Symbol  SymbolRef         `json:"symbol"`
+	Message string            `json:"message,omitempty"` // Deprecation notice, usually naming the replacement
+	Usages  []DeprecatedUsage `json:"usages"`
+}
+
+// DeprecatedUsage is a call of or reference to a deprecated symbol
+type DeprecatedUsage struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column,omitempty"`
+	User   string `json:"user,omitempty"` // Symbol containing the usage; empty at the top level of a file
+	Kind   string `json:"kind"`           // calls or references
+}
+
+// MigrationHotspot is a file using deprecated symbols, ranked by how much migration work it holds
+type MigrationHotspot struct {
+	File    string   `json:"file"`
+	Usages  int      `json:"usages"`
+	Symbols []string `json:"symbols"` // Names of the deprecated symbols used
+}
+
+// DeprecationReport lists the deprecated symbols of a codebase and the files still using them
+type DeprecationReport struct {
+	Deprecated []DeprecatedSymbol `json:"deprecated"`
+	Hotspots   []MigrationHotspot `json:"hotspots"`
+}
+
+// FindDeprecations reports the symbols marked deprecated, from Go deprecation notices, JSDoc and
+// Javadoc tags, Java @Deprecated, Rust #[deprecated] and Python deprecation warnings, and the call and
+// reference sites using them. The graph must already have its relationships analyzed.
+func FindDeprecations(graph *types.CodeGraph) *DeprecationReport {
+	ra := NewRelationshipAnalyzer(graph)
+	report := &DeprecationReport{
+		Deprecated: make([]DeprecatedSymbol, 0),
+		Hotspots:   make([]MigrationHotspot, 0),
+	}
+
+	deprecated := make(map[types.NodeId]*DeprecatedSymbol)
+	for _, fileNode := range graph.Files {
+		for _, annotation := range fileNode.Annotations {
+			symbol := graph.Symbols[annotation.Symbol]
+			if annotation.Kind != parser.AnnotationDeprecated || symbol == nil {
+				continue
+			}
+			nodeId := types.NodeId(fmt.Sprintf("symbol-%s", symbol.Id))
+			entry := deprecated[nodeId]
+			if entry == nil {
+				entry = &DeprecatedSymbol{
+					Symbol: SymbolRef{Id: symbol.Id, Name: symbol.Name, Type: symbol.Type, File: ra.symbolFile(symbol.Id), Line: symbol.Location.StartLine},
+					Usages: make([]DeprecatedUsage, 0),
+				}
+				deprecated[nodeId] = entry
+			}
+			if entry.Message == "" {
+				entry.Message = annotation.Text
+			}
+		}
+	}
+	if len(deprecated) == 0 {
+		return report
+	}
+
+	for _, edge := range graph.Edges {
+		entry := deprecated[edge.To]
+		if entry == nil || edge.From == edge.To || !deprecationUsageEdges[edge.Type] {
+			continue
+		}
+		user := ""
+		userLine := 0
+		if symbol := graph.Symbols[types.SymbolId(strings.TrimPrefix(string(edge.From), "symbol-"))]; symbol != nil {
+			user = symbol.Name
+			userLine = symbol.Location.StartLine
+		}
+
+		// Call edges record each call site; references are located at the symbol making them
+		if sites, ok := edge.Metadata["call_sites"].([]CallSite); ok {
+			for _, site := range sites {
+				entry.Usages = append(entry.Usages, DeprecatedUsage{File: site.File, Line: site.Line, Column: site.Column, User: user, Kind: edge.Type})
+			}
+			continue
+		}
+		source, _ := edge.Metadata["source_file"].(string)
+		entry.Usages = append(entry.Usages, DeprecatedUsage{File: source, Line: userLine, User: user, Kind: edge.Type})
+	}
+
+	hotspots := make(map[string]*MigrationHotspot)
+	for _, entry := range deprecated {
+		sort.Slice(entry.Usages, func(i, j int) bool {
+			a, b := entry.Usages[i], entry.Usages[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			if a.Line != b.Line {
+				return a.Line < b.Line
+			}
+			return a.Column < b.Column
+		})
+		for _, usage := range entry.Usages {
+			hotspot := hotspots[usage.File]
+			if hotspot == nil {
+				hotspot = &MigrationHotspot{File: usage.File, Symbols: make([]string, 0)}
+				hotspots[usage.File] = hotspot
+			}
+			hotspot.Usages++
+			if !slices.Contains(hotspot.Symbols, entry.Symbol.Name) {
+				hotspot.Symbols = append(hotspot.Symbols, entry.Symbol.Name)
+			}
+		}
+		report.Deprecated = append(report.Deprecated, *entry)
+	}
+
+	// Most used first, so the symbols blocking their removal the most lead the report
+	sort.Slice(report.Deprecated, func(i, j int) bool {
+		a, b := report.Deprecated[i], report.Deprecated[j]
+		if len(a.Usages) != len(b.Usages) {
+			return len(a.Usages) > len(b.Usages)
+		}
+		return a.Symbol.Id < b.Symbol.Id
+	})
+	for _, hotspot := range hotspots {
+		sort.Strings(hotspot.Symbols)
+		report.Hotspots = append(report.Hotspots, *hotspot)
+	}
+	sort.Slice(report.Hotspots, func(i, j int) bool {
+		a, b := report.Hotspots[i], report.Hotspots[j]
+		if a.Usages != b.Usages {
+			return a.Usages > b.Usages
+		}
+		return a.File < b.File
+	})
+	return report
+}
+
+// UsageCount returns the number of sites using deprecated symbols
+func (r *DeprecationReport) UsageCount() int {
+	count := 0
+	for _, entry := range r.Deprecated {
+		count += len(entry.Usages)
+	}
+	return count
+}
+
+// WriteDeprecationReport writes the deprecated symbols and the migration hot spots as markdown. A
+// positive limit caps the number of symbols and files listed.
+func WriteDeprecationReport(sb *strings.Builder, report *DeprecationReport, limit int) {
+	if len(report.Deprecated) == 0 {
+		sb.WriteString("*No deprecated symbols found.*\n")
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("Found %d deprecated symbols with %d usages.\n\n", len(report.Deprecated), report.UsageCount()))
+	sb.WriteString("| Symbol | Defined In | Notice | Usages |\n")
+	sb.WriteString("|--------|------------|--------|--------|\n")
+	for i, entry := range report.Deprecated {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("\n*... and %d more deprecated symbols*\n", len(report.Deprecated)-limit))
+			break
+		}
+		notice := strings.ReplaceAll(entry.Message, "|", "\\|")
+		sb.WriteString(fmt.Sprintf("| `%s` | `%s:%d` | %s | %d |\n", entry.Symbol.Name, entry.Symbol.File, entry.Symbol.Line, notice, len(entry.Usages)))
+	}
+
+	if len(report.Hotspots) == 0 {
+		sb.WriteString("\n*No usages of deprecated symbols remain.*\n")
+		return
+	}
+	sb.WriteString("\n### Migration Hot Spots\n\n")
+	sb.WriteString("| File | Usages | Deprecated Symbols |\n")
+	sb.WriteString("|------|--------|--------------------|\n")
+	for i, hotspot := range report.Hotspots {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("\n*... and %d more files*\n", len(report.Hotspots)-limit))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %d | `%s` |\n", hotspot.File, hotspot.Usages, strings.Join(hotspot.Symbols, "`, `")))
+	}
+}
@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestFindDeprecations(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/store.ts", "typescript")
+	addTestFile(graph, "src/app.ts", "typescript", "./store")
+	addTestFile(graph, "src/jobs.ts", "typescript", "./store")
+
+	makeStore := addTestSymbol(graph, "src/store.ts", "makeStore", types.SymbolTypeFunction, 5)
+	addTestSymbol(graph, "src/store.ts", "createStore", types.SymbolTypeFunction, 10)
+	legacy := addTestSymbol(graph, "src/store.ts", "LegacyStore", types.SymbolTypeClass, 20)
+	unused := addTestSymbol(graph, "src/store.ts", "unusedOld", types.SymbolTypeFunction, 30)
+	graph.Files["src/store.ts"].Annotations = []*types.Annotation{
+		{Kind: "DEPRECATED", Text: "use createStore | build", Symbol: makeStore.Id, Location: types.FileLocation{Line: 4}},
+		{Kind: "TODO", Text: "remove", Symbol: makeStore.Id, Location: types.FileLocation{Line: 4}},
+		{Kind: "DEPRECATED", Symbol: legacy.Id, Location: types.FileLocation{Line: 19}},
+		{Kind: "DEPRECATED", Text: "not needed", Symbol: unused.Id, Location: types.FileLocation{Line: 29}},
+		{Kind: "DEPRECATED", Text: "file level"},
+	}
+
+	run := addTestSymbol(graph, "src/app.ts", "run", types.SymbolTypeFunction, 3)
+	job := addTestSymbol(graph, "src/jobs.ts", "job", types.SymbolTypeFunction, 7)
+	addTestCall(graph, "src/app.ts", run, "", "makeStore", 4)
+	addTestCall(graph, "src/app.ts", run, "", "makeStore", 8)
+	addTestCall(graph, "src/app.ts", nil, "", "createStore", 12)
+	addTestCall(graph, "src/jobs.ts", job, "", "makeStore", 9)
+
+	if _, err := NewRelationshipAnalyzer(graph).AnalyzeAllRelationships(); err != nil {
+		t.Fatalf("AnalyzeAllRelationships() error = %v", err)
+	}
+	graph.Edges["ref-run-legacy"] = &types.GraphEdge{
+		Id:       "ref-run-legacy",
+		From:     types.NodeId("symbol-" + run.Id),
+		To:       types.NodeId("symbol-" + legacy.Id),
+		Type:     string(RelationshipReferences),
+		Metadata: map[string]interface{}{"source_file": "src/app.ts", "target_file": "src/store.ts"},
+	}
+
+	report := FindDeprecations(graph)
+
+	names := make([]string, 0, len(report.Deprecated))
+	for _, entry := range report.Deprecated {
+		names = append(names, entry.Symbol.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"makeStore", "LegacyStore", "unusedOld"}) {
+		t.Fatalf("Deprecated = %v, expected symbols ordered by usages", names)
+	}
+
+	expected := []DeprecatedUsage{
+		{File: "src/app.ts", Line: 4, Column: 5, User: "run", Kind: "calls"},
+		{File: "src/app.ts", Line: 8, Column: 5, User: "run", Kind: "calls"},
+		{File: "src/jobs.ts", Line: 9, Column: 5, User: "job", Kind: "calls"},
+	}
+	if !reflect.DeepEqual(report.Deprecated[0].Usages, expected) {
+		t.Errorf("makeStore usages = %+v, expected %+v", report.Deprecated[0].Usages, expected)
+	}
+	if usages := report.Deprecated[1].Usages; len(usages) != 1 || usages[0].Kind != "references" || usages[0].Line != 3 {
+		t.Errorf("LegacyStore usages = %+v, expected the reference from run", usages)
+	}
+
+	expectedHotspots := []MigrationHotspot{
+		{File: "src/app.ts", Usages: 3, Symbols: []string{"LegacyStore", "makeStore"}},
+		{File: "src/jobs.ts", Usages: 1, Symbols: []string{"makeStore"}},
+	}
+	if !reflect.DeepEqual(report.Hotspots, expectedHotspots) {
+		t.Errorf("Hotspots = %+v, expected %+v", report.Hotspots, expectedHotspots)
+	}
+
+	var sb strings.Builder
+	WriteDeprecationReport(&sb, report, 0)
+	for _, expected := range []string{
+		"Found 3 deprecated symbols with 4 usages.",
+		"| `makeStore` | `src/store.ts:5` | use createStore \\| build | 3 |",
+		"| `unusedOld` | `src/store.ts:30` | not needed | 0 |",
+		"### Migration Hot Spots",
+		"| `src/app.ts` | 3 | `LegacyStore`, `makeStore` |",
+	} {
+		if !strings.Contains(sb.String(), expected) {
+			t.Errorf("report missing %q:\n%s", expected, sb.String())
+		}
+	}
+
+	sb.Reset()
+	WriteDeprecationReport(&sb, FindDeprecations(newEmptyTestGraph()), 0)
+	if sb.String() != "*No deprecated symbols found.*\n" {
+		t.Errorf("unexpected empty report: %q", sb.String())
+	}
+}
@@ -0,0 +1,551 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Documentation kinds
+const (
+	DocKindReadme = "readme"
+	DocKindADR    = "adr"   // Architecture decision record
+	DocKindGuide  = "guide" // Any other markdown document
+)
+
+// docExtensions are the extensions of the markdown documents linked to the code
+var docExtensions = map[string]bool{".md": true, ".markdown": true, ".mdx": true}
+
+// adrDirs name the directories holding architecture decision records
+var adrDirs = map[string]bool{"adr": true, "adrs": true, "decisions": true, "architecture-decisions": true}
+
+const (
+	maxDocSize     = 1 << 20 // Larger documents are generated or vendored rather than written
+	maxDocExcerpt  = 600     // Characters of prose kept from each section
+	maxDocTargets  = 3       // Mentions matching more symbols or files than this are too ambiguous to link
+	minMentionName = 3       // Shorter names match too many unrelated symbols
+)
+
+var (
+	headingPattern  = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	fencePattern    = regexp.MustCompile("^ {0,3}(```|~~~)")
+	codeSpanPattern = regexp.MustCompile("`([^`]+)`")
+	linkPattern     = regexp.MustCompile(`\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	// identifierPattern matches a code span naming a symbol, optionally qualified as in pkg.Name or
+	// Type::method, or called as in run()
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(?:(?:\.|::)[A-Za-z_][A-Za-z0-9_]*)*(?:\(\))?$`)
+	// proseIdentifierPattern matches words in prose shaped like identifiers rather than English:
+	// camelCase, PascalCase with several humps and snake_case
+	proseIdentifierPattern = regexp.MustCompile(`\b(?:[a-z][a-z0-9]*[A-Z][A-Za-z0-9]*|[A-Z][a-z0-9]+[A-Z][A-Za-z0-9]*|[A-Za-z][A-Za-z0-9]*_[A-Za-z0-9_]+)\b`)
+)
+
+// DocSection is a section of a markdown document, from one heading to the next
+type DocSection struct {
+	Document string `json:"document"`
+	Title    string `json:"title"` // First top-level heading of the document, or its file name
+	Kind     string `json:"kind"`  // DocKindReadme, DocKindADR or DocKindGuide
+	Heading  string `json:"heading"`
+	Level    int    `json:"level"` // Heading level; 0 for the text before the first heading
+	Line     int    `json:"line"`
+	EndLine  int    `json:"end_line"`
+	Excerpt  string `json:"excerpt,omitempty"` // Start of the prose of the section
+}
+
+// DocReference is a documentation section mentioning a file or its symbols
+type DocReference struct {
+	Section  DocSection `json:"section"`
+	Mentions []string   `json:"mentions"` // Names of the mentioned symbols, or the file itself
+}
+
+// docMention is a symbol or file name written in a document
+type docMention struct {
+	text string
+	line int
+	path bool // A link or file path rather than an identifier
+}
+
+// markdownSection is a parsed section with the mentions found in it
+type markdownSection struct {
+	DocSection
+	mentions []docMention
+}
+
+// isDocumentationFile reports whether a path is a markdown document
+func isDocumentationFile(path string) bool {
+	return docExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// docKind classifies a document as a README, an architecture decision record or another guide
+func docKind(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+	if strings.HasPrefix(base, "readme") {
+		return DocKindReadme
+	}
+	if strings.HasPrefix(base, "adr-") || strings.HasPrefix(base, "adr_") {
+		return DocKindADR
+	}
+	for _, part := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+		if adrDirs[strings.ToLower(part)] {
+			return DocKindADR
+		}
+	}
+	return DocKindGuide
+}
+
+// parseMarkdown splits a document into sections at its headings and collects the code spans,
+// links and identifier-shaped words of each. Fenced code blocks are left out of both.
+func parseMarkdown(path, content string) []markdownSection {
+	title := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	kind := docKind(path)
+	sections := make([]markdownSection, 0)
+	current := &markdownSection{DocSection: DocSection{Document: path, Kind: kind, Line: 1}}
+	var excerpt strings.Builder
+	hasContent, titled := false, false
+
+	finish := func(endLine int) {
+		current.EndLine = endLine
+		current.Excerpt = truncateExcerpt(excerpt.String())
+		if current.Level > 0 || hasContent {
+			sections = append(sections, *current)
+		}
+		excerpt.Reset()
+		hasContent = false
+	}
+
+	lines := strings.Split(content, "\n")
+	inFence := ""
+	for i, line := range lines {
+		lineNumber := i + 1
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			if inFence == "" {
+				inFence = match[1]
+			} else if match[1] == inFence {
+				inFence = ""
+			}
+			continue
+		}
+		if inFence != "" {
+			continue
+		}
+
+		if match := headingPattern.FindStringSubmatch(line); match != nil {
+			finish(lineNumber - 1)
+			heading := strings.TrimSpace(match[2])
+			current = &markdownSection{DocSection: DocSection{Document: path, Kind: kind, Heading: strings.ReplaceAll(heading, "`", ""), Level: len(match[1]), Line: lineNumber}}
+			if current.Level == 1 && !titled {
+				title, titled = current.Heading, true
+			}
+			current.mentions = append(current.mentions, lineMentions(heading, lineNumber)...)
+			continue
+		}
+
+		if strings.TrimSpace(line) != "" {
+			hasContent = true
+			if excerpt.Len() < maxDocExcerpt {
+				if excerpt.Len() > 0 {
+					excerpt.WriteString(" ")
+				}
+				excerpt.WriteString(strings.TrimSpace(line))
+			}
+		}
+		current.mentions = append(current.mentions, lineMentions(line, lineNumber)...)
+	}
+	finish(len(lines))
+
+	for i := range sections {
+		sections[i].Title = title
+		if sections[i].Level == 0 {
+			sections[i].Heading = title
+		}
+	}
+	return sections
+}
+
+// lineMentions returns the code spans, link targets and identifier-shaped words of a line
+func lineMentions(line string, lineNumber int) []docMention {
+	mentions := make([]docMention, 0)
+	for _, match := range codeSpanPattern.FindAllStringSubmatch(line, -1) {
+		span := strings.TrimSpace(match[1])
+		switch {
+		case identifierPattern.MatchString(span):
+			mentions = append(mentions, docMention{text: span, line: lineNumber})
+		case strings.Contains(span, "/") || filepath.Ext(span) != "":
+			if !strings.ContainsAny(span, " \t") {
+				mentions = append(mentions, docMention{text: span, line: lineNumber, path: true})
+			}
+		}
+	}
+	prose := codeSpanPattern.ReplaceAllString(line, " ")
+	for _, match := range linkPattern.FindAllStringSubmatch(prose, -1) {
+		target := strings.SplitN(match[1], "#", 2)[0]
+		if target != "" && !strings.Contains(target, "://") && !strings.HasPrefix(target, "mailto:") {
+			mentions = append(mentions, docMention{text: target, line: lineNumber, path: true})
+		}
+	}
+	prose = linkPattern.ReplaceAllString(prose, "]")
+	for _, word := range proseIdentifierPattern.FindAllString(prose, -1) {
+		mentions = append(mentions, docMention{text: word, line: lineNumber})
+	}
+	return mentions
+}
+
+// truncateExcerpt shortens prose to about maxDocExcerpt characters, cutting at a word boundary
+func truncateExcerpt(text string) string {
+	if len(text) <= maxDocExcerpt {
+		return text
+	}
+	cut := strings.LastIndex(text[:maxDocExcerpt], " ")
+	if cut <= 0 {
+		cut = maxDocExcerpt
+	}
+	return text[:cut] + "…"
+}
+
+// isGeneratedContextMap reports whether a document is a context map written by this tool, which
+// mentions every symbol and would link to all of them
+func isGeneratedContextMap(content string) bool {
+	return strings.HasPrefix(strings.TrimSpace(content), "# CodeContext Map")
+}
+
+// docSectionNodeId returns the graph node id of a document section
+func docSectionNodeId(path string, line int) types.NodeId {
+	return types.NodeId(fmt.Sprintf("doc-%s:%d", path, line))
+}
+
+// linkDocuments adds a node for every section of the markdown documents and documents edges from
+// each section to the symbols and files it mentions
+func (ra *RelationshipAnalyzer) linkDocuments(paths []string) {
+	ra.indexSymbols()
+	files := make([]string, 0, len(ra.graph.Files))
+	for filePath := range ra.graph.Files {
+		files = append(files, filePath)
+	}
+	sort.Strings(files)
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || info.Size() > maxDocSize {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil || isGeneratedContextMap(string(content)) {
+			continue
+		}
+
+		for _, section := range parseMarkdown(path, string(content)) {
+			nodeId := docSectionNodeId(path, section.Line)
+			ra.graph.Nodes[nodeId] = &types.GraphNode{
+				Id:       nodeId,
+				Type:     "doc_section",
+				Label:    section.Heading,
+				FilePath: path,
+				Metadata: map[string]interface{}{
+					"title":    section.Title,
+					"kind":     section.Kind,
+					"level":    section.Level,
+					"line":     section.Line,
+					"end_line": section.EndLine,
+					"excerpt":  section.Excerpt,
+				},
+			}
+
+			for _, mention := range section.mentions {
+				if mention.path {
+					for _, target := range resolveDocPath(files, path, mention.text) {
+						ra.addDocumentsEdge(nodeId, path, types.NodeId(fmt.Sprintf("file-%s", target)), target, mention)
+					}
+					continue
+				}
+				for _, symbol := range ra.resolveDocIdentifier(mention.text) {
+					ra.addDocumentsEdge(nodeId, path, types.NodeId(fmt.Sprintf("symbol-%s", symbol.Id)), ra.symbolFile(symbol.Id), mention)
+				}
+			}
+		}
+	}
+}
+
+// addDocumentsEdge records a mention on the edge from a document section to a symbol or file,
+// creating it on first use
+func (ra *RelationshipAnalyzer) addDocumentsEdge(from types.NodeId, document string, to types.NodeId, targetFile string, mention docMention) {
+	edgeId := types.EdgeId(fmt.Sprintf("documents-%s-%s", from, to))
+	if edge, exists := ra.graph.Edges[edgeId]; exists {
+		edge.Metadata["lines"] = append(edge.Metadata["lines"].([]int), mention.line)
+		edge.Weight++
+		return
+	}
+	ra.graph.Edges[edgeId] = &types.GraphEdge{
+		Id:     edgeId,
+		From:   from,
+		To:     to,
+		Type:   string(RelationshipDocuments),
+		Weight: 1.0,
+		Metadata: map[string]interface{}{
+			"mention":     mention.text,
+			"source_file": document,
+			"target_file": targetFile,
+			"lines":       []int{mention.line},
+		},
+	}
+}
+
+// resolveDocPath returns the analyzed files a link or path in a document names: the file relative
+// to the document, or else the files whose path ends with it
+func resolveDocPath(files []string, document, mention string) []string {
+	if filepath.IsAbs(mention) {
+		return nil
+	}
+	relative := filepath.Join(filepath.Dir(document), filepath.FromSlash(mention))
+	if i := sort.SearchStrings(files, relative); i < len(files) && files[i] == relative {
+		return []string{relative}
+	}
+
+	suffix := "/" + strings.TrimPrefix(filepath.ToSlash(mention), "./")
+	matches := make([]string, 0)
+	for _, filePath := range files {
+		slashed := filepath.ToSlash(filePath)
+		if slashed == suffix[1:] || strings.HasSuffix(slashed, suffix) {
+			matches = append(matches, filePath)
+		}
+	}
+	if len(matches) > maxDocTargets {
+		return nil
+	}
+	return matches
+}
+
+// resolveDocIdentifier returns the symbols an identifier in a document names. A qualifier, as in
+// pkg.Name or Type.method, must match the package directory, module file or type of the symbol.
+func (ra *RelationshipAnalyzer) resolveDocIdentifier(mention string) []*types.Symbol {
+	parts := strings.FieldsFunc(strings.TrimSuffix(mention, "()"), func(r rune) bool { return r == '.' || r == ':' })
+	if len(parts) == 0 || len(parts[len(parts)-1]) < minMentionName {
+		return nil
+	}
+	name := parts[len(parts)-1]
+	qualifier := ""
+	if len(parts) > 1 && !selfReceivers[parts[len(parts)-2]] {
+		qualifier = parts[len(parts)-2]
+	}
+
+	candidates := make([]*types.Symbol, 0)
+	for _, symbol := range ra.symbolsByName[name] {
+		if qualifier == "" || ra.qualifiesSymbol(symbol, qualifier) {
+			candidates = append(candidates, symbol)
+		}
+	}
+	candidates = withoutExportDuplicates(candidates)
+	if len(candidates) > maxDocTargets {
+		return nil
+	}
+	return candidates
+}
+
+// qualifiesSymbol reports whether a qualifier written before a symbol name names the package
+// directory, module file, enclosing type or method receiver of the symbol
+func (ra *RelationshipAnalyzer) qualifiesSymbol(symbol *types.Symbol, qualifier string) bool {
+	filePath := ra.symbolFile(symbol.Id)
+	if filepath.Base(filepath.Dir(filePath)) == qualifier || strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath)) == qualifier {
+		return true
+	}
+	fileNode := ra.graph.Files[filePath]
+	if fileNode == nil {
+		return false
+	}
+	if container := ra.containingType(fileNode, symbol); container != nil && container.Name == qualifier {
+		return true
+	}
+	for _, method := range fileNode.Methods {
+		if method.Symbol == symbol.Id && method.Receiver == qualifier {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutExportDuplicates drops the namespace symbols extracted for export statements when the
+// exported declaration itself is among the symbols
+func withoutExportDuplicates(symbols []*types.Symbol) []*types.Symbol {
+	declared := make(map[int]bool)
+	for _, symbol := range symbols {
+		if symbol.Type != types.SymbolTypeNamespace {
+			declared[symbol.Location.StartLine] = true
+		}
+	}
+	result := symbols[:0]
+	for _, symbol := range symbols {
+		if symbol.Type != types.SymbolTypeNamespace || !declared[symbol.Location.StartLine] {
+			result = append(result, symbol)
+		}
+	}
+	return result
+}
+
+// DocumentationFor lists the documentation sections mentioning a file or its symbols, the sections
+// mentioning the most of them first
+func DocumentationFor(graph *types.CodeGraph, filePath string) []DocReference {
+	targets := map[types.NodeId]string{types.NodeId(fmt.Sprintf("file-%s", filePath)): filepath.Base(filePath)}
+	if fileNode := graph.Files[filePath]; fileNode != nil {
+		for _, symbolId := range fileNode.Symbols {
+			if symbol := graph.Symbols[symbolId]; symbol != nil {
+				targets[types.NodeId(fmt.Sprintf("symbol-%s", symbolId))] = symbol.Name
+			}
+		}
+	}
+
+	bySection := make(map[types.NodeId]*DocReference)
+	for _, edge := range graph.Edges {
+		name, ok := targets[edge.To]
+		if !ok || edge.Type != string(RelationshipDocuments) {
+			continue
+		}
+		node := graph.Nodes[edge.From]
+		if node == nil {
+			continue
+		}
+		reference := bySection[edge.From]
+		if reference == nil {
+			reference = &DocReference{Section: docSectionFromNode(node), Mentions: make([]string, 0)}
+			bySection[edge.From] = reference
+		}
+		reference.Mentions = append(reference.Mentions, name)
+	}
+
+	references := make([]DocReference, 0, len(bySection))
+	for _, reference := range bySection {
+		sort.Strings(reference.Mentions)
+		references = append(references, *reference)
+	}
+	sort.Slice(references, func(i, j int) bool {
+		a, b := references[i], references[j]
+		if len(a.Mentions) != len(b.Mentions) {
+			return len(a.Mentions) > len(b.Mentions)
+		}
+		if a.Section.Document != b.Section.Document {
+			return a.Section.Document < b.Section.Document
+		}
+		return a.Section.Line < b.Section.Line
+	})
+	return references
+}
+
+// docSectionFromNode reads a document section back from its graph node
+func docSectionFromNode(node *types.GraphNode) DocSection {
+	section := DocSection{Document: node.FilePath, Heading: node.Label}
+	section.Title, _ = node.Metadata["title"].(string)
+	section.Kind, _ = node.Metadata["kind"].(string)
+	section.Level, _ = node.Metadata["level"].(int)
+	section.Line, _ = node.Metadata["line"].(int)
+	section.EndLine, _ = node.Metadata["end_line"].(int)
+	section.Excerpt, _ = node.Metadata["excerpt"].(string)
+	return section
+}
+
+// WriteDocReferences writes documentation sections as markdown with their excerpts. A positive limit
+// caps the number of sections listed.
+func WriteDocReferences(sb *strings.Builder, references []DocReference, limit int) {
+	if len(references) == 0 {
+		sb.WriteString("*No documentation mentions this file.*\n")
+		return
+	}
+
+	for i, reference := range references {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("*... and %d more sections*\n", len(references)-limit))
+			break
+		}
+		section := reference.Section
+		heading := section.Heading
+		if section.Level > 0 && section.Heading != section.Title {
+			heading = section.Title + " › " + section.Heading
+		}
+		sb.WriteString(fmt.Sprintf("### %s\n\n", heading))
+		sb.WriteString(fmt.Sprintf("`%s:%d` (%s), mentions `%s`\n\n", section.Document, section.Line, section.Kind, strings.Join(reference.Mentions, "`, `")))
+		if section.Excerpt != "" {
+			sb.WriteString("> " + section.Excerpt + "\n\n")
+		}
+	}
+}
+
+// DocumentSummary describes a markdown document and how much of the code it documents
+type DocumentSummary struct {
+	Document string `json:"document"`
+	Title    string `json:"title"`
+	Kind     string `json:"kind"`
+	Sections int    `json:"sections"`
+	Symbols  int    `json:"symbols"` // Distinct symbols mentioned
+	Files    int    `json:"files"`   // Distinct files mentioned, directly or through their symbols
+}
+
+// Documents summarises the markdown documents of the graph, those documenting the most symbols first
+func Documents(graph *types.CodeGraph) []DocumentSummary {
+	byDocument := make(map[string]*DocumentSummary)
+	for _, node := range graph.Nodes {
+		if node.Type != "doc_section" {
+			continue
+		}
+		summary := byDocument[node.FilePath]
+		if summary == nil {
+			section := docSectionFromNode(node)
+			summary = &DocumentSummary{Document: node.FilePath, Title: section.Title, Kind: section.Kind}
+			byDocument[node.FilePath] = summary
+		}
+		summary.Sections++
+	}
+
+	symbols := make(map[string]map[types.NodeId]bool)
+	files := make(map[string]map[string]bool)
+	for _, edge := range graph.Edges {
+		if edge.Type != string(RelationshipDocuments) {
+			continue
+		}
+		document, _ := edge.Metadata["source_file"].(string)
+		target, _ := edge.Metadata["target_file"].(string)
+		if symbols[document] == nil {
+			symbols[document] = make(map[types.NodeId]bool)
+			files[document] = make(map[string]bool)
+		}
+		if strings.HasPrefix(string(edge.To), "symbol-") {
+			symbols[document][edge.To] = true
+		}
+		files[document][target] = true
+	}
+
+	summaries := make([]DocumentSummary, 0, len(byDocument))
+	for document, summary := range byDocument {
+		summary.Symbols = len(symbols[document])
+		summary.Files = len(files[document])
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Symbols != b.Symbols {
+			return a.Symbols > b.Symbols
+		}
+		return a.Document < b.Document
+	})
+	return summaries
+}
+
+// WriteDocumentSummaries writes the documents as a markdown table. A positive limit caps the number
+// of documents listed.
+func WriteDocumentSummaries(sb *strings.Builder, summaries []DocumentSummary, limit int) {
+	if len(summaries) == 0 {
+		sb.WriteString("*No markdown documentation found.*\n")
+		return
+	}
+
+	sb.WriteString("| Document | Title | Kind | Symbols | Files |\n")
+	sb.WriteString("|----------|-------|------|---------|-------|\n")
+	for i, summary := range summaries {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("\n*... and %d more documents*\n", len(summaries)-limit))
+			break
+		}
+		title := strings.ReplaceAll(summary.Title, "|", "\\|")
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %d | %d |\n", summary.Document, title, summary.Kind, summary.Symbols, summary.Files))
+	}
+}
@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	content := "Intro mentioning `Store`.\n\n# Storage Design\n\nThe `store.Get()` call and [the cache](../cache/cache.go) use loadIndex and max_size.\n\n```go\nstore.Put(ignored)\n```\n\n## Why `Store` ## \n\nPlain English words are not identifiers.\n"
+	sections := parseMarkdown("docs/adr/0001-storage.md", content)
+	if len(sections) != 3 {
+		t.Fatalf("expected 3 sections, got %+v", sections)
+	}
+
+	headings := make([]string, 0, len(sections))
+	for _, section := range sections {
+		headings = append(headings, section.Heading)
+		if section.Title != "Storage Design" || section.Kind != DocKindADR {
+			t.Errorf("section %q: title %q kind %q", section.Heading, section.Title, section.Kind)
+		}
+	}
+	if !reflect.DeepEqual(headings, []string{"Storage Design", "Storage Design", "Why Store"}) {
+		t.Errorf("headings = %v", headings)
+	}
+	if sections[1].Line != 3 || sections[1].EndLine != 10 || sections[2].Level != 2 {
+		t.Errorf("unexpected section bounds: %+v", sections)
+	}
+
+	mentions := make([]string, 0)
+	for _, mention := range sections[1].mentions {
+		if mention.path {
+			mentions = append(mentions, "path:"+mention.text)
+		} else {
+			mentions = append(mentions, mention.text)
+		}
+	}
+	// Fenced code and plain words are left out
+	if !reflect.DeepEqual(mentions, []string{"store.Get()", "path:../cache/cache.go", "loadIndex", "max_size"}) {
+		t.Errorf("mentions = %v", mentions)
+	}
+	if !strings.HasPrefix(sections[1].Excerpt, "The `store.Get()` call") {
+		t.Errorf("excerpt = %q", sections[1].Excerpt)
+	}
+
+	for path, expected := range map[string]string{"README.md": DocKindReadme, "docs/ADR-002.md": DocKindADR, "docs/guide.md": DocKindGuide} {
+		if kind := docKind(path); kind != expected {
+			t.Errorf("docKind(%s) = %s, expected %s", path, kind, expected)
+		}
+	}
+}
+
+func TestLinkDocuments(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeTestFile(t, dir, "store/store.go", "package store\n\ntype Store struct{}\n\nfunc (s *Store) Get(key string) string { return key }\n\nfunc loadIndex() {}\n")
+	writeTestFile(t, dir, "cache/cache.go", "package cache\n\nfunc Get(key string) string { return key }\n")
+	writeTestFile(t, dir, "docs/adr/0001-storage.md", "# Storage\n\n## Context\n\nReads go through `store.Get()` after loadIndex runs.\n\n## Caching\n\nSee [the cache](../../cache/cache.go).\n")
+	writeTestFile(t, dir, "README.md", "# App\n\nNothing about the code.\n")
+	// Context maps written by the generate command mention everything and are not documentation
+	writeTestFile(t, dir, "CLAUDE.md", "# CodeContext Map\n\n`Store` `loadIndex`\n")
+
+	graph, err := NewGraphBuilder().AnalyzeDirectory(dir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectory() error = %v", err)
+	}
+	if _, exists := graph.Files[filepath.Join(dir, "README.md")]; exists {
+		t.Error("documents should not be analyzed as source files")
+	}
+
+	references := DocumentationFor(graph, filepath.Join(dir, "store", "store.go"))
+	if len(references) != 1 {
+		t.Fatalf("expected one section documenting store.go, got %+v", references)
+	}
+	if section := references[0].Section; section.Heading != "Context" || section.Title != "Storage" || section.Kind != DocKindADR || section.Line != 3 {
+		t.Errorf("unexpected section %+v", section)
+	}
+	if !reflect.DeepEqual(references[0].Mentions, []string{"Get", "loadIndex"}) {
+		t.Errorf("mentions = %v, expected the qualified Get and loadIndex", references[0].Mentions)
+	}
+
+	references = DocumentationFor(graph, filepath.Join(dir, "cache", "cache.go"))
+	if len(references) != 1 || references[0].Section.Heading != "Caching" || !reflect.DeepEqual(references[0].Mentions, []string{"cache.go"}) {
+		t.Errorf("expected the linking section for cache.go, got %+v", references)
+	}
+
+	summaries := Documents(graph)
+	documents := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		documents = append(documents, filepath.Base(summary.Document))
+	}
+	if !reflect.DeepEqual(documents, []string{"0001-storage.md", "README.md"}) {
+		t.Errorf("Documents() = %v", documents)
+	}
+	if summaries[0].Symbols != 2 || summaries[0].Files != 2 || summaries[0].Sections != 3 {
+		t.Errorf("unexpected summary %+v", summaries[0])
+	}
+
+	var sb strings.Builder
+	WriteDocReferences(&sb, DocumentationFor(graph, filepath.Join(dir, "store", "store.go")), 0)
+	for _, expected := range []string{"### Storage › Context", "(adr), mentions `Get`, `loadIndex`", "> Reads go through"} {
+		if !strings.Contains(sb.String(), expected) {
+			t.Errorf("references missing %q:\n%s", expected, sb.String())
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package analyzer
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Entry point kinds, in the order the context map lists them
+const (
+	EntryPointMain    = "main"    // Program entry: main functions and Python __main__ modules and guards
+	EntryPointCommand = "command" // CLI subcommands such as cobra commands
+	EntryPointScript  = "script"  // package.json bin and scripts, Python console scripts
+	EntryPointHTTP    = "http"    // HTTP handlers and routes
+	EntryPointLibrary = "library" // Public roots of libraries: package entry files, lib.rs, module root packages
+)
+
+// entryPointKinds lists the kinds in display order
+var entryPointKinds = []string{EntryPointMain, EntryPointCommand, EntryPointScript, EntryPointHTTP, EntryPointLibrary}
+
+// EntryPoint is a place where execution of the project, or use of it as a library, starts
+type EntryPoint struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+	Detail string `json:"detail,omitempty"` // Command run, handler framework or target the entry point names
+}
+
+var (
+	// cobraCommandPattern matches cobra command declarations such as var rootCmd = &cobra.Command{
+	cobraCommandPattern = regexp.MustCompile(`(\w+)\s*:?=\s*&cobra\.Command\s*\{`)
+	// cobraUsePattern matches the Use field naming a cobra command
+	cobraUsePattern = regexp.MustCompile(`\bUse:\s*"([^"]*)"`)
+	// pythonMainGuardPattern matches if __name__ == "__main__":
+	pythonMainGuardPattern = regexp.MustCompile(`(?m)^if\s+__name__\s*==\s*["']__main__["']\s*:`)
+)
+
+// httpHandlerParameters maps parameter types that mark a Go function as an HTTP handler to the framework
+var httpHandlerParameters = []struct {
+	Types     []string
+	Framework string
+}{
+	{[]string{"http.ResponseWriter", "*http.Request"}, "net/http"},
+	{[]string{"*gin.Context"}, "gin"},
+	{[]string{"echo.Context"}, "echo"},
+	{[]string{"*fiber.Ctx"}, "fiber"},
+}
+
+// httpHandlerFramework returns the framework of a Go function whose parameters make it an HTTP
+// handler, or "" for other symbols
+func httpHandlerFramework(symbol *types.Symbol, fileNode *types.FileNode) string {
+	if fileNode.Language != "go" || (symbol.Type != types.SymbolTypeFunction && symbol.Type != types.SymbolTypeMethod) {
+		return ""
+	}
+	parameters := symbol.Signature
+	if open := strings.Index(parameters, symbol.Name+"("); open >= 0 {
+		parameters = parameters[open+len(symbol.Name):]
+	}
+	for _, handler := range httpHandlerParameters {
+		matched := true
+		for _, parameterType := range handler.Types {
+			if !strings.Contains(parameters, " "+parameterType) {
+				matched = false
+			}
+		}
+		if matched {
+			return handler.Framework
+		}
+	}
+	return ""
+}
+
+// FindEntryPoints lists where execution starts: main functions and modules, CLI commands, scripts
+// declared in package.json and Python project metadata, HTTP handlers and routes, and the public
+// roots of libraries. Test and generated files are left out.
+func FindEntryPoints(graph *types.CodeGraph) []EntryPoint {
+	ra := NewRelationshipAnalyzer(graph)
+	entryPoints := make([]EntryPoint, 0)
+
+	filePaths := make([]string, 0, len(graph.Files))
+	for filePath, fileNode := range graph.Files {
+		if !fileNode.IsTest && !fileNode.IsGenerated {
+			filePaths = append(filePaths, filePath)
+		}
+	}
+	sort.Strings(filePaths)
+
+	nodePackages := make(map[string]*nodePackage)
+	pythonProjects := make(map[string]bool)
+	goModules := make(map[string]*goModule)
+	goMainDirs := make(map[string]bool)
+
+	// Registered routes name their handlers; routes with inline handlers are entry points themselves
+	routesByHandler := make(map[types.SymbolId][]Route)
+	for _, route := range FindRoutes(graph) {
+		if route.HandlerId != "" {
+			routesByHandler[route.HandlerId] = append(routesByHandler[route.HandlerId], route)
+		} else {
+			entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointHTTP, Name: route.Method + " " + route.Path, File: route.File, Line: route.Line, Detail: route.Framework + ", inline handler"})
+		}
+	}
+
+	for _, filePath := range filePaths {
+		fileNode := graph.Files[filePath]
+		for _, symbolId := range fileNode.Symbols {
+			symbol := graph.Symbols[symbolId]
+			if symbol == nil {
+				continue
+			}
+			switch {
+			case symbol.Name == "main" && (symbol.Type == types.SymbolTypeFunction || symbol.Type == types.SymbolTypeMethod):
+				entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointMain, Name: symbol.Name, File: filePath, Line: symbol.Location.StartLine})
+				if fileNode.Language == "go" {
+					goMainDirs[filepath.Dir(filePath)] = true
+				}
+			case len(routesByHandler[symbolId]) > 0:
+				routes := routesByHandler[symbolId]
+				registrations := make([]string, 0, len(routes))
+				for _, route := range routes {
+					registrations = append(registrations, route.Method+" "+route.Path)
+				}
+				entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointHTTP, Name: symbol.Name, File: filePath, Line: symbol.Location.StartLine, Detail: routes[0].Framework + ": " + strings.Join(registrations, ", ")})
+			default:
+				if framework := httpHandlerFramework(symbol, fileNode); framework != "" {
+					entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointHTTP, Name: symbol.Name, File: filePath, Line: symbol.Location.StartLine, Detail: framework})
+				}
+			}
+		}
+
+		dir := filepath.Dir(filePath)
+		switch fileNode.Language {
+		case "go":
+			if module := ra.goModules.moduleForDir(dir); module != nil {
+				goModules[module.Dir] = module
+			}
+			for _, imp := range fileNode.Imports {
+				if imp.Path == "github.com/spf13/cobra" {
+					entryPoints = append(entryPoints, cobraCommands(filePath)...)
+					break
+				}
+			}
+		case "python":
+			if filepath.Base(filePath) == "__main__.py" {
+				module := filepath.Base(dir)
+				entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointMain, Name: module, File: filePath, Line: 1, Detail: "python -m " + module})
+			} else if line := pythonMainGuardLine(filePath); line > 0 {
+				entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointMain, Name: strings.TrimSuffix(filepath.Base(filePath), ".py"), File: filePath, Line: line, Detail: "__main__ guard"})
+			}
+			if project := ra.pythonModules.projectDir(dir); project != "" {
+				pythonProjects[project] = true
+			}
+		case "javascript", "typescript":
+			if owner := ra.nodeModules.ownerFor(dir); owner != nil {
+				nodePackages[owner.Dir] = owner
+			}
+		case "rust":
+			if filepath.Base(filePath) == "lib.rs" && filepath.Base(dir) == "src" {
+				entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointLibrary, Name: filepath.Base(filepath.Dir(dir)), File: filePath, Line: 1, Detail: "crate root"})
+			}
+		}
+	}
+
+	for _, dir := range sortedKeys(nodePackages) {
+		entryPoints = append(entryPoints, ra.nodePackageEntryPoints(nodePackages[dir])...)
+	}
+	for _, project := range sortedKeys(pythonProjects) {
+		entryPoints = append(entryPoints, ra.pythonScriptEntryPoints(project)...)
+	}
+	for _, dir := range sortedKeys(goModules) {
+		// The package at the module root is the library's import path, unless it is a command
+		files := ra.filesInDir(dir, "go", func(path string) bool { return !graph.Files[path].IsTest })
+		if len(files) > 0 && !goMainDirs[dir] {
+			sort.Strings(files)
+			entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointLibrary, Name: goModules[dir].Path, File: files[0], Line: 1, Detail: "module root package"})
+		}
+	}
+
+	rank := make(map[string]int, len(entryPointKinds))
+	for i, kind := range entryPointKinds {
+		rank[kind] = i
+	}
+	sort.SliceStable(entryPoints, func(i, j int) bool {
+		if entryPoints[i].Kind != entryPoints[j].Kind {
+			return rank[entryPoints[i].Kind] < rank[entryPoints[j].Kind]
+		}
+		if entryPoints[i].File != entryPoints[j].File {
+			return entryPoints[i].File < entryPoints[j].File
+		}
+		return entryPoints[i].Line < entryPoints[j].Line
+	})
+	return entryPoints
+}
+
+// cobraCommands lists the cobra commands declared in a Go file, named by the first word of Use
+func cobraCommands(filePath string) []EntryPoint {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	content := string(data)
+
+	commands := make([]EntryPoint, 0)
+	matches := cobraCommandPattern.FindAllStringSubmatchIndex(content, -1)
+	for i, match := range matches {
+		// The Use field belongs to this declaration if it comes before the next one
+		end := len(content)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		variable := content[match[2]:match[3]]
+		name := variable
+		if use := cobraUsePattern.FindStringSubmatch(content[match[1]:end]); use != nil {
+			if fields := strings.Fields(use[1]); len(fields) > 0 {
+				name = fields[0]
+			}
+		}
+		commands = append(commands, EntryPoint{
+			Kind:   EntryPointCommand,
+			Name:   name,
+			File:   filePath,
+			Line:   lineOf(content, match[0]),
+			Detail: variable,
+		})
+	}
+	return commands
+}
+
+// pythonMainGuardLine returns the line of a module's if __name__ == "__main__" block, or 0
+func pythonMainGuardLine(filePath string) int {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0
+	}
+	location := pythonMainGuardPattern.FindIndex(data)
+	if location == nil {
+		return 0
+	}
+	return lineOf(string(data), location[0])
+}
+
+// nodePackageEntryPoints lists the bin commands and scripts of a package.json, and the package's
+// entry file when it is a library
+func (ra *RelationshipAnalyzer) nodePackageEntryPoints(pkg *nodePackage) []EntryPoint {
+	manifest := filepath.Join(pkg.Dir, "package.json")
+	entryPoints := make([]EntryPoint, 0)
+
+	// bin is either one path named after the package or a map of command names to paths
+	bins := make(map[string]string)
+	var single string
+	if json.Unmarshal(pkg.Bin, &single) == nil && single != "" {
+		bins[filepath.Base(pkg.Name)] = single
+	} else {
+		_ = json.Unmarshal(pkg.Bin, &bins)
+	}
+	for _, name := range sortedKeys(bins) {
+		file := ra.nodeModules.sourceFileFor(filepath.Join(pkg.Dir, filepath.FromSlash(bins[name])))
+		if file == "" {
+			file = manifest
+		}
+		entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointScript, Name: name, File: file, Detail: "bin: " + bins[name]})
+	}
+	for _, name := range sortedKeys(pkg.Scripts) {
+		entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointScript, Name: "npm run " + name, File: manifest, Detail: pkg.Scripts[name]})
+	}
+
+	if pkg.Name != "" && (pkg.Main != "" || pkg.Module != "" || pkg.Source != "" || len(pkg.Exports) > 0) {
+		if file := ra.nodeModules.resolveEntry(pkg, "."); file != "" {
+			entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointLibrary, Name: pkg.Name, File: file, Line: 1, Detail: "package entry"})
+		}
+	}
+	return entryPoints
+}
+
+// pythonScriptEntryPoints lists the console scripts a Python project declares, pointing at the
+// module that defines each script's function when it was analyzed
+func (ra *RelationshipAnalyzer) pythonScriptEntryPoints(project string) []EntryPoint {
+	entryPoints := make([]EntryPoint, 0)
+	for _, script := range parsePythonScripts(project) {
+		file, line := script.Manifest, script.Line
+		module, _, _ := strings.Cut(script.Target, ":")
+		if resolution := ra.pythonModules.Resolve(strings.TrimSpace(module), nil, script.Manifest); len(resolution.Files) > 0 {
+			file, line = resolution.Files[0], 0
+		}
+		entryPoints = append(entryPoints, EntryPoint{Kind: EntryPointScript, Name: script.Name, File: file, Line: line, Detail: script.Target})
+	}
+	return entryPoints
+}
+
+// pythonScript is a console script declared in Python project metadata
+type pythonScript struct {
+	Name     string
+	Target   string // module:function
+	Manifest string
+	Line     int
+}
+
+// parsePythonScripts reads [project.scripts] and [tool.poetry.scripts] from pyproject.toml and the
+// console_scripts entry points from setup.cfg
+func parsePythonScripts(project string) []pythonScript {
+	scripts := make([]pythonScript, 0)
+	for _, manifest := range []string{"pyproject.toml", "setup.cfg"} {
+		path := filepath.Join(project, manifest)
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+
+		section, inConsoleScripts := "", false
+		scanner := bufio.NewScanner(file)
+		for lineNumber := 1; scanner.Scan(); lineNumber++ {
+			raw := scanner.Text()
+			line := strings.TrimSpace(stripTomlComment(raw))
+			if strings.HasPrefix(line, "[") {
+				section, inConsoleScripts = strings.Trim(line, "[] "), false
+				continue
+			}
+
+			var isScript bool
+			if manifest == "pyproject.toml" {
+				isScript = section == "project.scripts" || section == "tool.poetry.scripts"
+			} else if section == "options.entry_points" {
+				// console_scripts =, then indented name = module:function lines
+				if key, _, ok := strings.Cut(line, "="); ok && !strings.HasPrefix(raw, " ") && !strings.HasPrefix(raw, "\t") {
+					inConsoleScripts = strings.TrimSpace(key) == "console_scripts"
+					continue
+				}
+				isScript = inConsoleScripts
+			}
+			name, target, ok := strings.Cut(line, "=")
+			if !isScript || !ok {
+				continue
+			}
+			target = strings.Trim(strings.TrimSpace(target), `"'`)
+			if strings.HasPrefix(target, "{") {
+				// Poetry's { reference = "...", type = "file" } form names no function
+				continue
+			}
+			scripts = append(scripts, pythonScript{
+				Name:     strings.Trim(strings.TrimSpace(name), `"'`),
+				Target:   target,
+				Manifest: path,
+				Line:     lineNumber,
+			})
+		}
+		file.Close()
+	}
+	return scripts
+}
+
+// sortedKeys returns the keys of a string-keyed map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestFindEntryPoints(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module example.com/tool\n\ngo 1.22\n")
+	command := writeTestFile(t, dir, "cmd/tool/main.go", `package main
+
+import "github.com/spf13/cobra"
+
+var rootCmd = &cobra.Command{
+	Use:   "tool",
+	Short: "Runs the tool",
+}
+
+var serveCmd = &cobra.Command{
+	Use: "serve [addr]",
+}
+
+func main() { rootCmd.Execute() }
+`)
+	library := writeTestFile(t, dir, "tool.go", "package tool\n")
+	server := filepath.Join(dir, "server", "routes.go")
+
+	web := filepath.Join(dir, "web")
+	writeTestFile(t, web, "package.json", `{"name": "@acme/web", "main": "src/index.js", "bin": {"web-cli": "./bin/cli.js"}, "scripts": {"build": "vite build"}}`)
+	cli := writeTestFile(t, web, "bin/cli.js", "")
+	index := writeTestFile(t, web, "src/index.js", "")
+
+	py := filepath.Join(dir, "py")
+	writeTestFile(t, py, "pyproject.toml", "[project]\nname = \"app\"\n\n[project.scripts]\napp = \"app.cli:main\"  # console entry\n")
+	pyCLI := writeTestFile(t, py, "app/cli.py", "def main():\n    pass\n\nif __name__ == \"__main__\":\n    main()\n")
+	pyMain := writeTestFile(t, py, "app/__main__.py", "from app.cli import main\nmain()\n")
+	writeTestFile(t, py, "app/__init__.py", "")
+
+	graph := newEmptyTestGraph()
+	addTestFile(graph, command, "go", "github.com/spf13/cobra")
+	addTestSymbol(graph, command, "main", types.SymbolTypeFunction, 14)
+	addTestFile(graph, library, "go")
+	addTestFile(graph, server, "go", "net/http", "github.com/gin-gonic/gin")
+	addTestSymbol(graph, server, "health", types.SymbolTypeFunction, 5).Signature = "func health(w http.ResponseWriter, r *http.Request)"
+	addTestSymbol(graph, server, "list", types.SymbolTypeMethod, 9).Signature = "func (s *Server) list(c *gin.Context)"
+	addTestSymbol(graph, server, "render", types.SymbolTypeFunction, 13).Signature = "func render(w http.ResponseWriter, data any)"
+	addTestFile(graph, cli, "javascript")
+	addTestFile(graph, index, "javascript")
+	addTestFile(graph, filepath.Join(py, "app", "__init__.py"), "python")
+	addTestFile(graph, pyCLI, "python")
+	addTestFile(graph, pyMain, "python")
+	addTestFile(graph, filepath.Join(dir, "tool_test.go"), "go").IsTest = true
+	addTestSymbol(graph, filepath.Join(dir, "tool_test.go"), "main", types.SymbolTypeFunction, 3)
+
+	entryPoints := FindEntryPoints(graph)
+	found := make([]string, 0, len(entryPoints))
+	for _, entryPoint := range entryPoints {
+		relative, _ := filepath.Rel(dir, entryPoint.File)
+		found = append(found, strings.Join([]string{entryPoint.Kind, entryPoint.Name, filepath.ToSlash(relative), entryPoint.Detail}, " "))
+	}
+	expected := []string{
+		"main main cmd/tool/main.go ",
+		"main app py/app/__main__.py python -m app",
+		"main cli py/app/cli.py __main__ guard",
+		"command tool cmd/tool/main.go rootCmd",
+		"command serve cmd/tool/main.go serveCmd",
+		"script app py/app/cli.py app.cli:main",
+		"script web-cli web/bin/cli.js bin: ./bin/cli.js",
+		"script npm run build web/package.json vite build",
+		"http health server/routes.go net/http",
+		"http list server/routes.go gin",
+		"library example.com/tool tool.go module root package",
+		"library @acme/web web/src/index.js package entry",
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("FindEntryPoints() =\n%s\nexpected\n%s", strings.Join(found, "\n"), strings.Join(expected, "\n"))
+	}
+
+	content := NewMarkdownGenerator(graph).GenerateContextMap()
+	for _, expected := range []string{
+		"## 🚪 Entry Points",
+		"### Commands (2)",
+		"| `serve` | `" + command + ":10` | serveCmd |",
+		"### HTTP Handlers (2)",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("entry points section missing %q:\n%s", expected, content)
+		}
+	}
+	if strings.Index(content, "## 🚪 Entry Points") > strings.Index(content, "## 📁 File Analysis") {
+		t.Error("expected the entry points section before file analysis")
+	}
+}
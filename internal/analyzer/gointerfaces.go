@@ -0,0 +1,266 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/parser"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// TypeResolutionStructural marks implements edges derived from Go method sets rather than a declared clause
+const TypeResolutionStructural = "structural"
+
+// goWellKnownInterfaces are standard library interfaces commonly embedded in local types
+var goWellKnownInterfaces = map[string]map[string]string{
+	"error":        {"Error": "()(string)"},
+	"fmt.Stringer": {"String": "()(string)"},
+	"io.Reader":    {"Read": "([]byte)(int,error)"},
+	"io.Writer":    {"Write": "([]byte)(int,error)"},
+	"io.Closer":    {"Close": "()(error)"},
+	"io.ReadCloser": {
+		"Read":  "([]byte)(int,error)",
+		"Close": "()(error)",
+	},
+	"io.WriteCloser": {
+		"Write": "([]byte)(int,error)",
+		"Close": "()(error)",
+	},
+	"io.ReadWriter": {
+		"Read":  "([]byte)(int,error)",
+		"Write": "([]byte)(int,error)",
+	},
+}
+
+// goTypeName matches a possibly package-qualified identifier in a method signature, e.g. io.Reader
+var goTypeName = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)?`)
+
+// goPredeclared are the identifiers of signatures that name no package type: predeclared types
+// and the keywords of composite types
+var goPredeclared = map[string]bool{
+	"bool": true, "byte": true, "complex64": true, "complex128": true, "error": true, "float32": true,
+	"float64": true, "int": true, "int8": true, "int16": true, "int32": true, "int64": true, "rune": true,
+	"string": true, "uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"uintptr": true, "any": true, "comparable": true,
+	"func": true, "map": true, "chan": true, "struct": true, "interface": true,
+}
+
+// goNamedType is a Go type definition together with where it was declared
+type goNamedType struct {
+	definition *types.TypeDefinition
+	file       string
+	module     *goModule
+}
+
+// goMethodSetAnalyzer computes method sets of Go named types and matches them against interfaces
+type goMethodSetAnalyzer struct {
+	ra       *RelationshipAnalyzer
+	named    map[types.SymbolId]*goNamedType
+	declared map[string][]*types.MethodSpec // package dir + receiver name -> declared methods
+	cache    map[string]map[string]string   // symbol id + receiver kind -> method name -> signature
+	complete map[string]bool                // Whether every embedded type of a cached set was resolved
+	visiting map[string]bool
+}
+
+// analyzeGoInterfaceSatisfaction emits implements edges for Go types whose method sets contain
+// every method of an interface declared in the same module
+func (ra *RelationshipAnalyzer) analyzeGoInterfaceSatisfaction(metrics *RelationshipMetrics) {
+	ma := &goMethodSetAnalyzer{
+		ra:       ra,
+		named:    make(map[types.SymbolId]*goNamedType),
+		declared: make(map[string][]*types.MethodSpec),
+		cache:    make(map[string]map[string]string),
+		complete: make(map[string]bool),
+		visiting: make(map[string]bool),
+	}
+
+	var interfaces, concrete []*goNamedType
+	for filePath, fileNode := range ra.graph.Files {
+		if fileNode.Language != "go" {
+			continue
+		}
+		dir := filepath.Dir(filePath)
+		module := ra.goModules.moduleForDir(dir)
+		for _, definition := range fileNode.Types {
+			named := &goNamedType{definition: definition, file: filePath, module: module}
+			ma.named[definition.Symbol] = named
+			if definition.Kind == parser.TypeKindInterface {
+				interfaces = append(interfaces, named)
+			} else {
+				concrete = append(concrete, named)
+			}
+		}
+		for _, method := range fileNode.Methods {
+			key := dir + "\x00" + method.Receiver
+			qualified := *method
+			qualified.Signature = ra.qualifyGoSignature(method.Signature, filePath)
+			ma.declared[key] = append(ma.declared[key], &qualified)
+		}
+	}
+
+	// Stable order keeps edge metadata deterministic
+	bySymbol := func(named []*goNamedType) {
+		sort.Slice(named, func(i, j int) bool { return named[i].definition.Symbol < named[j].definition.Symbol })
+	}
+	bySymbol(interfaces)
+	bySymbol(concrete)
+
+	count, crossFileCount := 0, 0
+	for _, iface := range interfaces {
+		required, complete := ma.methodSet(iface, false)
+		// Empty interfaces are satisfied by everything and constraints by no method set
+		if !complete || len(required) == 0 {
+			continue
+		}
+
+		for _, named := range concrete {
+			if named.module != iface.module {
+				continue
+			}
+			pointer := false
+			if valueSet, _ := ma.methodSet(named, false); !containsMethods(valueSet, required) {
+				if pointerSet, _ := ma.methodSet(named, true); !containsMethods(pointerSet, required) {
+					continue
+				}
+				pointer = true
+			}
+
+			subject := ra.graph.Symbols[named.definition.Symbol]
+			target := ra.graph.Symbols[iface.definition.Symbol]
+			if subject == nil || target == nil {
+				continue
+			}
+			clause := &types.Inheritance{
+				Symbol:   subject.Id,
+				Type:     subject.Name,
+				Kind:     string(RelationshipImplements),
+				Target:   target.Name,
+				Location: named.definition.Location,
+			}
+			if ra.addInheritanceEdge(named.file, clause, subject, target, TypeResolutionStructural) {
+				ra.graph.Edges[inheritanceEdgeId(clause.Kind, subject, target)].Metadata["pointer_receiver"] = pointer
+				count++
+				if named.file != iface.file {
+					crossFileCount++
+				}
+			}
+		}
+	}
+
+	metrics.ByType[RelationshipImplements] += count
+	metrics.SymbolToSymbol += count
+	metrics.CrossFileRefs += crossFileCount
+}
+
+// methodSet returns the methods of a type, or of its pointer type, including methods promoted from
+// embedded fields and interfaces. The flag reports whether every embedded type could be resolved.
+func (ma *goMethodSetAnalyzer) methodSet(named *goNamedType, pointer bool) (map[string]string, bool) {
+	key := string(named.definition.Symbol)
+	if pointer {
+		key += "*"
+	}
+	if methods, cached := ma.cache[key]; cached {
+		return methods, ma.complete[key]
+	}
+	if ma.visiting[key] {
+		// Embedding cycles are invalid Go; treat the repeated type as contributing nothing
+		return nil, false
+	}
+	ma.visiting[key] = true
+	defer delete(ma.visiting, key)
+
+	methods := make(map[string]string)
+	complete := true
+
+	if named.definition.Kind == parser.TypeKindInterface {
+		for _, method := range named.definition.Methods {
+			methods[method.Name] = ma.ra.qualifyGoSignature(method.Signature, named.file)
+		}
+	} else {
+		// The method set of T holds value receivers; the method set of *T adds pointer receivers
+		for _, method := range ma.declared[filepath.Dir(named.file)+"\x00"+named.definition.Name] {
+			if !method.Pointer || pointer {
+				methods[method.Name] = method.Signature
+			}
+		}
+	}
+
+	// Promoted methods never replace methods declared at a shallower depth
+	for _, embedded := range named.definition.Embedded {
+		embeddedPointer := strings.HasPrefix(embedded, "*")
+		promoted, resolved := ma.embeddedMethods(strings.TrimPrefix(embedded, "*"), named.file, pointer || embeddedPointer)
+		complete = complete && resolved
+		for name, signature := range promoted {
+			if _, exists := methods[name]; !exists {
+				methods[name] = signature
+			}
+		}
+	}
+
+	ma.cache[key] = methods
+	ma.complete[key] = complete
+	return methods, complete
+}
+
+// embeddedMethods resolves an embedded type name used in filePath and returns its method set
+func (ma *goMethodSetAnalyzer) embeddedMethods(name, filePath string, pointer bool) (map[string]string, bool) {
+	if methods, wellKnown := goWellKnownInterfaces[name]; wellKnown {
+		return methods, true
+	}
+
+	// Drop type arguments of embedded generic types
+	if index := strings.Index(name, "["); index >= 0 {
+		name = name[:index]
+	}
+	qualifier, typeName := "", name
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		qualifier, typeName = name[:index], name[index+1:]
+	}
+
+	symbol, _ := ma.ra.resolveTypeReference(typeName, qualifier, filePath)
+	if symbol == nil || ma.named[symbol.Id] == nil {
+		return nil, false
+	}
+	return ma.methodSet(ma.named[symbol.Id], pointer)
+}
+
+// qualifyGoSignature prefixes the types named in a method signature of filePath with the package
+// declaring them: the file's own directory for unqualified names, and the package an import resolves
+// to for qualified ones. A type then reads the same inside and outside its package, while io.Reader
+// stays apart from a local Reader.
+func (ra *RelationshipAnalyzer) qualifyGoSignature(signature, filePath string) string {
+	fileNode := ra.graph.Files[filePath]
+	return goTypeName.ReplaceAllStringFunc(signature, func(name string) string {
+		qualifier, typeName, qualified := strings.Cut(name, ".")
+		if !qualified {
+			if goPredeclared[name] {
+				return name
+			}
+			return filepath.Dir(filePath) + "." + name
+		}
+		if fileNode != nil {
+			for _, imp := range fileNode.Imports {
+				if !importBindsCall(imp, &types.Call{Callee: typeName, Receiver: qualifier}) {
+					continue
+				}
+				if resolution := ra.resolveImport(imp, filePath); resolution.Package != "" {
+					return resolution.Package + "." + typeName
+				}
+				return imp.Path + "." + typeName
+			}
+		}
+		return name
+	})
+}
+
+// containsMethods reports whether a method set has every required method with the same signature
+func containsMethods(methods, required map[string]string) bool {
+	for name, signature := range required {
+		if methods[name] != signature {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/internal/parser"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// addTestGoType registers a Go type definition and its symbol
+func addTestGoType(graph *types.CodeGraph, filePath, name, kind string, line int, embedded []string, methods ...*types.MethodSpec) *types.Symbol {
+	symbolType := types.SymbolTypeType
+	if kind == parser.TypeKindInterface {
+		symbolType = types.SymbolTypeInterface
+	}
+	symbol := addTestSymbol(graph, filePath, name, symbolType, line)
+	graph.Files[filePath].Types = append(graph.Files[filePath].Types, &types.TypeDefinition{
+		Symbol:   symbol.Id,
+		Name:     name,
+		Kind:     kind,
+		Methods:  methods,
+		Embedded: embedded,
+		Location: types.FileLocation{Line: line},
+	})
+	return symbol
+}
+
+// addTestGoMethod declares a method on a receiver type
+func addTestGoMethod(graph *types.CodeGraph, filePath, receiver string, pointer bool, name, signature string) {
+	graph.Files[filePath].Methods = append(graph.Files[filePath].Methods, &types.MethodSpec{
+		Name:      name,
+		Signature: signature,
+		Receiver:  receiver,
+		Pointer:   pointer,
+	})
+}
+
+func TestGoInterfaceSatisfaction(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module example.com/app\n")
+	writeTestFile(t, dir, "other/go.mod", "module example.com/other\n")
+
+	storeFile := filepath.Join(dir, "store", "store.go")
+	memoryFile := filepath.Join(dir, "store", "memory.go")
+	cacheFile := filepath.Join(dir, "cache", "cache.go")
+	remoteFile := filepath.Join(dir, "other", "remote.go")
+
+	graph := newEmptyTestGraph()
+	addTestFile(graph, storeFile, "go")
+	addTestFile(graph, memoryFile, "go")
+	addTestFile(graph, cacheFile, "go", "example.com/app/store")
+	addTestFile(graph, remoteFile, "go")
+
+	get := &types.MethodSpec{Name: "Get", Signature: "(string)(string,error)"}
+	keys := &types.MethodSpec{Name: "Keys", Signature: "()([]string)"}
+	symbols := map[string]*types.Symbol{
+		"Store": addTestGoType(graph, storeFile, "Store", parser.TypeKindInterface, 3, []string{"io.Closer"}, get),
+		"Keyed": addTestGoType(graph, storeFile, "Keyed", parser.TypeKindInterface, 8, nil, keys),
+		"Any":   addTestGoType(graph, storeFile, "Any", parser.TypeKindInterface, 12, nil),
+
+		// Memory gets Get from a pointer receiver and Keys/Close promoted from base
+		"base":   addTestGoType(graph, memoryFile, "base", parser.TypeKindStruct, 3, nil),
+		"Memory": addTestGoType(graph, memoryFile, "Memory", parser.TypeKindStruct, 6, []string{"base"}),
+		"Broken": addTestGoType(graph, memoryFile, "Broken", parser.TypeKindStruct, 20, nil),
+
+		// Embedding Memory by value promotes only its value methods; embedding *Memory promotes all
+		"Cache":    addTestGoType(graph, cacheFile, "Cache", parser.TypeKindStruct, 5, []string{"store.Memory"}),
+		"PtrCache": addTestGoType(graph, cacheFile, "PtrCache", parser.TypeKindStruct, 9, []string{"*store.Memory"}),
+
+		// Matching methods in another module do not count
+		"Remote": addTestGoType(graph, remoteFile, "Remote", parser.TypeKindStruct, 3, []string{"io.Closer"}),
+	}
+	addTestGoMethod(graph, memoryFile, "base", false, "Close", "()(error)")
+	addTestGoMethod(graph, memoryFile, "base", false, "Keys", "()([]string)")
+	addTestGoMethod(graph, memoryFile, "Memory", true, "Get", "(string)(string,error)")
+	addTestGoMethod(graph, memoryFile, "Broken", false, "Get", "(int)(string,error)")
+	addTestGoMethod(graph, memoryFile, "Broken", false, "Close", "()(error)")
+	addTestGoMethod(graph, remoteFile, "Remote", false, "Get", "(string)(string,error)")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{ByType: make(map[RelationshipType]int)}
+	analyzer.analyzeGoInterfaceSatisfaction(metrics)
+
+	expected := []struct {
+		subject string
+		target  string
+		pointer bool
+	}{
+		{"Memory", "Store", true},
+		{"Memory", "Keyed", false},
+		{"base", "Keyed", false},
+		{"Cache", "Store", true},
+		{"Cache", "Keyed", false},
+		{"PtrCache", "Store", false},
+		{"PtrCache", "Keyed", false},
+	}
+
+	for _, tt := range expected {
+		edge := graph.Edges[inheritanceEdgeId(string(RelationshipImplements), symbols[tt.subject], symbols[tt.target])]
+		if edge == nil {
+			t.Errorf("expected %s to implement %s", tt.subject, tt.target)
+			continue
+		}
+		if edge.Metadata["resolution"] != TypeResolutionStructural {
+			t.Errorf("%s -> %s resolution = %v, expected structural", tt.subject, tt.target, edge.Metadata["resolution"])
+		}
+		if edge.Metadata["pointer_receiver"] != tt.pointer {
+			t.Errorf("%s -> %s pointer_receiver = %v, expected %v", tt.subject, tt.target, edge.Metadata["pointer_receiver"], tt.pointer)
+		}
+	}
+
+	if metrics.ByType[RelationshipImplements] != len(expected) || len(graph.Edges) != len(expected) {
+		t.Errorf("implements = %d (%d edges), expected %d", metrics.ByType[RelationshipImplements], len(graph.Edges), len(expected))
+		for id := range graph.Edges {
+			t.Logf("edge %s", id)
+		}
+	}
+}
+
+func TestGoInterfaceSatisfactionQualifiedTypes(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module example.com/app\n")
+
+	storeFile := filepath.Join(dir, "store", "store.go")
+	cacheFile := filepath.Join(dir, "cache", "cache.go")
+
+	graph := newEmptyTestGraph()
+	addTestFile(graph, storeFile, "go", "io")
+	addTestFile(graph, cacheFile, "go", "example.com/app/store")
+
+	load := &types.MethodSpec{Name: "Load", Signature: "(string)(*Item,error)"}
+	open := &types.MethodSpec{Name: "Open", Signature: "()(io.Reader,error)"}
+	loader := addTestGoType(graph, storeFile, "Loader", parser.TypeKindInterface, 3, nil, load)
+	opener := addTestGoType(graph, storeFile, "Opener", parser.TypeKindInterface, 7, nil, open)
+	addTestGoType(graph, storeFile, "Item", parser.TypeKindStruct, 11, nil)
+
+	// Cache returns store.Item, which is the Item of the interface, and a local Reader, which is not io.Reader
+	cache := addTestGoType(graph, cacheFile, "Cache", parser.TypeKindStruct, 5, nil)
+	addTestGoType(graph, cacheFile, "Reader", parser.TypeKindStruct, 9, nil)
+	addTestGoMethod(graph, cacheFile, "Cache", false, "Load", "(string)(*store.Item,error)")
+	addTestGoMethod(graph, cacheFile, "Cache", false, "Open", "()(Reader,error)")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{ByType: make(map[RelationshipType]int)}
+	analyzer.analyzeGoInterfaceSatisfaction(metrics)
+
+	if graph.Edges[inheritanceEdgeId(string(RelationshipImplements), cache, loader)] == nil {
+		t.Errorf("expected Cache to implement Loader through store.Item")
+	}
+	if graph.Edges[inheritanceEdgeId(string(RelationshipImplements), cache, opener)] != nil {
+		t.Errorf("expected the local Reader not to match io.Reader")
+	}
+	if len(graph.Edges) != 1 {
+		t.Errorf("expected one edge, got %d", len(graph.Edges))
+	}
+}
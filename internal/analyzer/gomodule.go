@@ -0,0 +1,359 @@
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// goModule describes a Go module discovered from a go.mod file
+type goModule struct {
+	Path      string            // Module path declared by the module directive
+	Dir       string            // Directory containing go.mod
+	GoVersion string            // Version from the go directive
+	Requires  map[string]string // Required module path -> version
+	Replaces  map[string]string // Replaced module path -> local directory
+}
+
+// goModuleResolver maps Go import paths onto package directories using go.mod and go.work files
+type goModuleResolver struct {
+	ra         *RelationshipAnalyzer
+	modules    map[string]*goModule   // go.mod directory -> module
+	dirModules map[string]*goModule   // source directory -> nearest enclosing module
+	workspaces map[string][]*goModule // module directory -> modules of its go.work workspace
+	analyzed   []*goModule            // Modules enclosing analyzed files, built on first cross-root lookup
+}
+
+// newGoModuleResolver creates a resolver for Go imports in the analyzer's graph
+func newGoModuleResolver(ra *RelationshipAnalyzer) *goModuleResolver {
+	return &goModuleResolver{
+		ra:         ra,
+		modules:    make(map[string]*goModule),
+		dirModules: make(map[string]*goModule),
+		workspaces: make(map[string][]*goModule),
+	}
+}
+
+// Resolve resolves a Go import path used in fromFile
+func (gr *goModuleResolver) Resolve(importPath, fromFile string) *ImportResolution {
+	module := gr.moduleForDir(filepath.Dir(fromFile))
+
+	if module != nil {
+		// Modules of the same workspace (or the module itself) resolve to local directories
+		for _, candidate := range gr.workspaceModules(module) {
+			if dir, ok := goPackageDir(candidate.Path, candidate.Dir, importPath); ok {
+				return gr.packageResolution(dir, importPath, candidate)
+			}
+		}
+
+		// Local replace directives point at directories on disk
+		for _, replaced := range module.replacedPaths() {
+			dir := module.Replaces[replaced]
+			if local, ok := goPackageDir(replaced, dir, importPath); ok {
+				return gr.packageResolution(local, importPath, &goModule{Path: replaced, Dir: dir})
+			}
+		}
+	}
+
+	// Modules of other source roots resolve to their analyzed directories
+	if isMultiRoot(gr.ra.graph) {
+		for _, candidate := range gr.analyzedModules() {
+			if dir, ok := goPackageDir(candidate.Path, candidate.Dir, importPath); ok {
+				return gr.packageResolution(dir, importPath, candidate)
+			}
+		}
+	}
+
+	if isGoStdlib(importPath) {
+		version := ""
+		if module != nil && module.GoVersion != "" {
+			version = "go" + module.GoVersion
+		}
+		return &ImportResolution{External: true, Stdlib: true, Module: "std", Version: version}
+	}
+
+	resolution := &ImportResolution{External: true, Module: importPath}
+	if module != nil {
+		if path, version := module.requirementFor(importPath); path != "" {
+			resolution.Module = path
+			resolution.Version = version
+		}
+	}
+	return resolution
+}
+
+// replacedPaths returns the module paths with a local replacement, longest first so that a nested
+// module such as example.com/lib/v2 wins over example.com/lib
+func (m *goModule) replacedPaths() []string {
+	paths := make([]string, 0, len(m.Replaces))
+	for path := range m.Replaces {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) > len(paths[j])
+		}
+		return paths[i] < paths[j]
+	})
+	return paths
+}
+
+// packageResolution builds a resolution for a local package directory
+func (gr *goModuleResolver) packageResolution(dir, importPath string, module *goModule) *ImportResolution {
+	files := gr.ra.filesInDir(dir, "go", func(path string) bool {
+		return !strings.HasSuffix(path, "_test.go")
+	})
+
+	if len(files) == 0 {
+		// The package lives in the module but was not part of the analysis
+		return &ImportResolution{External: true, Module: module.Path}
+	}
+
+	return &ImportResolution{
+		Files:   files,
+		Package: dir,
+		Module:  module.Path,
+	}
+}
+
+// moduleForDir finds the module whose go.mod is closest above dir
+func (gr *goModuleResolver) moduleForDir(dir string) *goModule {
+	if module, cached := gr.dirModules[dir]; cached {
+		return module
+	}
+
+	var module *goModule
+	if parsed, ok := gr.loadModule(dir); ok {
+		module = parsed
+	} else if parent := filepath.Dir(dir); parent != dir {
+		module = gr.moduleForDir(parent)
+	}
+
+	gr.dirModules[dir] = module
+	return module
+}
+
+// loadModule parses dir/go.mod, caching the result
+func (gr *goModuleResolver) loadModule(dir string) (*goModule, bool) {
+	if module, cached := gr.modules[dir]; cached {
+		return module, module != nil
+	}
+
+	module, err := parseGoMod(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		gr.modules[dir] = nil
+		return nil, false
+	}
+
+	gr.modules[dir] = module
+	return module, true
+}
+
+// analyzedModules returns the modules enclosing any analyzed Go file, ordered by directory
+func (gr *goModuleResolver) analyzedModules() []*goModule {
+	if gr.analyzed != nil {
+		return gr.analyzed
+	}
+
+	seen := make(map[*goModule]bool)
+	gr.analyzed = make([]*goModule, 0)
+	for filePath, fileNode := range gr.ra.graph.Files {
+		if fileNode.Language != "go" {
+			continue
+		}
+		if module := gr.moduleForDir(filepath.Dir(filePath)); module != nil && !seen[module] {
+			seen[module] = true
+			gr.analyzed = append(gr.analyzed, module)
+		}
+	}
+	sort.Slice(gr.analyzed, func(i, j int) bool { return gr.analyzed[i].Dir < gr.analyzed[j].Dir })
+	return gr.analyzed
+}
+
+// workspaceModules returns the modules sharing a go.work workspace with module, module first
+func (gr *goModuleResolver) workspaceModules(module *goModule) []*goModule {
+	if modules, cached := gr.workspaces[module.Dir]; cached {
+		return modules
+	}
+
+	modules := []*goModule{module}
+	for dir := module.Dir; ; dir = filepath.Dir(dir) {
+		uses, err := parseGoWork(filepath.Join(dir, "go.work"))
+		if err == nil {
+			for _, use := range uses {
+				useDir := filepath.Clean(filepath.Join(dir, use))
+				if useDir == module.Dir {
+					continue
+				}
+				if member, ok := gr.loadModule(useDir); ok {
+					modules = append(modules, member)
+				}
+			}
+			break
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	gr.workspaces[module.Dir] = modules
+	return modules
+}
+
+// requirementFor finds the required module providing importPath
+func (m *goModule) requirementFor(importPath string) (string, string) {
+	bestPath, bestVersion := "", ""
+	for path, version := range m.Requires {
+		if (importPath == path || strings.HasPrefix(importPath, path+"/")) && len(path) > len(bestPath) {
+			bestPath, bestVersion = path, version
+		}
+	}
+	return bestPath, bestVersion
+}
+
+// goPackageDir maps importPath to a directory when it belongs to the module rooted at moduleDir
+func goPackageDir(modulePath, moduleDir, importPath string) (string, bool) {
+	if modulePath == "" {
+		return "", false
+	}
+	if importPath == modulePath {
+		return moduleDir, true
+	}
+	if strings.HasPrefix(importPath, modulePath+"/") {
+		rel := strings.TrimPrefix(importPath, modulePath+"/")
+		return filepath.Join(moduleDir, filepath.FromSlash(rel)), true
+	}
+	return "", false
+}
+
+// isGoStdlib reports whether an import path belongs to the standard library.
+// Like the go command, it treats paths whose first element has no dot as standard.
+func isGoStdlib(importPath string) bool {
+	first := importPath
+	if idx := strings.Index(importPath, "/"); idx != -1 {
+		first = importPath[:idx]
+	}
+	return first != "" && !strings.Contains(first, ".")
+}
+
+// parseGoMod reads the module, go, require and replace directives of a go.mod file
+func parseGoMod(path string) (*goModule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	module := &goModule{
+		Dir:      filepath.Dir(path),
+		Requires: make(map[string]string),
+		Replaces: make(map[string]string),
+	}
+
+	block := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := stripGoModComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if block != "" {
+			if line == ")" {
+				block = ""
+				continue
+			}
+			module.applyDirective(block, line)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == "(" {
+			block = fields[0]
+			continue
+		}
+		module.applyDirective(fields[0], strings.TrimSpace(strings.TrimPrefix(line, fields[0])))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+// applyDirective records a single go.mod directive
+func (m *goModule) applyDirective(verb, args string) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return
+	}
+
+	switch verb {
+	case "module":
+		m.Path = strings.Trim(fields[0], `"`)
+	case "go":
+		m.GoVersion = fields[0]
+	case "require":
+		if len(fields) >= 2 {
+			m.Requires[fields[0]] = fields[1]
+		}
+	case "replace":
+		// replace old [v] => new [v]; only local directory targets are relevant here
+		arrow := -1
+		for i, field := range fields {
+			if field == "=>" {
+				arrow = i
+				break
+			}
+		}
+		if arrow <= 0 || arrow+1 >= len(fields) {
+			return
+		}
+		target := fields[arrow+1]
+		if strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") || filepath.IsAbs(target) {
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(m.Dir, target)
+			}
+			m.Replaces[fields[0]] = filepath.Clean(target)
+		}
+	}
+}
+
+// parseGoWork returns the use directives of a go.work file
+func parseGoWork(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var uses []string
+	inUse := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := stripGoModComment(scanner.Text())
+		switch {
+		case line == "":
+		case inUse && line == ")":
+			inUse = false
+		case inUse:
+			uses = append(uses, strings.Trim(line, `"`))
+		case line == "use (":
+			inUse = true
+		case strings.HasPrefix(line, "use "):
+			uses = append(uses, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "use ")), `"`))
+		}
+	}
+
+	return uses, scanner.Err()
+}
+
+// stripGoModComment removes // comments and surrounding whitespace from a go.mod line
+func stripGoModComment(line string) string {
+	if idx := strings.Index(line, "//"); idx != -1 {
+		line = line[:idx]
+	}
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,193 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// writeTestFile writes content to dir/name, creating parent directories
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory for %s: %v", name, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// newEmptyTestGraph creates a graph without files or symbols
+func newEmptyTestGraph() *types.CodeGraph {
+	return &types.CodeGraph{
+		Nodes:    make(map[types.NodeId]*types.GraphNode),
+		Edges:    make(map[types.EdgeId]*types.GraphEdge),
+		Files:    make(map[string]*types.FileNode),
+		Symbols:  make(map[types.SymbolId]*types.Symbol),
+		Metadata: &types.GraphMetadata{},
+	}
+}
+
+// addTestFile registers a file with the given imports in the graph
+func addTestFile(graph *types.CodeGraph, path, language string, imports ...string) *types.FileNode {
+	fileNode := &types.FileNode{
+		Path:     path,
+		Language: language,
+		Imports:  make([]*types.Import, 0, len(imports)),
+	}
+	for _, imp := range imports {
+		fileNode.Imports = append(fileNode.Imports, &types.Import{Path: imp})
+	}
+	graph.Files[path] = fileNode
+	return fileNode
+}
+
+func TestParseGoMod(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "go.mod", `module github.com/acme/app // main module
+
+go 1.22
+
+require github.com/spf13/cobra v1.9.1
+
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/sys v0.29.0 // indirect
+)
+
+replace github.com/acme/shared => ../shared
+`)
+
+	module, err := parseGoMod(path)
+	if err != nil {
+		t.Fatalf("parseGoMod() error = %v", err)
+	}
+
+	if module.Path != "github.com/acme/app" {
+		t.Errorf("module path = %q, expected github.com/acme/app", module.Path)
+	}
+	if module.GoVersion != "1.22" {
+		t.Errorf("go version = %q, expected 1.22", module.GoVersion)
+	}
+	if module.Requires["github.com/spf13/cobra"] != "v1.9.1" {
+		t.Errorf("cobra version = %q, expected v1.9.1", module.Requires["github.com/spf13/cobra"])
+	}
+	if module.Requires["golang.org/x/sys"] != "v0.29.0" {
+		t.Errorf("x/sys version = %q, expected v0.29.0", module.Requires["golang.org/x/sys"])
+	}
+	if expected := filepath.Join(filepath.Dir(dir), "shared"); module.Replaces["github.com/acme/shared"] != expected {
+		t.Errorf("replace dir = %q, expected %q", module.Replaces["github.com/acme/shared"], expected)
+	}
+}
+
+func TestGoModuleImportResolution(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module github.com/acme/app\n\ngo 1.22\n\nrequire github.com/spf13/cobra v1.9.1\n")
+
+	graph := newEmptyTestGraph()
+	mainFile := filepath.Join(dir, "cmd", "app", "main.go")
+	addTestFile(graph, mainFile, "go", "fmt", "github.com/acme/app/internal/store", "github.com/spf13/cobra/doc")
+	addTestFile(graph, filepath.Join(dir, "internal", "store", "store.go"), "go")
+	addTestFile(graph, filepath.Join(dir, "internal", "store", "cache.go"), "go")
+	addTestFile(graph, filepath.Join(dir, "internal", "store", "store_test.go"), "go")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{ByType: make(map[RelationshipType]int)}
+	analyzer.analyzeImportRelationships(metrics)
+
+	storeDir := filepath.Join(dir, "internal", "store")
+	for _, target := range []string{"store.go", "cache.go"} {
+		edgeId := types.EdgeId("import-" + mainFile + "-" + filepath.Join(storeDir, target))
+		if _, exists := graph.Edges[edgeId]; !exists {
+			t.Errorf("expected file-level import edge to %s", target)
+		}
+	}
+	if _, exists := graph.Edges[types.EdgeId("import-"+mainFile+"-"+filepath.Join(storeDir, "store_test.go"))]; exists {
+		t.Error("test files should not be import targets")
+	}
+
+	packageEdge := graph.Edges[types.EdgeId("import-package-"+mainFile+"-"+storeDir)]
+	if packageEdge == nil {
+		t.Fatal("expected package-level import edge")
+	}
+	if packageEdge.To != packageNodeId(storeDir) {
+		t.Errorf("package edge target = %s, expected %s", packageEdge.To, packageNodeId(storeDir))
+	}
+	if node := graph.Nodes[packageNodeId(storeDir)]; node == nil || node.Type != "package" {
+		t.Error("expected package node to be added to the graph")
+	}
+
+	stdlib := graph.Edges[types.EdgeId("external-import-"+mainFile+"-fmt")]
+	if stdlib == nil {
+		t.Fatal("expected external edge for fmt")
+	}
+	if stdlib.Metadata["is_stdlib"] != true || stdlib.Metadata["module_version"] != "go1.22" {
+		t.Errorf("fmt edge metadata = %v, expected stdlib with version go1.22", stdlib.Metadata)
+	}
+
+	thirdParty := graph.Edges[types.EdgeId("external-import-"+mainFile+"-github.com/spf13/cobra/doc")]
+	if thirdParty == nil {
+		t.Fatal("expected external edge for cobra")
+	}
+	if thirdParty.Metadata["module"] != "github.com/spf13/cobra" || thirdParty.Metadata["module_version"] != "v1.9.1" {
+		t.Errorf("cobra edge metadata = %v, expected module github.com/spf13/cobra@v1.9.1", thirdParty.Metadata)
+	}
+}
+
+func TestGoWorkspaceImportResolution(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.work", "go 1.22\n\nuse (\n\t./api\n\t./lib\n)\n")
+	writeTestFile(t, dir, "api/go.mod", "module example.com/api\n\ngo 1.22\n")
+	writeTestFile(t, dir, "lib/go.mod", "module example.com/lib\n\ngo 1.22\n")
+
+	graph := newEmptyTestGraph()
+	handler := filepath.Join(dir, "api", "handler.go")
+	addTestFile(graph, handler, "go", "example.com/lib/strutil")
+	util := filepath.Join(dir, "lib", "strutil", "strutil.go")
+	addTestFile(graph, util, "go")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	if resolved := analyzer.resolveImportPath("example.com/lib/strutil", handler); resolved != util {
+		t.Errorf("resolveImportPath() = %q, expected %q", resolved, util)
+	}
+}
+
+func TestGoReplaceImportResolution(t *testing.T) {
+	dir := t.TempDir()
+	// The nested module is replaced separately from the module containing its path
+	writeTestFile(t, dir, "app/go.mod", "module example.com/app\n\ngo 1.22\n\nreplace example.com/lib => ../lib\n\nreplace example.com/lib/plugins => ../plugins\n")
+
+	graph := newEmptyTestGraph()
+	app := filepath.Join(dir, "app", "main.go")
+	addTestFile(graph, app, "go", "example.com/lib/plugins/auth")
+	plugin := filepath.Join(dir, "plugins", "auth", "auth.go")
+	addTestFile(graph, plugin, "go")
+	addTestFile(graph, filepath.Join(dir, "lib", "plugins", "auth", "auth.go"), "go")
+
+	// Each analyzer parses go.mod afresh, so repeating the lookup covers different map orders
+	for i := 0; i < 10; i++ {
+		if resolved := NewRelationshipAnalyzer(graph).resolveImportPath("example.com/lib/plugins/auth", app); resolved != plugin {
+			t.Fatalf("resolveImportPath() = %q, expected the nested replacement %q", resolved, plugin)
+		}
+	}
+}
+
+func TestIsGoStdlib(t *testing.T) {
+	tests := map[string]bool{
+		"fmt":                      true,
+		"net/http":                 true,
+		"github.com/spf13/cobra":   false,
+		"golang.org/x/sys/unix":    false,
+		"example.com/internal/foo": false,
+	}
+
+	for importPath, expected := range tests {
+		if result := isGoStdlib(importPath); result != expected {
+			t.Errorf("isGoStdlib(%q) = %v, expected %v", importPath, result, expected)
+		}
+	}
+}
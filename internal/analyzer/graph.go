@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -26,6 +27,9 @@ type GraphBuilder struct {
 	cache            *cache.PersistentCache
 	progressCallback func(string)
 	progressConfig   ProgressConfig
+	fileTimeout      time.Duration // Longest time spent on one file, 0 for no limit
+	annotationBlame  bool          // Attribute annotations to authors with git blame
+	blameCache       map[string]*blameCacheEntry
 }
 
 // NewGraphBuilder creates a new graph builder
@@ -43,6 +47,7 @@ func NewGraphBuilder() *GraphBuilder {
 			Interval:       10,   // Default: update every 10 files
 			ShowPercentage: false, // Default: don't show percentage (requires pre-counting)
 		},
+		annotationBlame: true,
 	}
 }
 
@@ -70,52 +75,148 @@ func (gb *GraphBuilder) SetProgressConfig(config ProgressConfig) {
 	}
 }
 
+// SetFileTimeout limits the time spent parsing and extracting a single file. A file that takes
+// longer is skipped and recorded in the graph's errors; 0 removes the limit.
+func (gb *GraphBuilder) SetFileTimeout(timeout time.Duration) {
+	gb.fileTimeout = timeout
+}
+
+// SetAnnotationBlame sets whether TODO and other annotations are attributed to their authors with
+// git blame. It is on by default; turning it off saves one git process per annotated file.
+func (gb *GraphBuilder) SetAnnotationBlame(enabled bool) {
+	gb.annotationBlame = enabled
+}
+
 // AnalyzeDirectory analyzes a directory and builds a complete code graph
 func (gb *GraphBuilder) AnalyzeDirectory(targetDir string) (*types.CodeGraph, error) {
+	return gb.AnalyzeDirectoryContext(context.Background(), targetDir)
+}
+
+// AnalyzeDirectoryContext analyzes a directory like AnalyzeDirectory, stopping with ctx's error
+// once ctx is done
+func (gb *GraphBuilder) AnalyzeDirectoryContext(ctx context.Context, targetDir string) (*types.CodeGraph, error) {
+	return gb.analyze(ctx, targetDir, []types.SourceRoot{{Path: targetDir}})
+}
+
+// AnalyzeRoots analyzes several source roots, such as a backend and a frontend checkout, into one
+// code graph. Files are labeled with their root and imports are resolved across roots where the
+// language allows it. A file below more than one root belongs to the first.
+func (gb *GraphBuilder) AnalyzeRoots(roots []types.SourceRoot) (*types.CodeGraph, error) {
+	return gb.AnalyzeRootsContext(context.Background(), roots)
+}
+
+// AnalyzeRootsContext analyzes several source roots like AnalyzeRoots, stopping with ctx's error
+// once ctx is done
+func (gb *GraphBuilder) AnalyzeRootsContext(ctx context.Context, roots []types.SourceRoot) (*types.CodeGraph, error) {
+	roots, err := NormalizeSourceRoots(roots)
+	if err != nil {
+		return nil, err
+	}
+	return gb.analyze(ctx, roots[0].Path, roots)
+}
+
+// analyze walks the roots and builds the graph; projectPath is recorded in the metadata. Files that
+// fail to parse are recorded in the metadata errors and skipped, so only cancellation and unreadable
+// roots abort the analysis.
+func (gb *GraphBuilder) analyze(ctx context.Context, projectPath string, roots []types.SourceRoot) (*types.CodeGraph, error) {
 	start := time.Now()
 
+	// Start from an empty graph so repeated analyses see edited and deleted files
+	gb.graph = &types.CodeGraph{
+		Nodes:   make(map[types.NodeId]*types.GraphNode),
+		Edges:   make(map[types.EdgeId]*types.GraphEdge),
+		Files:   make(map[string]*types.FileNode),
+		Symbols: make(map[types.SymbolId]*types.Symbol),
+	}
+
 	// Initialize graph metadata
 	gb.graph.Metadata = &types.GraphMetadata{
 		Generated:    time.Now(),
 		Version:      "2.0.0",
+		ProjectPath:  projectPath,
 		TotalFiles:   0,
 		TotalSymbols: 0,
 		Languages:    make(map[string]int),
 	}
+	if roots[0].Label != "" {
+		// Labeled roots come from AnalyzeRoots; resolvers consult them while relationships are built
+		gb.graph.Metadata.Roots = roots
+	}
 
-	// Walk directory and process files
+	// Walk directories and process files, setting documentation aside to link once symbols are known
 	fileCount := 0
-	err := filepath.Walk(targetDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+	documents := make([]string, 0)
+	seenDocuments := make(map[string]bool)
+	for _, root := range roots {
+		err := filepath.Walk(root.Path, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if path == root.Path {
+					return err
+				}
+				// Unreadable entries below the root are skipped like files that fail to parse
+				gb.recordFileError(path, err)
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-		// Skip directories and unsupported files
-		if info.IsDir() || !gb.isSupportedFile(path) {
-			return nil
-		}
+			// Skip directories and unsupported files
+			if info.IsDir() || (!gb.isSupportedFile(path) && !isDocumentationFile(path)) {
+				return nil
+			}
 
-		// Skip certain directories
-		if gb.shouldSkipPath(path) {
-			return nil
-		}
+			// Skip certain directories below the root, wherever the root itself lives
+			if relative, err := filepath.Rel(root.Path, path); err == nil && gb.shouldSkipPath(relative) {
+				return nil
+			}
 
-		fileCount++
-		// Update progress at configured intervals for staged display
-		if gb.progressCallback != nil && fileCount%gb.progressConfig.Interval == 0 {
-			gb.progressCallback(fmt.Sprintf("📄 Parsing files... (%d files)", fileCount))
-		}
+			if isDocumentationFile(path) {
+				if !seenDocuments[path] {
+					seenDocuments[path] = true
+					documents = append(documents, path)
+				}
+				return nil
+			}
 
-		return gb.processFile(path)
-	})
+			// Skip files already analyzed under an enclosing root
+			if _, exists := gb.graph.Files[path]; exists {
+				return nil
+			}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to analyze directory: %w", err)
+			fileCount++
+			// Update progress at configured intervals for staged display
+			if gb.progressCallback != nil && fileCount%gb.progressConfig.Interval == 0 {
+				gb.progressCallback(fmt.Sprintf("📄 Parsing files... (%d files)", fileCount))
+			}
+
+			if err := gb.processFile(ctx, path); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				gb.recordFileError(path, err)
+				return nil
+			}
+			if fileNode := gb.graph.Files[path]; fileNode != nil {
+				fileNode.Root = root.Label
+			}
+			return nil
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze directory: %w", err)
+		}
 	}
 
 	// Show completion of parsing stage
 	if gb.progressCallback != nil {
 		gb.progressCallback(fmt.Sprintf("✅ Parsing complete (%d files)", fileCount))
+		if len(gb.graph.Metadata.Errors) > 0 {
+			gb.progressCallback(fmt.Sprintf("⚠️ Skipped %d files that could not be analyzed", len(gb.graph.Metadata.Errors)))
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to analyze directory: %w", err)
 	}
 
 	// Build relationships between files
@@ -128,12 +229,30 @@ func (gb *GraphBuilder) AnalyzeDirectory(targetDir string) (*types.CodeGraph, er
 		gb.progressCallback("✅ Relationships built")
 	}
 
+	// Link markdown documentation to the symbols and files it mentions
+	NewRelationshipAnalyzer(gb.graph).linkDocuments(documents)
+
+	// Attribute TODO and other annotations to their authors
+	gb.blameAnnotations(ctx, roots)
+
 	// Build semantic neighborhoods if git repository
 	if gb.progressCallback != nil {
 		gb.progressCallback("📊 Analyzing git history...")
 	}
-	semanticResult, err := gb.buildSemanticNeighborhoods(targetDir)
-	if err == nil && semanticResult != nil {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to analyze directory: %w", err)
+	}
+	var semanticResult *SemanticAnalysisResult
+	for _, root := range roots {
+		if ctx.Err() != nil {
+			break
+		}
+		result, err := gb.buildSemanticNeighborhoods(root.Path)
+		if err == nil && result != nil {
+			semanticResult = mergeSemanticResults(semanticResult, result)
+		}
+	}
+	if semanticResult != nil {
 		// Add semantic analysis results to metadata
 		if gb.graph.Metadata.Configuration == nil {
 			gb.graph.Metadata.Configuration = make(map[string]interface{})
@@ -147,6 +266,10 @@ func (gb *GraphBuilder) AnalyzeDirectory(targetDir string) (*types.CodeGraph, er
 		gb.progressCallback("⚠️ Git analysis skipped")
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to analyze directory: %w", err)
+	}
+
 	// Update metadata
 	gb.graph.Metadata.TotalFiles = len(gb.graph.Files)
 	gb.graph.Metadata.TotalSymbols = len(gb.graph.Symbols)
@@ -155,8 +278,19 @@ func (gb *GraphBuilder) AnalyzeDirectory(targetDir string) (*types.CodeGraph, er
 	return gb.graph, nil
 }
 
-// processFile processes a single file and extracts symbols
-func (gb *GraphBuilder) processFile(filePath string) error {
+// recordFileError notes a file that was skipped because it could not be analyzed
+func (gb *GraphBuilder) recordFileError(filePath string, err error) {
+	gb.graph.Metadata.Errors = append(gb.graph.Metadata.Errors, types.FileError{Path: filePath, Error: err.Error()})
+}
+
+// processFile processes a single file and extracts symbols, within the configured file timeout
+func (gb *GraphBuilder) processFile(ctx context.Context, filePath string) error {
+	if gb.fileTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, gb.fileTimeout)
+		defer cancel()
+	}
+
 	// Detect language
 	classification, err := gb.parser.ClassifyFile(filePath)
 	if err != nil {
@@ -165,7 +299,7 @@ func (gb *GraphBuilder) processFile(filePath string) error {
 	}
 
 	// Parse the file
-	ast, err := gb.parser.ParseFile(filePath, classification.Language)
+	ast, err := gb.parser.ParseFileContext(ctx, filePath, classification.Language)
 	if err != nil {
 		return fmt.Errorf("failed to parse file %s: %w", filePath, err)
 	}
@@ -182,6 +316,39 @@ func (gb *GraphBuilder) processFile(filePath string) error {
 		return fmt.Errorf("failed to extract imports from %s: %w", filePath, err)
 	}
 
+	// Extract call sites
+	calls, err := gb.parser.ExtractCalls(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract calls from %s: %w", filePath, err)
+	}
+
+	// Extract heritage clauses
+	inheritance, err := gb.parser.ExtractInheritance(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract inheritance from %s: %w", filePath, err)
+	}
+
+	// Extract type definitions and methods for structural interface matching
+	typeDefinitions, err := gb.parser.ExtractTypeDefinitions(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract type definitions from %s: %w", filePath, err)
+	}
+	methods, err := gb.parser.ExtractMethods(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract methods from %s: %w", filePath, err)
+	}
+
+	// Extract TODO, FIXME, HACK, XXX and @deprecated comments
+	annotations, err := gb.parser.ExtractAnnotations(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract annotations from %s: %w", filePath, err)
+	}
+
+	// Extraction cannot be interrupted, but a file that overran its timeout is still dropped
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to analyze %s: %w", filePath, err)
+	}
+
 	// Create file node
 	fileNode := &types.FileNode{
 		Path:         filePath,
@@ -195,6 +362,12 @@ func (gb *GraphBuilder) processFile(filePath string) error {
 		LastModified: time.Now(),
 		Symbols:      make([]types.SymbolId, 0, len(symbols)),
 		Imports:      imports,
+		Calls:        calls,
+		Inheritance:  inheritance,
+		Types:        typeDefinitions,
+		Methods:      methods,
+		Metrics:      parser.SummarizeMetrics(symbols),
+		Annotations:  annotations,
 	}
 
 	// Add symbols to graph and file
@@ -309,7 +482,7 @@ func (gb *GraphBuilder) resolveImportPath(importPath, fromFile string) string {
 // isSupportedFile checks if a file is supported for parsing
 func (gb *GraphBuilder) isSupportedFile(path string) bool {
 	ext := filepath.Ext(path)
-	supportedExtensions := []string{".ts", ".tsx", ".js", ".jsx", ".json", ".yaml", ".yml"}
+	supportedExtensions := []string{".ts", ".tsx", ".js", ".jsx", ".go", ".py", ".java", ".rs", ".json", ".yaml", ".yml"}
 
 	for _, supported := range supportedExtensions {
 		if ext == supported {
@@ -319,15 +492,16 @@ func (gb *GraphBuilder) isSupportedFile(path string) bool {
 	return false
 }
 
-// shouldSkipPath checks if a path should be skipped during analysis
+// shouldSkipPath checks if a path relative to the analyzed root lies in a directory that should be
+// skipped during analysis; directories are matched by name, so builder/ is analyzed but build/ is not
 func (gb *GraphBuilder) shouldSkipPath(path string) bool {
-	skipDirs := []string{
-		"node_modules", ".git", ".codecontext", "dist", "build",
-		"coverage", ".nyc_output", "tmp", "temp",
+	skipDirs := map[string]bool{
+		"node_modules": true, ".git": true, ".codecontext": true, "dist": true, "build": true,
+		"coverage": true, ".nyc_output": true, "tmp": true, "temp": true,
 	}
 
-	for _, skipDir := range skipDirs {
-		if strings.Contains(path, skipDir) {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if skipDirs[part] {
 			return true
 		}
 	}
@@ -499,6 +673,36 @@ func (gb *GraphBuilder) buildSemanticNeighborhoods(targetDir string) (*SemanticA
 	}, nil
 }
 
+// mergeSemanticResults combines the semantic analysis of several roots; scores and the analysis
+// period come from the first root that is a git repository
+func mergeSemanticResults(merged, result *SemanticAnalysisResult) *SemanticAnalysisResult {
+	if merged == nil {
+		return result
+	}
+	if !merged.AnalysisMetadata.IsGitRepository && result.AnalysisMetadata.IsGitRepository {
+		merged, result = result, merged
+	}
+
+	merged.SemanticNeighborhoods = append(merged.SemanticNeighborhoods, result.SemanticNeighborhoods...)
+	merged.EnhancedNeighborhoods = append(merged.EnhancedNeighborhoods, result.EnhancedNeighborhoods...)
+	merged.ClusteredNeighborhoods = append(merged.ClusteredNeighborhoods, result.ClusteredNeighborhoods...)
+	if merged.Error == "" {
+		merged.Error = result.Error
+	}
+
+	metadata := &merged.AnalysisMetadata
+	clusters := metadata.TotalClusters + result.AnalysisMetadata.TotalClusters
+	if clusters > 0 {
+		metadata.AverageClusterSize = (metadata.AverageClusterSize*float64(metadata.TotalClusters) +
+			result.AnalysisMetadata.AverageClusterSize*float64(result.AnalysisMetadata.TotalClusters)) / float64(clusters)
+	}
+	metadata.TotalClusters = clusters
+	metadata.TotalNeighborhoods += result.AnalysisMetadata.TotalNeighborhoods
+	metadata.FilesWithPatterns += result.AnalysisMetadata.FilesWithPatterns
+	metadata.AnalysisTime += result.AnalysisMetadata.AnalysisTime
+	return merged
+}
+
 // calculateQualityScores calculates overall quality metrics from clustered neighborhoods
 func (gb *GraphBuilder) calculateQualityScores(clusteredNeighborhoods []git.ClusteredNeighborhood) QualityScores {
 	if len(clusteredNeighborhoods) == 0 {
@@ -1,11 +1,14 @@
 package analyzer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewGraphBuilder(t *testing.T) {
@@ -134,6 +137,44 @@ const result = testFunction("hello");
 	}
 }
 
+func TestAnalyzeDirectoryContext(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.go", "package a\n\nfunc A() {}\n")
+	writeTestFile(t, dir, "b/b.ts", "export function b() { return 1 }\n")
+
+	builder := NewGraphBuilder()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := builder.AnalyzeDirectoryContext(ctx, dir); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled analysis to fail with context.Canceled, got %v", err)
+	}
+
+	// Files overrunning the timeout are recorded and skipped without failing the analysis
+	builder.SetFileTimeout(time.Nanosecond)
+	graph, err := builder.AnalyzeDirectoryContext(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectoryContext() error = %v", err)
+	}
+	if len(graph.Files) != 0 || len(graph.Metadata.Errors) != 2 {
+		t.Errorf("expected both files to be skipped, got %d files and errors %+v", len(graph.Files), graph.Metadata.Errors)
+	}
+	for _, fileError := range graph.Metadata.Errors {
+		if !strings.Contains(fileError.Error, context.DeadlineExceeded.Error()) {
+			t.Errorf("unexpected error for %s: %s", fileError.Path, fileError.Error)
+		}
+	}
+
+	// A reused builder starts from an empty graph
+	builder.SetFileTimeout(0)
+	graph, err = builder.AnalyzeDirectoryContext(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectoryContext() error = %v", err)
+	}
+	if len(graph.Files) != 2 || len(graph.Metadata.Errors) != 0 {
+		t.Errorf("expected 2 files without errors, got %d files and errors %+v", len(graph.Files), graph.Metadata.Errors)
+	}
+}
+
 func TestIsSupportedFile(t *testing.T) {
 	builder := NewGraphBuilder()
 
@@ -149,8 +190,10 @@ func TestIsSupportedFile(t *testing.T) {
 		{"test.yaml", true},
 		{"test.yml", true},
 		{"test.txt", false},
-		{"test.py", false},
-		{"test.go", false},
+		{"test.py", true},
+		{"test.go", true},
+		{"Test.java", true},
+		{"test.rs", true},
 		{"README.md", false},
 	}
 
@@ -177,6 +220,8 @@ func TestShouldSkipPath(t *testing.T) {
 		{"coverage/report.html", true},
 		{"test/unit.spec.ts", false},
 		{".codecontext/config.yaml", true},
+		{"internal/builder/graph.go", false},
+		{"src/tmp/scratch.go", true},
 	}
 
 	for _, test := range tests {
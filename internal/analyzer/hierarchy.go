@@ -0,0 +1,193 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// typeSymbolTypes lists symbol types a heritage clause can name
+var typeSymbolTypes = map[types.SymbolType]bool{
+	types.SymbolTypeClass:     true,
+	types.SymbolTypeInterface: true,
+	types.SymbolTypeType:      true,
+	types.SymbolTypeComponent: true,
+}
+
+// TypeHierarchyNode is a type together with the types that extend or implement it
+type TypeHierarchyNode struct {
+	Symbol   *types.Symbol        `json:"symbol"`
+	File     string               `json:"file"`
+	Kind     string               `json:"kind,omitempty"` // How this type relates to its parent: extends or implements
+	Subtypes []*TypeHierarchyNode `json:"subtypes,omitempty"`
+}
+
+// analyzeInheritanceRelationships resolves heritage clauses into extends and implements edges
+func (ra *RelationshipAnalyzer) analyzeInheritanceRelationships(metrics *RelationshipMetrics) {
+	counts := make(map[RelationshipType]int)
+	crossFileCount := 0
+
+	for filePath, fileNode := range ra.graph.Files {
+		for _, clause := range fileNode.Inheritance {
+			subject := ra.inheritanceSubject(clause, filePath)
+			target, resolution := ra.resolveTypeReference(clause.Target, clause.Qualifier, filePath)
+			if subject == nil || target == nil || subject.Id == target.Id {
+				continue
+			}
+
+			if ra.addInheritanceEdge(filePath, clause, subject, target, resolution) {
+				counts[RelationshipType(clause.Kind)]++
+				if ra.symbolFile(target.Id) != ra.symbolFile(subject.Id) {
+					crossFileCount++
+				}
+			}
+		}
+	}
+
+	for _, relType := range []RelationshipType{RelationshipExtends, RelationshipImplements} {
+		metrics.ByType[relType] = counts[relType]
+		metrics.SymbolToSymbol += counts[relType]
+	}
+	metrics.CrossFileRefs += crossFileCount
+}
+
+// inheritanceSubject returns the type declaring a heritage clause. Rust trait impls are owned by
+// their impl block, so the implementing struct or enum is looked up by name.
+func (ra *RelationshipAnalyzer) inheritanceSubject(clause *types.Inheritance, filePath string) *types.Symbol {
+	symbol := ra.graph.Symbols[clause.Symbol]
+	if symbol == nil || !isRustImplBlock(symbol) {
+		return symbol
+	}
+	subject, _ := ra.resolveTypeReference(clause.Type, "", filePath)
+	return subject
+}
+
+// resolveTypeReference finds the type a name used in filePath refers to, following the same
+// local, import, package and global steps as call resolution. Ambiguous global names stay unresolved.
+func (ra *RelationshipAnalyzer) resolveTypeReference(name, qualifier, filePath string) (*types.Symbol, string) {
+	symbol, resolution, candidates := ra.resolveScopedName(name, qualifier, filePath, ra.typesNamed)
+	if candidates > 1 {
+		return nil, ""
+	}
+	return symbol, resolution
+}
+
+// typesNamed returns the class, interface and type symbols with a name, sorted by id
+func (ra *RelationshipAnalyzer) typesNamed(name string) []*types.Symbol {
+	ra.indexSymbols()
+	return ra.typesByName[name]
+}
+
+// isRustImplBlock reports whether a symbol is a Rust impl block rather than a type declaration
+func isRustImplBlock(symbol *types.Symbol) bool {
+	return symbol.Language == "rust" && strings.HasPrefix(string(symbol.Id), "impl-")
+}
+
+// addInheritanceEdge records an extends or implements edge between two types
+func (ra *RelationshipAnalyzer) addInheritanceEdge(filePath string, clause *types.Inheritance, subject, target *types.Symbol, resolution string) bool {
+	edgeId := inheritanceEdgeId(clause.Kind, subject, target)
+	if _, exists := ra.graph.Edges[edgeId]; exists {
+		return false
+	}
+
+	ra.graph.Edges[edgeId] = &types.GraphEdge{
+		Id:     edgeId,
+		From:   types.NodeId(fmt.Sprintf("symbol-%s", subject.Id)),
+		To:     types.NodeId(fmt.Sprintf("symbol-%s", target.Id)),
+		Type:   clause.Kind,
+		Weight: 1.0,
+		Metadata: map[string]interface{}{
+			"target":      clause.Target,
+			"qualifier":   clause.Qualifier,
+			"resolution":  resolution,
+			"source_file": filePath,
+			"target_file": ra.symbolFile(target.Id),
+			"line":        clause.Location.Line,
+		},
+	}
+	return true
+}
+
+// inheritanceEdgeId returns the id of the extends or implements edge between two types
+func inheritanceEdgeId(kind string, subject, target *types.Symbol) types.EdgeId {
+	return types.EdgeId(fmt.Sprintf("%s-%s-%s", kind, subject.Id, target.Id))
+}
+
+// BuildTypeHierarchy arranges the extends and implements edges of a graph into trees rooted at
+// the types that have no resolved supertype
+func BuildTypeHierarchy(graph *types.CodeGraph) []*TypeHierarchyNode {
+	type subtypeLink struct {
+		id   types.SymbolId
+		kind string
+	}
+	subtypes := make(map[types.SymbolId][]subtypeLink)
+	hasSupertype := make(map[types.SymbolId]bool)
+
+	for _, edge := range graph.Edges {
+		if edge.Type != string(RelationshipExtends) && edge.Type != string(RelationshipImplements) {
+			continue
+		}
+		from := types.SymbolId(strings.TrimPrefix(string(edge.From), "symbol-"))
+		to := types.SymbolId(strings.TrimPrefix(string(edge.To), "symbol-"))
+		if graph.Symbols[from] == nil || graph.Symbols[to] == nil {
+			continue
+		}
+		subtypes[to] = append(subtypes[to], subtypeLink{id: from, kind: edge.Type})
+		hasSupertype[from] = true
+	}
+
+	symbolFiles := make(map[types.SymbolId]string)
+	for filePath, fileNode := range graph.Files {
+		for _, symbolId := range fileNode.Symbols {
+			symbolFiles[symbolId] = filePath
+		}
+	}
+
+	byName := func(ids []types.SymbolId) {
+		sort.Slice(ids, func(i, j int) bool {
+			a, b := graph.Symbols[ids[i]], graph.Symbols[ids[j]]
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.Id < b.Id
+		})
+	}
+
+	// Types on an inheritance cycle are not revisited on the same path
+	var build func(id types.SymbolId, kind string, path map[types.SymbolId]bool) *TypeHierarchyNode
+	build = func(id types.SymbolId, kind string, path map[types.SymbolId]bool) *TypeHierarchyNode {
+		node := &TypeHierarchyNode{Symbol: graph.Symbols[id], File: symbolFiles[id], Kind: kind}
+		path[id] = true
+		defer delete(path, id)
+
+		kinds := make(map[types.SymbolId]string)
+		children := make([]types.SymbolId, 0, len(subtypes[id]))
+		for _, link := range subtypes[id] {
+			if _, seen := kinds[link.id]; !seen && !path[link.id] {
+				children = append(children, link.id)
+			}
+			kinds[link.id] = link.kind
+		}
+		byName(children)
+		for _, child := range children {
+			node.Subtypes = append(node.Subtypes, build(child, kinds[child], path))
+		}
+		return node
+	}
+
+	roots := make([]types.SymbolId, 0)
+	for id := range subtypes {
+		if !hasSupertype[id] {
+			roots = append(roots, id)
+		}
+	}
+	byName(roots)
+
+	hierarchy := make([]*TypeHierarchyNode, 0, len(roots))
+	for _, root := range roots {
+		hierarchy = append(hierarchy, build(root, "", make(map[types.SymbolId]bool)))
+	}
+	return hierarchy
+}
@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// addTestInheritance records a heritage clause declared by symbol in filePath
+func addTestInheritance(graph *types.CodeGraph, filePath string, symbol *types.Symbol, typeName, kind, qualifier, target string) {
+	graph.Files[filePath].Inheritance = append(graph.Files[filePath].Inheritance, &types.Inheritance{
+		Symbol:    symbol.Id,
+		Type:      typeName,
+		Kind:      kind,
+		Target:    target,
+		Qualifier: qualifier,
+		Location:  types.FileLocation{Line: symbol.Location.StartLine},
+	})
+}
+
+func newHierarchyTestGraph() (*types.CodeGraph, map[string]*types.Symbol) {
+	graph := newEmptyTestGraph()
+	symbols := make(map[string]*types.Symbol)
+
+	// TypeScript: Dog extends an imported Animal and implements a local interface
+	addTestFile(graph, "src/dog.ts", "typescript", "./animal")
+	graph.Files["src/dog.ts"].Imports[0].Specifiers = []string{"Animal"}
+	addTestFile(graph, "src/animal.ts", "typescript")
+	symbols["Animal"] = addTestSymbol(graph, "src/animal.ts", "Animal", types.SymbolTypeClass, 1)
+	symbols["Pet"] = addTestSymbol(graph, "src/dog.ts", "Pet", types.SymbolTypeInterface, 2)
+	symbols["Dog"] = addTestSymbol(graph, "src/dog.ts", "Dog", types.SymbolTypeClass, 5)
+	symbols["Puppy"] = addTestSymbol(graph, "src/dog.ts", "Puppy", types.SymbolTypeClass, 20)
+	addTestInheritance(graph, "src/dog.ts", symbols["Dog"], "Dog", "extends", "", "Animal")
+	addTestInheritance(graph, "src/dog.ts", symbols["Dog"], "Dog", "implements", "", "Pet")
+	addTestInheritance(graph, "src/dog.ts", symbols["Puppy"], "Puppy", "extends", "", "Dog")
+	addTestInheritance(graph, "src/dog.ts", symbols["Puppy"], "Puppy", "extends", "", "Unknown") // external, stays unresolved
+
+	// TypeScript: a class extending a Node built-in never binds to a local class of the same name
+	addTestFile(graph, "src/stream.ts", "typescript", "events")
+	graph.Files["src/stream.ts"].Imports[0].Specifiers = []string{"EventEmitter"}
+	addTestFile(graph, "src/bus.ts", "typescript")
+	symbols["EventEmitter"] = addTestSymbol(graph, "src/bus.ts", "EventEmitter", types.SymbolTypeClass, 1)
+	symbols["Stream"] = addTestSymbol(graph, "src/stream.ts", "Stream", types.SymbolTypeClass, 3)
+	addTestInheritance(graph, "src/stream.ts", symbols["Stream"], "Stream", "extends", "", "EventEmitter")
+
+	// Rust: an impl block in another module implements a trait for a struct
+	addTestFile(graph, "src/shapes.rs", "rust")
+	addTestFile(graph, "src/render.rs", "rust")
+	symbols["Shape"] = addTestSymbol(graph, "src/shapes.rs", "Shape", types.SymbolTypeInterface, 1)
+	symbols["Circle"] = addTestSymbol(graph, "src/render.rs", "Circle", types.SymbolTypeClass, 1)
+	impl := &types.Symbol{Id: "impl-src/render.rs-5", Name: "Shape", Type: types.SymbolTypeClass, Language: "rust"}
+	graph.Symbols[impl.Id] = impl
+	graph.Files["src/render.rs"].Symbols = append(graph.Files["src/render.rs"].Symbols, impl.Id)
+	addTestInheritance(graph, "src/render.rs", impl, "Circle", "implements", "", "Shape")
+
+	return graph, symbols
+}
+
+func TestInheritanceResolution(t *testing.T) {
+	graph, symbols := newHierarchyTestGraph()
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{ByType: make(map[RelationshipType]int)}
+	analyzer.analyzeInheritanceRelationships(metrics)
+
+	tests := []struct {
+		name       string
+		kind       RelationshipType
+		subject    string
+		target     string
+		resolution string
+	}{
+		{"imported base class", RelationshipExtends, "Dog", "Animal", CallResolutionImport},
+		{"local interface", RelationshipImplements, "Dog", "Pet", CallResolutionLocal},
+		{"local subclass", RelationshipExtends, "Puppy", "Dog", CallResolutionLocal},
+		{"rust trait impl", RelationshipImplements, "Circle", "Shape", CallResolutionGlobal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edgeId := types.EdgeId(fmt.Sprintf("%s-%s-%s", tt.kind, symbols[tt.subject].Id, symbols[tt.target].Id))
+			edge := graph.Edges[edgeId]
+			if edge == nil {
+				t.Fatalf("expected %s edge from %s to %s", tt.kind, tt.subject, tt.target)
+			}
+			if edge.Type != string(tt.kind) {
+				t.Errorf("edge type = %s, expected %s", edge.Type, tt.kind)
+			}
+			if edge.Metadata["resolution"] != tt.resolution {
+				t.Errorf("resolution = %v, expected %s", edge.Metadata["resolution"], tt.resolution)
+			}
+		})
+	}
+
+	if edge := graph.Edges[types.EdgeId(fmt.Sprintf("%s-%s-%s", RelationshipExtends, symbols["Stream"].Id, symbols["EventEmitter"].Id))]; edge != nil {
+		t.Error("class extending an imported Node built-in linked to the local EventEmitter")
+	}
+
+	if metrics.ByType[RelationshipExtends] != 2 || metrics.ByType[RelationshipImplements] != 2 {
+		t.Errorf("metrics = %v, expected 2 extends and 2 implements", metrics.ByType)
+	}
+	if metrics.CrossFileRefs != 2 {
+		t.Errorf("cross file refs = %d, expected 2", metrics.CrossFileRefs)
+	}
+}
+
+func TestBuildTypeHierarchy(t *testing.T) {
+	graph, _ := newHierarchyTestGraph()
+	NewRelationshipAnalyzer(graph).analyzeInheritanceRelationships(&RelationshipMetrics{ByType: make(map[RelationshipType]int)})
+
+	hierarchy := BuildTypeHierarchy(graph)
+
+	var lines []string
+	var walk func(node *TypeHierarchyNode, depth int)
+	walk = func(node *TypeHierarchyNode, depth int) {
+		lines = append(lines, fmt.Sprintf("%s%s %s", strings.Repeat(" ", depth), node.Symbol.Name, node.Kind))
+		for _, subtype := range node.Subtypes {
+			walk(subtype, depth+1)
+		}
+	}
+	for _, root := range hierarchy {
+		walk(root, 0)
+	}
+
+	expected := []string{
+		"Animal ",
+		" Dog extends",
+		"  Puppy extends",
+		"Pet ",
+		" Dog implements",
+		"  Puppy extends",
+		"Shape ",
+		" Circle implements",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("hierarchy =\n%s\nexpected\n%s", strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+
+	markdown := NewMarkdownGenerator(graph).generateTypeHierarchy()
+	for _, want := range []string{"## 🧬 Type Hierarchy", "- 🏗️ **Animal** (`src/animal.ts`)", "  - 🏗️ **Dog** *extends* (`src/dog.ts`)", "    - 🏗️ **Puppy** *extends*"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("type hierarchy section missing %q:\n%s", want, markdown)
+		}
+	}
+}
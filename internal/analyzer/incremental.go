@@ -321,6 +321,31 @@ func (ia *IncrementalAnalyzer) processFileAdded(ctx context.Context, change File
 		return fmt.Errorf("failed to extract imports: %w", err)
 	}
 
+	calls, err := ia.parser.ExtractCalls(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract calls: %w", err)
+	}
+
+	inheritance, err := ia.parser.ExtractInheritance(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract inheritance: %w", err)
+	}
+
+	typeDefinitions, err := ia.parser.ExtractTypeDefinitions(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract type definitions: %w", err)
+	}
+
+	methods, err := ia.parser.ExtractMethods(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract methods: %w", err)
+	}
+
+	annotations, err := ia.parser.ExtractAnnotations(ast)
+	if err != nil {
+		return fmt.Errorf("failed to extract annotations: %w", err)
+	}
+
 	// Create file node
 	fileNode := &types.FileNode{
 		Path:         change.Path,
@@ -334,6 +359,13 @@ func (ia *IncrementalAnalyzer) processFileAdded(ctx context.Context, change File
 		LastModified: time.Now(),
 		Symbols:      make([]types.SymbolId, 0, len(symbols)),
 		Imports:      imports,
+		Calls:        calls,
+		Inheritance:  inheritance,
+		Types:        typeDefinitions,
+		Methods:      methods,
+		Annotations:  annotations,
+		Metrics:      parser.SummarizeMetrics(symbols),
+		Root:         rootOf(ia.vge.GetActualGraph(), change.Path),
 	}
 
 	// Create VGE change set for file addition
@@ -420,6 +452,31 @@ func (ia *IncrementalAnalyzer) processFileModified(ctx context.Context, change F
 		return fmt.Errorf("failed to extract imports: %w", err)
 	}
 
+	calls, err := ia.parser.ExtractCalls(newAST)
+	if err != nil {
+		return fmt.Errorf("failed to extract calls: %w", err)
+	}
+
+	inheritance, err := ia.parser.ExtractInheritance(newAST)
+	if err != nil {
+		return fmt.Errorf("failed to extract inheritance: %w", err)
+	}
+
+	typeDefinitions, err := ia.parser.ExtractTypeDefinitions(newAST)
+	if err != nil {
+		return fmt.Errorf("failed to extract type definitions: %w", err)
+	}
+
+	methods, err := ia.parser.ExtractMethods(newAST)
+	if err != nil {
+		return fmt.Errorf("failed to extract methods: %w", err)
+	}
+
+	annotations, err := ia.parser.ExtractAnnotations(newAST)
+	if err != nil {
+		return fmt.Errorf("failed to extract annotations: %w", err)
+	}
+
 	// Create updated file node
 	fileNode := &types.FileNode{
 		Path:         change.Path,
@@ -433,6 +490,13 @@ func (ia *IncrementalAnalyzer) processFileModified(ctx context.Context, change F
 		LastModified: time.Now(),
 		Symbols:      make([]types.SymbolId, 0, len(symbols)),
 		Imports:      imports,
+		Calls:        calls,
+		Inheritance:  inheritance,
+		Types:        typeDefinitions,
+		Methods:      methods,
+		Annotations:  annotations,
+		Metrics:      parser.SummarizeMetrics(symbols),
+		Root:         rootOf(ia.vge.GetActualGraph(), change.Path),
 	}
 
 	// Create VGE change set for file modification
@@ -338,6 +338,46 @@ export class UserService {
 	}
 }
 
+func TestIncrementalAnalyzer_ModifiedFileAnnotations(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "store.ts")
+	if err := os.WriteFile(testFile, []byte("export function load() {}\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	analyzer, err := NewIncrementalAnalyzer(tempDir, DefaultIncrementalConfig())
+	if err != nil {
+		t.Fatalf("NewIncrementalAnalyzer() error = %v", err)
+	}
+	if err := analyzer.Initialize(createTestCodeGraphWithFiles(testFile, filepath.Join(tempDir, "other.ts"))); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	content := "// TODO(ana): cache the result\nexport function load() {}\n"
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to modify test file: %v", err)
+	}
+
+	result, err := analyzer.AnalyzeChanges(context.Background(), []string{testFile})
+	if err != nil {
+		t.Fatalf("AnalyzeChanges() error = %v", err)
+	}
+	if len(result.ProcessedChanges) != 1 || result.ProcessedChanges[0].Type != ChangeTypeModified {
+		t.Fatalf("Expected one modified change, got %+v (errors %v)", result.ProcessedChanges, result.Errors)
+	}
+
+	fileNode := result.UpdatedGraph.Files[testFile]
+	if fileNode == nil {
+		t.Fatal("Expected the modified file in the updated graph")
+	}
+	if len(fileNode.Annotations) != 1 {
+		t.Fatalf("Expected the TODO to survive the update, got %+v", fileNode.Annotations)
+	}
+	if annotation := fileNode.Annotations[0]; annotation.Kind != "TODO" || annotation.Author != "ana" || annotation.Location.Line != 1 {
+		t.Errorf("Unexpected annotation %+v", annotation)
+	}
+}
+
 func TestChangeTypes(t *testing.T) {
 	tests := []struct {
 		changeType ChangeType
@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// javaSourceRoot is a directory whose subdirectories mirror Java package names
+type javaSourceRoot struct {
+	Dir       string // Root directory, e.g. service/src/main/java
+	Module    string // Maven/Gradle module directory the root belongs to
+	SourceSet string // Source set name such as main or test
+}
+
+// javaModuleResolver maps Java imports onto files using Maven/Gradle source set conventions
+type javaModuleResolver struct {
+	ra    *RelationshipAnalyzer
+	roots []*javaSourceRoot
+}
+
+// newJavaModuleResolver creates a resolver for Java imports in the analyzer's graph
+func newJavaModuleResolver(ra *RelationshipAnalyzer) *javaModuleResolver {
+	return &javaModuleResolver{ra: ra}
+}
+
+// javaStdlibPrefixes lists package prefixes provided by the JDK
+var javaStdlibPrefixes = []string{"java.", "javax.", "jdk.", "sun.", "com.sun.", "org.w3c.dom.", "org.xml.sax.", "org.ietf.jgss."}
+
+// Resolve resolves a Java import. Single-type imports map to the class file; static and nested
+// class imports fall back to the enclosing class, and on-demand imports map to the package directory.
+func (jr *javaModuleResolver) Resolve(importPath string, specifiers []string, fromFile string) *ImportResolution {
+	parts := strings.Split(importPath, ".")
+	onDemand := len(specifiers) == 1 && specifiers[0] == "*"
+
+	for _, root := range jr.rootsFor(fromFile) {
+		base := filepath.Join(append([]string{root.Dir}, parts...)...)
+
+		if onDemand {
+			if files := jr.ra.filesInDir(base, "java", nil); len(files) > 0 {
+				return &ImportResolution{Files: files, Package: base}
+			}
+		}
+
+		// import a.b.C, import a.b.C.Inner and import static a.b.C.member all live in a/b/C.java
+		for n := len(parts); n > 0; n-- {
+			candidate := filepath.Join(append([]string{root.Dir}, parts[:n]...)...) + ".java"
+			if _, exists := jr.ra.graph.Files[candidate]; exists {
+				return &ImportResolution{Files: []string{candidate}}
+			}
+		}
+	}
+
+	resolution := &ImportResolution{External: true, Module: javaPackageName(parts, onDemand)}
+	for _, prefix := range javaStdlibPrefixes {
+		if strings.HasPrefix(importPath+".", prefix) {
+			resolution.Stdlib = true
+			break
+		}
+	}
+	return resolution
+}
+
+// rootsFor orders source roots for fromFile: its own source set, then its module, then the rest
+func (jr *javaModuleResolver) rootsFor(fromFile string) []*javaSourceRoot {
+	jr.collectRoots()
+
+	own := javaRootOf(fromFile)
+	rank := func(root *javaSourceRoot) int {
+		switch {
+		case own == nil:
+			return 2
+		case root.Dir == own.Dir:
+			return 0
+		case root.Module == own.Module:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	roots := make([]*javaSourceRoot, len(jr.roots))
+	copy(roots, jr.roots)
+	sort.SliceStable(roots, func(i, j int) bool {
+		return rank(roots[i]) < rank(roots[j])
+	})
+	return roots
+}
+
+// collectRoots finds the source roots of all Java files in the graph
+func (jr *javaModuleResolver) collectRoots() {
+	if jr.roots != nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	jr.roots = make([]*javaSourceRoot, 0)
+	for filePath, fileNode := range jr.ra.graph.Files {
+		if fileNode.Language != "java" {
+			continue
+		}
+		root := javaRootOf(filePath)
+		if root == nil || seen[root.Dir] {
+			continue
+		}
+		seen[root.Dir] = true
+		jr.roots = append(jr.roots, root)
+	}
+
+	// Files outside the source set layout are resolved against the project directory
+	if metadata := jr.ra.graph.Metadata; metadata != nil && metadata.ProjectPath != "" && !seen[metadata.ProjectPath] {
+		jr.roots = append(jr.roots, &javaSourceRoot{Dir: metadata.ProjectPath, Module: metadata.ProjectPath})
+	}
+	if metadata := jr.ra.graph.Metadata; metadata != nil {
+		for _, root := range metadata.Roots {
+			if !seen[root.Path] && root.Path != metadata.ProjectPath {
+				seen[root.Path] = true
+				jr.roots = append(jr.roots, &javaSourceRoot{Dir: root.Path, Module: root.Path})
+			}
+		}
+	}
+
+	sort.Slice(jr.roots, func(i, j int) bool {
+		return jr.roots[i].Dir < jr.roots[j].Dir
+	})
+}
+
+// javaRootOf returns the src/<set>/java root containing filePath, or nil outside that layout
+func javaRootOf(filePath string) *javaSourceRoot {
+	segments := strings.Split(filepath.ToSlash(filePath), "/")
+	for i := len(segments) - 3; i >= 0; i-- {
+		if segments[i] != "src" || segments[i+2] != "java" || i+3 >= len(segments) {
+			continue
+		}
+		module := filepath.FromSlash(strings.Join(segments[:i], "/"))
+		if module == "" && strings.HasPrefix(filePath, string(filepath.Separator)) {
+			module = string(filepath.Separator)
+		}
+		return &javaSourceRoot{
+			Dir:       filepath.FromSlash(strings.Join(segments[:i+3], "/")),
+			Module:    module,
+			SourceSet: segments[i+1],
+		}
+	}
+	return nil
+}
+
+// javaPackageName strips class and member names from an imported name
+func javaPackageName(parts []string, onDemand bool) string {
+	if onDemand {
+		return strings.Join(parts, ".")
+	}
+	for i, part := range parts {
+		if part != "" && unicode.IsUpper(rune(part[0])) {
+			return strings.Join(parts[:i], ".")
+		}
+	}
+	return strings.Join(parts[:len(parts)-1], ".")
+}
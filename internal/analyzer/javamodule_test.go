@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestJavaSourceSetResolution(t *testing.T) {
+	dir := t.TempDir()
+	graph := newEmptyTestGraph()
+
+	mainRoot := filepath.Join(dir, "service", "src", "main", "java")
+	testRoot := filepath.Join(dir, "service", "src", "test", "java")
+	commonRoot := filepath.Join(dir, "common", "src", "main", "java")
+
+	app := filepath.Join(mainRoot, "com", "acme", "app", "App.java")
+	appTest := filepath.Join(testRoot, "com", "acme", "app", "AppTest.java")
+	bar := filepath.Join(mainRoot, "com", "acme", "foo", "Bar.java")
+	strs := filepath.Join(commonRoot, "com", "acme", "util", "Strings.java")
+	ids := filepath.Join(commonRoot, "com", "acme", "util", "Ids.java")
+	for _, file := range []string{app, appTest, bar, strs, ids} {
+		addTestFile(graph, file, "java")
+	}
+
+	analyzer := NewRelationshipAnalyzer(graph)
+
+	tests := []struct {
+		name       string
+		fromFile   string
+		importPath string
+		specifiers []string
+		files      []string
+		pkg        string
+		external   bool
+		stdlib     bool
+	}{
+		{"class in same source set", app, "com.acme.foo.Bar", nil, []string{bar}, "", false, false},
+		{"class in another module", app, "com.acme.util.Strings", nil, []string{strs}, "", false, false},
+		{"static member", app, "com.acme.util.Strings.trim", nil, []string{strs}, "", false, false},
+		{"nested class", app, "com.acme.foo.Bar.Builder", nil, []string{bar}, "", false, false},
+		{"on-demand package", app, "com.acme.util", []string{"*"}, []string{ids, strs}, filepath.Join(commonRoot, "com", "acme", "util"), false, false},
+		{"main class from test source set", appTest, "com.acme.app.App", nil, []string{app}, "", false, false},
+		{"jdk", app, "java.util.List", nil, nil, "", true, true},
+		{"third party", app, "org.junit.jupiter.api.Test", nil, nil, "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution := analyzer.resolveImport(&types.Import{Path: tt.importPath, Specifiers: tt.specifiers}, tt.fromFile)
+
+			if !reflect.DeepEqual(resolution.Files, tt.files) {
+				t.Errorf("Files = %v, expected %v", resolution.Files, tt.files)
+			}
+			if resolution.Package != tt.pkg {
+				t.Errorf("Package = %q, expected %q", resolution.Package, tt.pkg)
+			}
+			if resolution.External != tt.external || resolution.Stdlib != tt.stdlib {
+				t.Errorf("External/Stdlib = %v/%v, expected %v/%v", resolution.External, resolution.Stdlib, tt.external, tt.stdlib)
+			}
+		})
+	}
+
+	if module := analyzer.resolveImport(&types.Import{Path: "org.junit.jupiter.api.Test"}, app).Module; module != "org.junit.jupiter.api" {
+		t.Errorf("external module = %q, expected org.junit.jupiter.api", module)
+	}
+}
+
+func TestJavaRootOf(t *testing.T) {
+	root := javaRootOf(filepath.Join("repo", "api", "src", "integrationTest", "java", "com", "acme", "ApiIT.java"))
+	if root == nil {
+		t.Fatal("expected a source root for a Gradle source set")
+	}
+	if root.Dir != filepath.Join("repo", "api", "src", "integrationTest", "java") || root.Module != filepath.Join("repo", "api") || root.SourceSet != "integrationTest" {
+		t.Errorf("javaRootOf() = %+v", root)
+	}
+
+	if javaRootOf(filepath.Join("repo", "Main.java")) != nil {
+		t.Error("expected no source root outside the src/<set>/java layout")
+	}
+}
@@ -0,0 +1,505 @@
+package analyzer
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Dependency ecosystems
+const (
+	EcosystemGo    = "go"
+	EcosystemNpm   = "npm"
+	EcosystemPyPI  = "pypi"
+	EcosystemCargo = "cargo"
+	EcosystemMaven = "maven"
+)
+
+// declaredDependency is a dependency as a manifest declares it
+type declaredDependency struct {
+	Name    string
+	Version string
+	Dev     bool
+}
+
+// manifestFormat parses one kind of manifest and names the lockfiles that pin its versions
+type manifestFormat struct {
+	Ecosystem string
+	Parse     func(path string) []declaredDependency
+	Lockfiles []lockfileFormat // In order of preference when a directory has several
+}
+
+// lockfileFormat names a lockfile and parses it into package names and their locked versions
+type lockfileFormat struct {
+	Name  string
+	Parse func(path string) map[string][]string
+}
+
+// manifestFormats maps manifest file names to their format
+var manifestFormats = map[string]manifestFormat{
+	"go.mod": {Ecosystem: EcosystemGo, Parse: parseGoModDependencies},
+	"package.json": {Ecosystem: EcosystemNpm, Parse: parsePackageJSONDependencies, Lockfiles: []lockfileFormat{
+		{Name: "package-lock.json", Parse: parsePackageLock},
+		{Name: "yarn.lock", Parse: parseYarnLock},
+		{Name: "pnpm-lock.yaml", Parse: parsePnpmLock},
+	}},
+	"requirements.txt": {Ecosystem: EcosystemPyPI, Parse: parseRequirementsDependencies},
+	"pyproject.toml": {Ecosystem: EcosystemPyPI, Parse: parsePyprojectDependencies, Lockfiles: []lockfileFormat{
+		{Name: "poetry.lock", Parse: parseTomlPackageLock},
+		{Name: "uv.lock", Parse: parseTomlPackageLock},
+	}},
+	"Cargo.toml": {Ecosystem: EcosystemCargo, Parse: parseCargoDependencies, Lockfiles: []lockfileFormat{
+		{Name: "Cargo.lock", Parse: parseTomlPackageLock},
+	}},
+	"pom.xml":          {Ecosystem: EcosystemMaven, Parse: parsePomDependencies},
+	"build.gradle":     {Ecosystem: EcosystemMaven, Parse: parseGradleDependencies, Lockfiles: []lockfileFormat{{Name: "gradle.lockfile", Parse: parseGradleLock}}},
+	"build.gradle.kts": {Ecosystem: EcosystemMaven, Parse: parseGradleDependencies, Lockfiles: []lockfileFormat{{Name: "gradle.lockfile", Parse: parseGradleLock}}},
+}
+
+var (
+	// requirementPattern splits a PEP 508 requirement into name and version specifier
+	requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+	// tomlStringPattern matches basic and literal TOML strings
+	tomlStringPattern = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+	// gradleDependencyPattern matches configuration("group:artifact:version") and configuration 'group:artifact:version'
+	gradleDependencyPattern = regexp.MustCompile(`(?m)^\s*(\w+)\s*\(?\s*["']([^"':\s]+):([^"':\s]+)(?::([^"'@\s]+))?[^"']*["']`)
+	// pomPropertyPattern matches ${property} references in pom.xml values
+	pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+)
+
+// gradleConfigurations lists the Gradle configurations that declare library dependencies; the
+// test ones are development dependencies
+var gradleConfigurations = map[string]bool{
+	"implementation": false, "api": false, "compile": false, "compileOnly": false, "runtimeOnly": false,
+	"runtime": false, "annotationProcessor": false, "kapt": false,
+	"testImplementation": true, "testCompileOnly": true, "testRuntimeOnly": true, "testCompile": true,
+	"androidTestImplementation": true, "testAnnotationProcessor": true,
+}
+
+// parseGoModDependencies lists the modules a go.mod requires
+func parseGoModDependencies(path string) []declaredDependency {
+	module, err := parseGoMod(path)
+	if err != nil {
+		return nil
+	}
+	dependencies := make([]declaredDependency, 0, len(module.Requires))
+	for name, version := range module.Requires {
+		dependencies = append(dependencies, declaredDependency{Name: name, Version: version})
+	}
+	return dependencies
+}
+
+// parsePackageJSONDependencies lists the dependencies of a package.json; devDependencies are dev
+func parsePackageJSONDependencies(path string) []declaredDependency {
+	pkg, err := parsePackageJSON(path)
+	if err != nil {
+		return nil
+	}
+	dependencies := make([]declaredDependency, 0)
+	seen := make(map[string]bool)
+	for i, declared := range []map[string]string{pkg.Dependencies, pkg.PeerDependencies, pkg.OptionalDependencies, pkg.DevDependencies} {
+		for name, version := range declared {
+			if seen[name] || strings.HasPrefix(version, "workspace:") {
+				continue
+			}
+			seen[name] = true
+			dependencies = append(dependencies, declaredDependency{Name: name, Version: version, Dev: i == 3})
+		}
+	}
+	return dependencies
+}
+
+// parseRequirementsDependencies lists the requirements of a pip requirements file, skipping options,
+// includes and editable installs
+func parseRequirementsDependencies(path string) []declaredDependency {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	base := strings.ToLower(filepath.Base(path))
+	dev := strings.Contains(base, "dev") || strings.Contains(base, "test")
+
+	dependencies := make([]declaredDependency, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if index := strings.Index(line, " #"); index != -1 {
+			line = strings.TrimSpace(line[:index])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if dependency, ok := parseRequirement(line); ok {
+			dependency.Dev = dev
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies
+}
+
+// parseRequirement parses a PEP 508 requirement such as requests[socks]>=2.31; python_version>"3.8"
+func parseRequirement(requirement string) (declaredDependency, bool) {
+	requirement, _, _ = strings.Cut(requirement, ";")
+	match := requirementPattern.FindStringSubmatch(strings.TrimSpace(requirement))
+	if match == nil || strings.Contains(match[2], "://") && !strings.HasPrefix(match[2], "@") {
+		return declaredDependency{}, false
+	}
+	return declaredDependency{Name: match[1], Version: strings.TrimSpace(match[2])}, true
+}
+
+// parsePyprojectDependencies lists PEP 621 dependencies, optional dependencies, PEP 735 dependency
+// groups and Poetry dependencies. Groups and extras named for development or tests are dev.
+func parsePyprojectDependencies(path string) []declaredDependency {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	dependencies := make([]declaredDependency, 0)
+	isDevGroup := func(name string) bool {
+		name = strings.ToLower(name)
+		return strings.Contains(name, "dev") || strings.Contains(name, "test") || strings.Contains(name, "lint")
+	}
+
+	section := ""
+	var array strings.Builder // Multi-line array being collected
+	arrayDev, inArray := false, false
+	flushArray := func() {
+		for _, match := range tomlStringPattern.FindAllStringSubmatch(array.String(), -1) {
+			if dependency, ok := parseRequirement(match[1] + match[2]); ok {
+				dependency.Dev = arrayDev
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		array.Reset()
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripTomlComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if inArray {
+			array.WriteString(line)
+			if strings.Contains(line, "]") {
+				inArray = false
+				flushArray()
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.Trim(strings.TrimSpace(key), `"'`), strings.TrimSpace(value)
+
+		switch {
+		case section == "project" && key == "dependencies",
+			section == "project.optional-dependencies",
+			section == "dependency-groups":
+			arrayDev = section != "project" && isDevGroup(key)
+			array.WriteString(value)
+			if strings.HasPrefix(value, "[") && !strings.Contains(value, "]") {
+				inArray = true
+			} else {
+				flushArray()
+			}
+		case section == "tool.poetry.dependencies", section == "tool.poetry.dev-dependencies",
+			strings.HasPrefix(section, "tool.poetry.group.") && strings.HasSuffix(section, ".dependencies"):
+			if key == "python" {
+				continue
+			}
+			version := ""
+			if strings.HasPrefix(value, "{") {
+				for _, match := range cargoInlineKeyPattern.FindAllStringSubmatch(value, -1) {
+					if match[1] == "version" {
+						version = match[2]
+					}
+				}
+			} else if match := tomlStringPattern.FindStringSubmatch(value); match != nil {
+				version = match[1] + match[2]
+			}
+			dependencies = append(dependencies, declaredDependency{Name: key, Version: version, Dev: section != "tool.poetry.dependencies"})
+		}
+	}
+	return dependencies
+}
+
+// parseCargoDependencies lists the dependencies of a Cargo.toml, excluding local path dependencies
+func parseCargoDependencies(path string) []declaredDependency {
+	manifest, err := parseCargoToml(path)
+	if err != nil {
+		return nil
+	}
+	dependencies := make([]declaredDependency, 0, len(manifest.Dependencies))
+	for name, version := range manifest.Dependencies {
+		if _, local := manifest.PathDeps[name]; local {
+			continue
+		}
+		dependencies = append(dependencies, declaredDependency{Name: name, Version: version})
+	}
+	return dependencies
+}
+
+// pomProject describes the parts of a pom.xml that declare dependencies
+type pomProject struct {
+	Version    string `xml:"version"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies []struct {
+		GroupId    string `xml:"groupId"`
+		ArtifactId string `xml:"artifactId"`
+		Version    string `xml:"version"`
+		Scope      string `xml:"scope"`
+	} `xml:"dependencies>dependency"`
+}
+
+// parsePomDependencies lists the dependencies of a pom.xml as group:artifact, with ${property}
+// versions substituted; test-scoped dependencies are dev
+func parsePomDependencies(path string) []declaredDependency {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var project pomProject
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return nil
+	}
+
+	properties := map[string]string{"project.version": project.Version}
+	for _, entry := range project.Properties.Entries {
+		properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+	substitute := func(value string) string {
+		return pomPropertyPattern.ReplaceAllStringFunc(strings.TrimSpace(value), func(reference string) string {
+			if resolved, ok := properties[reference[2:len(reference)-1]]; ok {
+				return resolved
+			}
+			return reference
+		})
+	}
+
+	dependencies := make([]declaredDependency, 0, len(project.Dependencies))
+	for _, dependency := range project.Dependencies {
+		dependencies = append(dependencies, declaredDependency{
+			Name:    strings.TrimSpace(dependency.GroupId) + ":" + strings.TrimSpace(dependency.ArtifactId),
+			Version: substitute(dependency.Version),
+			Dev:     strings.TrimSpace(dependency.Scope) == "test",
+		})
+	}
+	return dependencies
+}
+
+// parseGradleDependencies lists the group:artifact:version dependencies of a Groovy or Kotlin build script
+func parseGradleDependencies(path string) []declaredDependency {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	dependencies := make([]declaredDependency, 0)
+	for _, match := range gradleDependencyPattern.FindAllStringSubmatch(string(data), -1) {
+		dev, known := gradleConfigurations[match[1]]
+		if !known {
+			continue
+		}
+		dependencies = append(dependencies, declaredDependency{Name: match[2] + ":" + match[3], Version: match[4], Dev: dev})
+	}
+	return dependencies
+}
+
+// parsePackageLock reads the versions installed at the top of node_modules from a package-lock.json
+func parsePackageLock(path string) map[string][]string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var lock struct {
+		Packages map[string]struct {
+			Version string `json:"version"`
+		} `json:"packages"`
+		Dependencies map[string]struct {
+			Version string `json:"version"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil
+	}
+
+	versions := make(map[string][]string)
+	for key, entry := range lock.Packages {
+		name := strings.TrimPrefix(key, "node_modules/")
+		if name == key || strings.Contains(name, "/node_modules/") || entry.Version == "" {
+			continue
+		}
+		versions[name] = []string{entry.Version}
+	}
+	// Lockfile version 1 lists dependencies instead of packages
+	for name, entry := range lock.Dependencies {
+		if _, exists := versions[name]; !exists && entry.Version != "" {
+			versions[name] = []string{entry.Version}
+		}
+	}
+	return versions
+}
+
+// parseYarnLock reads the resolved versions of a classic or berry yarn.lock
+func parseYarnLock(path string) map[string][]string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	versions := make(map[string][]string)
+	current := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case !strings.HasPrefix(line, " ") && strings.HasSuffix(trimmed, ":"):
+			// "@scope/name@^1.0.0", name@npm:^2: the name precedes the last @ of the first specifier
+			specifier := strings.Trim(strings.TrimSpace(strings.Split(strings.TrimSuffix(trimmed, ":"), ",")[0]), `"`)
+			current = ""
+			if at := strings.LastIndex(specifier, "@"); at > 0 {
+				current = specifier[:at]
+			}
+		case current != "" && strings.HasPrefix(trimmed, "version"):
+			version := strings.Trim(strings.TrimSpace(strings.TrimLeft(strings.TrimPrefix(trimmed, "version"), ": ")), `"`)
+			versions[current] = appendVersion(versions[current], version)
+			current = ""
+		}
+	}
+	return versions
+}
+
+// parsePnpmLock reads the package versions listed under packages in a pnpm-lock.yaml, in the
+// /name@version, name@version and older /name/version key forms
+func parsePnpmLock(path string) map[string][]string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	versions := make(map[string][]string)
+	inPackages := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			inPackages = strings.TrimSpace(line) == "packages:"
+			continue
+		}
+		if !inPackages || strings.HasPrefix(line, "   ") || !strings.HasSuffix(line, ":") {
+			continue
+		}
+		key := strings.Trim(strings.TrimSuffix(strings.TrimSpace(line), ":"), `'"`)
+		key, _, _ = strings.Cut(key, "(") // Peer dependency suffixes
+		key = strings.TrimPrefix(key, "/")
+
+		separator := strings.LastIndex(key, "@")
+		if separator <= 0 {
+			separator = strings.LastIndex(key, "/")
+		}
+		if separator <= 0 {
+			continue
+		}
+		versions[key[:separator]] = appendVersion(versions[key[:separator]], key[separator+1:])
+	}
+	return versions
+}
+
+// parseTomlPackageLock reads the [[package]] name and version pairs of a Cargo.lock, poetry.lock or uv.lock
+func parseTomlPackageLock(path string) map[string][]string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	versions := make(map[string][]string)
+	name, inPackage := "", false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inPackage, name = line == "[[package]]", ""
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !inPackage || !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "name":
+			name = value
+		case "version":
+			if name != "" {
+				versions[name] = appendVersion(versions[name], value)
+			}
+		}
+	}
+	return versions
+}
+
+// parseGradleLock reads group:artifact:version=configurations lines of a gradle.lockfile
+func parseGradleLock(path string) map[string][]string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	versions := make(map[string][]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		coordinates, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		parts := strings.Split(coordinates, ":")
+		if len(parts) != 3 || strings.HasPrefix(coordinates, "#") {
+			continue
+		}
+		name := parts[0] + ":" + parts[1]
+		versions[name] = appendVersion(versions[name], parts[2])
+	}
+	return versions
+}
+
+// appendVersion adds a version to a sorted list of distinct versions
+func appendVersion(versions []string, version string) []string {
+	if version == "" {
+		return versions
+	}
+	for _, existing := range versions {
+		if existing == version {
+			return versions
+		}
+	}
+	versions = append(versions, version)
+	sort.Strings(versions)
+	return versions
+}
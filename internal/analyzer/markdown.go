@@ -8,17 +8,57 @@ import (
 	"time"
 
 	"github.com/nuthan-ms/codecontext/internal/git"
+	"github.com/nuthan-ms/codecontext/internal/parser"
+	"github.com/nuthan-ms/codecontext/internal/redact"
 	"github.com/nuthan-ms/codecontext/pkg/types"
 )
 
+// maxComplexFunctions caps the complexity table to the functions most worth reviewing before a change
+const maxComplexFunctions = 20
+
+// maxSymbolDetails caps the symbol details table; larger projects list their most central symbols
+const maxSymbolDetails = 50
+
+// maxDependencyDetails caps the third-party dependency table, which lists the most used first
+const maxDependencyDetails = 50
+
+// maxCloneGroups caps the duplicate code section, which lists the groups duplicating the most code first
+const maxCloneGroups = 20
+
 // MarkdownGenerator generates rich markdown content from analyzed code graphs
 type MarkdownGenerator struct {
-	graph *types.CodeGraph
+	graph        *types.CodeGraph
+	deadCode     DeadCodeOptions
+	architecture ArchitectureConfig
+	redactor     *redact.Redactor
+	redactions   []redact.Finding
 }
 
-// NewMarkdownGenerator creates a new markdown generator
+// NewMarkdownGenerator creates a new markdown generator that redacts secrets with the built-in detectors
 func NewMarkdownGenerator(graph *types.CodeGraph) *MarkdownGenerator {
-	return &MarkdownGenerator{graph: graph}
+	// The built-in detectors always compile
+	redactor, _ := redact.New(redact.Config{})
+	return &MarkdownGenerator{graph: graph, redactor: redactor}
+}
+
+// SetDeadCodeOptions sets the allow-lists used by the dead code section
+func (mg *MarkdownGenerator) SetDeadCodeOptions(options DeadCodeOptions) {
+	mg.deadCode = options
+}
+
+// SetArchitecture sets the layer rules checked by the architecture section
+func (mg *MarkdownGenerator) SetArchitecture(config ArchitectureConfig) {
+	mg.architecture = config
+}
+
+// SetRedactor sets the redactor applied to the generated map; nil disables redaction
+func (mg *MarkdownGenerator) SetRedactor(redactor *redact.Redactor) {
+	mg.redactor = redactor
+}
+
+// Redactions returns the values redacted from the last generated map
+func (mg *MarkdownGenerator) Redactions() []redact.Finding {
+	return mg.redactions
 }
 
 // GenerateContextMap generates a comprehensive context map in markdown format
@@ -33,6 +73,24 @@ func (mg *MarkdownGenerator) GenerateContextMap() string {
 	sb.WriteString(mg.generateOverview())
 	sb.WriteString("\n\n")
 
+	// Entry Points
+	sb.WriteString(mg.generateEntryPoints())
+	sb.WriteString("\n\n")
+
+	// API Routes
+	sb.WriteString(mg.generateRoutes())
+	sb.WriteString("\n\n")
+
+	// Deprecated APIs
+	sb.WriteString(mg.generateDeprecations())
+	sb.WriteString("\n\n")
+
+	// Source Roots, only for multi-root analysis
+	if isMultiRoot(mg.graph) {
+		sb.WriteString(mg.generateSourceRoots())
+		sb.WriteString("\n\n")
+	}
+
 	// File Analysis
 	sb.WriteString(mg.generateFileAnalysis())
 	sb.WriteString("\n\n")
@@ -41,6 +99,10 @@ func (mg *MarkdownGenerator) GenerateContextMap() string {
 	sb.WriteString(mg.generateSymbolAnalysis())
 	sb.WriteString("\n\n")
 
+	// Complexity
+	sb.WriteString(mg.generateComplexity())
+	sb.WriteString("\n\n")
+
 	// Language Statistics
 	sb.WriteString(mg.generateLanguageStats())
 	sb.WriteString("\n\n")
@@ -49,14 +111,48 @@ func (mg *MarkdownGenerator) GenerateContextMap() string {
 	sb.WriteString(mg.generateImportAnalysis())
 	sb.WriteString("\n\n")
 
+	// Dependencies
+	sb.WriteString(mg.generateDependencies())
+	sb.WriteString("\n\n")
+
 	// Relationship Analysis
 	sb.WriteString(mg.generateRelationshipAnalysis())
 	sb.WriteString("\n\n")
 
+	// Type Hierarchy
+	sb.WriteString(mg.generateTypeHierarchy())
+	sb.WriteString("\n\n")
+
+	// Dead Code
+	sb.WriteString(mg.generateDeadCode())
+	sb.WriteString("\n\n")
+
+	// Duplicate Code
+	sb.WriteString(mg.generateClones())
+	sb.WriteString("\n\n")
+
+	// Annotations
+	sb.WriteString(mg.generateAnnotations())
+	sb.WriteString("\n\n")
+
+	// Documentation
+	sb.WriteString(mg.generateDocumentation())
+	sb.WriteString("\n\n")
+
+	// Architecture, only when layers are configured
+	if mg.architecture.Enabled() {
+		sb.WriteString(mg.generateArchitecture())
+		sb.WriteString("\n\n")
+	}
+
 	// Semantic Neighborhoods Analysis
 	sb.WriteString(mg.generateSemanticNeighborhoods())
 	sb.WriteString("\n\n")
 
+	// Package Dependencies
+	sb.WriteString(mg.generatePackageDependencies())
+	sb.WriteString("\n\n")
+
 	// Project Structure
 	sb.WriteString(mg.generateProjectStructure())
 	sb.WriteString("\n\n")
@@ -64,7 +160,9 @@ func (mg *MarkdownGenerator) GenerateContextMap() string {
 	// Footer
 	sb.WriteString(mg.generateFooter())
 
-	return sb.String()
+	content, redactions := mg.redactor.Redact("context map", sb.String())
+	mg.redactions = redactions
+	return content
 }
 
 // generateHeader creates the document header
@@ -113,17 +211,21 @@ func (mg *MarkdownGenerator) generateFileAnalysis() string {
 		return sb.String()
 	}
 
-	// Sort files by path for consistent output
+	// Most central files first, by path when equally central
 	files := make([]*types.FileNode, 0, len(mg.graph.Files))
 	for _, file := range mg.graph.Files {
 		files = append(files, file)
 	}
 	sort.Slice(files, func(i, j int) bool {
+		left, right := FileImportance(mg.graph, files[i].Path), FileImportance(mg.graph, files[j].Path)
+		if left != right {
+			return left > right
+		}
 		return files[i].Path < files[j].Path
 	})
 
-	sb.WriteString("| File | Language | Lines | Symbols | Imports | Type |\n")
-	sb.WriteString("|------|----------|-------|---------|---------|------|\n")
+	sb.WriteString("| File | Language | Lines | Symbols | Imports | Type | Centrality |\n")
+	sb.WriteString("|------|----------|-------|---------|---------|------|------------|\n")
 
 	for _, file := range files {
 		fileType := "source"
@@ -133,13 +235,14 @@ func (mg *MarkdownGenerator) generateFileAnalysis() string {
 			fileType = "generated"
 		}
 
-		sb.WriteString(fmt.Sprintf("| `%s` | %s | %d | %d | %d | %s |\n",
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %d | %d | %d | %s | %.2f |\n",
 			file.Path,
 			file.Language,
 			file.Lines,
 			file.SymbolCount,
 			file.ImportCount,
-			fileType))
+			fileType,
+			FileImportance(mg.graph, file.Path)))
 	}
 
 	return sb.String()
@@ -168,23 +271,33 @@ func (mg *MarkdownGenerator) generateSymbolAnalysis() string {
 		sb.WriteString(fmt.Sprintf("- %s **%s**: %d\n", icon, symbolType, count))
 	}
 
-	// Show detailed symbol list for smaller projects
-	if len(mg.graph.Symbols) <= 50 {
+	// Show the most central symbols, all of them for smaller projects
+	if len(mg.graph.Symbols) > 0 {
 		sb.WriteString("\n### Symbol Details\n\n")
+		if len(mg.graph.Symbols) > maxSymbolDetails {
+			sb.WriteString(fmt.Sprintf("Top %d of %d symbols by centrality:\n\n", maxSymbolDetails, len(mg.graph.Symbols)))
+		}
 		sb.WriteString("| Symbol | Type | File | Line | Signature |\n")
 		sb.WriteString("|--------|------|------|------|----------|\n")
 
-		// Sort symbols by file and line
+		// Sort symbols by centrality, then by file and line
 		symbols := make([]*types.Symbol, 0, len(mg.graph.Symbols))
 		for _, symbol := range mg.graph.Symbols {
 			symbols = append(symbols, symbol)
 		}
 		sort.Slice(symbols, func(i, j int) bool {
+			left, right := SymbolImportance(mg.graph, symbols[i].Id), SymbolImportance(mg.graph, symbols[j].Id)
+			if left != right {
+				return left > right
+			}
 			if symbols[i].FullyQualifiedName != symbols[j].FullyQualifiedName {
 				return symbols[i].FullyQualifiedName < symbols[j].FullyQualifiedName
 			}
 			return symbols[i].Location.StartLine < symbols[j].Location.StartLine
 		})
+		if len(symbols) > maxSymbolDetails {
+			symbols = symbols[:maxSymbolDetails]
+		}
 
 		for _, symbol := range symbols {
 			signature := symbol.Signature
@@ -204,6 +317,68 @@ func (mg *MarkdownGenerator) generateSymbolAnalysis() string {
 	return sb.String()
 }
 
+// generateComplexity creates the complexity section listing the functions and methods that are
+// hardest to understand, by cognitive and then cyclomatic complexity
+func (mg *MarkdownGenerator) generateComplexity() string {
+	var sb strings.Builder
+	sb.WriteString("## 🧮 Complexity\n\n")
+
+	type measuredFunction struct {
+		symbol *types.Symbol
+		file   string
+	}
+	functions := make([]measuredFunction, 0)
+	complex := 0
+	for filePath, file := range mg.graph.Files {
+		for _, symbolId := range file.Symbols {
+			symbol := mg.graph.Symbols[symbolId]
+			if symbol == nil || symbol.Metrics == nil {
+				continue
+			}
+			functions = append(functions, measuredFunction{symbol: symbol, file: filePath})
+			if symbol.Metrics.Cognitive > parser.ComplexCognitiveThreshold {
+				complex++
+			}
+		}
+	}
+	if len(functions) == 0 {
+		sb.WriteString("*No functions measured.*\n")
+		return sb.String()
+	}
+
+	sort.Slice(functions, func(i, j int) bool {
+		left, right := functions[i].symbol.Metrics, functions[j].symbol.Metrics
+		if left.Cognitive != right.Cognitive {
+			return left.Cognitive > right.Cognitive
+		}
+		if left.Cyclomatic != right.Cyclomatic {
+			return left.Cyclomatic > right.Cyclomatic
+		}
+		return functions[i].symbol.Id < functions[j].symbol.Id
+	})
+	if len(functions) > maxComplexFunctions {
+		functions = functions[:maxComplexFunctions]
+	}
+
+	sb.WriteString(fmt.Sprintf("%d functions exceed a cognitive complexity of %d.\n\n", complex, parser.ComplexCognitiveThreshold))
+	sb.WriteString("| Function | File | Line | Lines | Cyclomatic | Cognitive | Nesting | Params |\n")
+	sb.WriteString("|----------|------|------|-------|------------|-----------|---------|--------|\n")
+	for _, function := range functions {
+		metrics := function.symbol.Metrics
+		sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %d | %d | %d | %d | %d | %d |\n",
+			function.symbol.Name,
+			function.file,
+			function.symbol.Location.StartLine,
+			metrics.Lines,
+			metrics.Cyclomatic,
+			metrics.Cognitive,
+			metrics.MaxNesting,
+			metrics.Parameters))
+	}
+
+	return sb.String()
+}
+
 // generateLanguageStats creates the language statistics section
 func (mg *MarkdownGenerator) generateLanguageStats() string {
 	var sb strings.Builder
@@ -302,6 +477,57 @@ func (mg *MarkdownGenerator) generateImportAnalysis() string {
 	return sb.String()
 }
 
+// generateDependencies creates the dependencies section listing the third-party libraries declared in
+// manifests and the analyzed files importing each of them, most used first
+func (mg *MarkdownGenerator) generateDependencies() string {
+	var sb strings.Builder
+	sb.WriteString("## 🧩 Dependencies\n\n")
+
+	dependencies := Dependencies(mg.graph)
+	if len(dependencies) == 0 {
+		sb.WriteString("*No dependency manifests found.*\n")
+		return sb.String()
+	}
+
+	manifests := make(map[string]bool)
+	imported := 0
+	for _, dependency := range dependencies {
+		manifests[dependency.Manifest] = true
+		if len(dependency.Files) > 0 {
+			imported++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("%d third-party dependencies declared in %d manifests, %d imported by analyzed files.\n\n",
+		len(dependencies), len(manifests), imported))
+
+	sb.WriteString("| Dependency | Ecosystem | Version | Locked | Used By |\n")
+	sb.WriteString("|------------|-----------|---------|--------|---------|\n")
+	for i, dependency := range dependencies {
+		if i >= maxDependencyDetails {
+			break
+		}
+		name := fmt.Sprintf("`%s`", dependency.Name)
+		if dependency.Dev {
+			name += " (dev)"
+		}
+		usedBy := make([]string, 0, 3)
+		for j, filePath := range dependency.Files {
+			if j == 3 {
+				usedBy = append(usedBy, fmt.Sprintf("+%d more", len(dependency.Files)-3))
+				break
+			}
+			usedBy = append(usedBy, fmt.Sprintf("`%s`", filePath))
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			name, dependency.Ecosystem, dependency.Version, dependency.Locked, strings.Join(usedBy, ", ")))
+	}
+	if len(dependencies) > maxDependencyDetails {
+		sb.WriteString(fmt.Sprintf("\n*Top %d of %d dependencies by usage.*\n", maxDependencyDetails, len(dependencies)))
+	}
+
+	return sb.String()
+}
+
 // generateRelationshipAnalysis creates the relationship analysis section
 func (mg *MarkdownGenerator) generateRelationshipAnalysis() string {
 	var sb strings.Builder
@@ -349,13 +575,23 @@ func (mg *MarkdownGenerator) generateRelationshipAnalysis() string {
 	// Circular dependencies
 	if len(metrics.CircularDeps) > 0 {
 		sb.WriteString("### ⚠️ Circular Dependencies\n\n")
-		sb.WriteString(fmt.Sprintf("Found %d circular dependencies:\n\n", len(metrics.CircularDeps)))
+		sb.WriteString(fmt.Sprintf("Found %d circular dependency groups:\n\n", len(metrics.CircularDeps)))
 
 		for i, dep := range metrics.CircularDeps {
-			sb.WriteString(fmt.Sprintf("**Circular Dependency %d** (%s):\n", i+1, dep.Type))
+			sb.WriteString(fmt.Sprintf("**Circular Dependency %d** (%s, %d members, %d cycles):\n", i+1, dep.Type, len(dep.Files), len(dep.Cycles)))
+			sb.WriteString("```\n")
+			for _, cycle := range dep.Cycles {
+				sb.WriteString(strings.Join(cycle, " → "))
+				sb.WriteString("\n")
+			}
 			sb.WriteString("```\n")
-			sb.WriteString(strings.Join(dep.Path, " → "))
-			sb.WriteString("\n```\n\n")
+			if len(dep.SuggestedBreaks) > 0 {
+				sb.WriteString("Suggested edges to break:\n")
+				for _, link := range dep.SuggestedBreaks {
+					sb.WriteString(fmt.Sprintf("- `%s` → `%s`\n", link.From, link.To))
+				}
+			}
+			sb.WriteString("\n")
 		}
 	} else {
 		sb.WriteString("### ✅ No Circular Dependencies\n\n")
@@ -399,6 +635,149 @@ func (mg *MarkdownGenerator) generateRelationshipAnalysis() string {
 	return sb.String()
 }
 
+// generateTypeHierarchy creates the type hierarchy section listing every subtype of each base type
+func (mg *MarkdownGenerator) generateTypeHierarchy() string {
+	var sb strings.Builder
+	sb.WriteString("## 🧬 Type Hierarchy\n\n")
+
+	hierarchy := BuildTypeHierarchy(mg.graph)
+	if len(hierarchy) == 0 {
+		sb.WriteString("*No inheritance relationships found.*\n")
+		return sb.String()
+	}
+
+	for _, root := range hierarchy {
+		mg.writeHierarchyNode(&sb, root, 0)
+	}
+
+	return sb.String()
+}
+
+// generateDeadCode creates the dead code section, listing at most maxSymbolDetails entries per list
+func (mg *MarkdownGenerator) generateDeadCode() string {
+	var sb strings.Builder
+	sb.WriteString("## 🪦 Dead Code\n\n")
+	WriteDeadCodeReport(&sb, FindDeadCode(mg.graph, mg.deadCode), maxSymbolDetails)
+	return sb.String()
+}
+
+// generateClones creates the duplicate code section listing groups of cloned functions
+func (mg *MarkdownGenerator) generateClones() string {
+	var sb strings.Builder
+	sb.WriteString("## 👯 Duplicate Code\n\n")
+	WriteCloneReport(&sb, FindClones(mg.graph, CloneOptions{}), maxCloneGroups)
+	return sb.String()
+}
+
+// generateAnnotations creates the section listing TODO, FIXME, HACK, XXX and @deprecated comments
+func (mg *MarkdownGenerator) generateAnnotations() string {
+	var sb strings.Builder
+	sb.WriteString("## 📝 Annotations\n\n")
+	WriteAnnotationReport(&sb, FindAnnotations(mg.graph, AnnotationFilter{}), maxSymbolDetails)
+	return sb.String()
+}
+
+// generateDocumentation creates the section listing the markdown documents linked to the code
+func (mg *MarkdownGenerator) generateDocumentation() string {
+	var sb strings.Builder
+	sb.WriteString("## 📚 Documentation\n\n")
+	WriteDocumentSummaries(&sb, Documents(mg.graph), maxSymbolDetails)
+	return sb.String()
+}
+
+// generateArchitecture creates the architecture section listing the layer rules and their violations
+func (mg *MarkdownGenerator) generateArchitecture() string {
+	var sb strings.Builder
+	sb.WriteString("## 🏛️ Architecture\n\n")
+
+	if len(mg.architecture.Rules) > 0 {
+		sb.WriteString("**Rules:**\n")
+		for _, rule := range mg.architecture.Rules {
+			sb.WriteString(fmt.Sprintf("- %s\n", rule.Describe()))
+		}
+		sb.WriteString("\n")
+	}
+	WriteLayerViolations(&sb, CheckArchitecture(mg.graph, mg.architecture), maxSymbolDetails)
+	return sb.String()
+}
+
+// WriteLayerViolations writes architecture violations as markdown. A positive limit caps the list.
+func WriteLayerViolations(sb *strings.Builder, violations []LayerViolation, limit int) {
+	if len(violations) == 0 {
+		sb.WriteString("*No layer violations found.*\n")
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("### Layer Violations (%d)\n\n", len(violations)))
+	for i, violation := range violations {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("- *... and %d more*\n", len(violations)-limit))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("- `%s:%d` imports `%s` (%s → %s): %s\n",
+			violation.File, violation.Line, violation.ImportPath, violation.FromLayer, violation.ToLayer, violation.Rule))
+	}
+}
+
+// WriteDeadCodeReport writes a dead code report as markdown. A positive limit caps each list.
+func WriteDeadCodeReport(sb *strings.Builder, report *DeadCodeReport, limit int) {
+	if len(report.UnusedExports) == 0 && len(report.UnimportedFiles) == 0 && len(report.UnreachableFunctions) == 0 {
+		sb.WriteString("*No dead code found.*\n")
+		return
+	}
+
+	writeSymbols := func(title string, symbols []SymbolRef) {
+		if len(symbols) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("### %s (%d)\n\n", title, len(symbols)))
+		for i, symbol := range symbols {
+			if limit > 0 && i >= limit {
+				sb.WriteString(fmt.Sprintf("- *... and %d more*\n", len(symbols)-limit))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- `%s` (%s) in `%s:%d`\n", symbol.Name, symbol.Type, symbol.File, symbol.Line))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeSymbols("Unused Exports", report.UnusedExports)
+	if len(report.UnimportedFiles) > 0 {
+		sb.WriteString(fmt.Sprintf("### Unimported Files (%d)\n\n", len(report.UnimportedFiles)))
+		for i, filePath := range report.UnimportedFiles {
+			if limit > 0 && i >= limit {
+				sb.WriteString(fmt.Sprintf("- *... and %d more*\n", len(report.UnimportedFiles)-limit))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- `%s`\n", filePath))
+		}
+		sb.WriteString("\n")
+	}
+	if len(report.EntryPoints) == 0 {
+		sb.WriteString("*No entry points detected; reachability was not checked.*\n")
+		return
+	}
+	writeSymbols(fmt.Sprintf("Unreachable Functions from %d Entry Points", len(report.EntryPoints)), report.UnreachableFunctions)
+}
+
+// writeHierarchyNode writes a type and its subtypes as a nested list
+func (mg *MarkdownGenerator) writeHierarchyNode(sb *strings.Builder, node *TypeHierarchyNode, depth int) {
+	relation := ""
+	if node.Kind != "" {
+		relation = fmt.Sprintf(" *%s*", node.Kind)
+	}
+	sb.WriteString(fmt.Sprintf("%s- %s **%s**%s (`%s`)\n",
+		strings.Repeat("  ", depth),
+		mg.getSymbolIcon(node.Symbol.Type),
+		node.Symbol.Name,
+		relation,
+		node.File))
+
+	for _, subtype := range node.Subtypes {
+		mg.writeHierarchyNode(sb, subtype, depth+1)
+	}
+}
+
 // getRelationshipDescription returns a description for a relationship type
 func (mg *MarkdownGenerator) getRelationshipDescription(relType RelationshipType) string {
 	switch relType {
@@ -423,6 +802,137 @@ func (mg *MarkdownGenerator) getRelationshipDescription(relType RelationshipType
 	}
 }
 
+// generatePackageDependencies creates the package section: one row per directory with its aggregated
+// metrics and the packages it imports, most central first
+func (mg *MarkdownGenerator) generatePackageDependencies() string {
+	var sb strings.Builder
+	sb.WriteString("## 📦 Package Dependencies\n\n")
+
+	packages, dependencies := PackageGraph(mg.graph)
+	if len(packages) == 0 {
+		sb.WriteString("*No packages found.*\n")
+		return sb.String()
+	}
+
+	dirs := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		dirs = append(dirs, pkg.Dir)
+	}
+	names := relativeDirs(dirs)
+
+	sb.WriteString(fmt.Sprintf("%d packages with %d dependencies between them.\n\n", len(packages), len(dependencies)))
+	sb.WriteString("| Package | Files | Symbols | Lines | Fan-in | Fan-out | Depends On |\n")
+	sb.WriteString("|---------|-------|---------|-------|--------|---------|------------|\n")
+	for i, pkg := range packages {
+		if i >= maxSymbolDetails {
+			break
+		}
+		dependsOn := make([]string, 0, len(pkg.DependsOn))
+		for _, dir := range pkg.DependsOn {
+			dependsOn = append(dependsOn, fmt.Sprintf("`%s`", names[dir]))
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %d | %d | %d | %d | %d | %s |\n",
+			names[pkg.Dir], pkg.Files, pkg.Symbols, pkg.Lines, pkg.FanIn, pkg.FanOut, strings.Join(dependsOn, ", ")))
+	}
+	if len(packages) > maxSymbolDetails {
+		sb.WriteString(fmt.Sprintf("\n*Top %d of %d packages by centrality.*\n", maxSymbolDetails, len(packages)))
+	}
+
+	return sb.String()
+}
+
+// generateRoutes creates the API routes section listing the HTTP routes web frameworks register
+func (mg *MarkdownGenerator) generateRoutes() string {
+	var sb strings.Builder
+	sb.WriteString("## 🌐 API Routes\n\n")
+	WriteRouteTable(&sb, FindRoutes(mg.graph), maxSymbolDetails)
+	return sb.String()
+}
+
+// generateDeprecations creates the section warning against deprecated symbols and listing the files
+// still using them
+func (mg *MarkdownGenerator) generateDeprecations() string {
+	var sb strings.Builder
+	sb.WriteString("## ⚠️ Deprecated APIs\n\n")
+	report := FindDeprecations(mg.graph)
+	if len(report.Deprecated) > 0 {
+		sb.WriteString("> **Do not use these symbols in new code.** Use the replacement named in their notice, and\n")
+		sb.WriteString("> prefer migrating existing usages over adding to them.\n\n")
+	}
+	WriteDeprecationReport(&sb, report, maxSymbolDetails)
+	return sb.String()
+}
+
+// entryPointHeadings titles the entry point kinds in the context map
+var entryPointHeadings = map[string]string{
+	EntryPointMain:    "Programs",
+	EntryPointCommand: "Commands",
+	EntryPointScript:  "Scripts",
+	EntryPointHTTP:    "HTTP Handlers",
+	EntryPointLibrary: "Library Roots",
+}
+
+// generateEntryPoints creates the entry points section
+func (mg *MarkdownGenerator) generateEntryPoints() string {
+	var sb strings.Builder
+	sb.WriteString("## 🚪 Entry Points\n\n")
+
+	entryPoints := FindEntryPoints(mg.graph)
+	if len(entryPoints) == 0 {
+		sb.WriteString("*No entry points found.*\n")
+		return sb.String()
+	}
+
+	byKind := make(map[string][]EntryPoint)
+	for _, entryPoint := range entryPoints {
+		byKind[entryPoint.Kind] = append(byKind[entryPoint.Kind], entryPoint)
+	}
+	for _, kind := range entryPointKinds {
+		kindEntryPoints := byKind[kind]
+		if len(kindEntryPoints) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("### %s (%d)\n\n", entryPointHeadings[kind], len(kindEntryPoints)))
+		sb.WriteString("| Entry | Location | Details |\n")
+		sb.WriteString("|-------|----------|---------|\n")
+		for i, entryPoint := range kindEntryPoints {
+			if i >= maxSymbolDetails {
+				sb.WriteString(fmt.Sprintf("\n*%d more not shown.*\n", len(kindEntryPoints)-maxSymbolDetails))
+				break
+			}
+			location := entryPoint.File
+			if entryPoint.Line > 0 {
+				location = fmt.Sprintf("%s:%d", entryPoint.File, entryPoint.Line)
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %s |\n", entryPoint.Name, location, entryPoint.Detail))
+		}
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// generateSourceRoots creates the source roots section with each root's size and cross-root imports
+func (mg *MarkdownGenerator) generateSourceRoots() string {
+	var sb strings.Builder
+	sb.WriteString("## 🌳 Source Roots\n\n")
+
+	roots := SourceRoots(mg.graph)
+	sb.WriteString(fmt.Sprintf("%d source roots analyzed together.\n\n", len(roots)))
+	sb.WriteString("| Root | Path | Files | Symbols | Lines | Imports From |\n")
+	sb.WriteString("|------|------|-------|---------|-------|--------------|\n")
+	for _, root := range roots {
+		imports := make([]string, 0, len(root.ImportsTo))
+		for _, label := range rootLabels(root.ImportsTo) {
+			imports = append(imports, fmt.Sprintf("`%s` (%d)", label, root.ImportsTo[label]))
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %d | %d | %d | %s |\n",
+			root.Label, root.Path, root.Files, root.Symbols, root.Lines, strings.Join(imports, ", ")))
+	}
+
+	return sb.String()
+}
+
 // generateProjectStructure creates the project structure section
 func (mg *MarkdownGenerator) generateProjectStructure() string {
 	var sb strings.Builder
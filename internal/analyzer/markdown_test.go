@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestGenerateComplexity(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/app.ts", "typescript")
+	simple := addTestSymbol(graph, "src/app.ts", "simple", types.SymbolTypeFunction, 1)
+	simple.Metrics = &types.CodeMetrics{Lines: 3, Cyclomatic: 1, Cognitive: 0, Parameters: 1}
+	tangled := addTestSymbol(graph, "src/app.ts", "tangled", types.SymbolTypeFunction, 10)
+	tangled.Metrics = &types.CodeMetrics{Lines: 80, Cyclomatic: 14, Cognitive: 22, MaxNesting: 4, Parameters: 5}
+	addTestSymbol(graph, "src/app.ts", "Widget", types.SymbolTypeClass, 100)
+
+	content := NewMarkdownGenerator(graph).generateComplexity()
+	if !strings.Contains(content, "1 functions exceed a cognitive complexity of 15.") {
+		t.Errorf("expected one complex function, got:\n%s", content)
+	}
+	row := "| `tangled` | `src/app.ts` | 10 | 80 | 14 | 22 | 4 | 5 |"
+	if !strings.Contains(content, row) || strings.Index(content, row) > strings.Index(content, "| `simple`") {
+		t.Errorf("expected tangled first in the complexity table, got:\n%s", content)
+	}
+	if strings.Contains(content, "Widget") {
+		t.Errorf("classes have no metrics and should not be listed:\n%s", content)
+	}
+}
+
+func TestGenerateContextMapRedaction(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/billing.ts", "typescript")
+	charge := addTestSymbol(graph, "src/billing.ts", "charge", types.SymbolTypeFunction, 3)
+	charge.Signature = `charge(key = "sk_live_51HxYzAbCdEfGhIjKl")`
+
+	generator := NewMarkdownGenerator(graph)
+	generator.SetRedactor(nil)
+	if !strings.Contains(generator.GenerateContextMap(), "sk_live_51HxYzAbCdEfGhIjKl") {
+		t.Fatal("expected the signature in the unredacted map")
+	}
+
+	generator = NewMarkdownGenerator(graph)
+	content := generator.GenerateContextMap()
+	if strings.Contains(content, "sk_live_") || !strings.Contains(content, "[REDACTED:stripe-key]") {
+		t.Errorf("expected the key to be redacted by default")
+	}
+	if redactions := generator.Redactions(); len(redactions) == 0 || redactions[0].Detector != "stripe-key" {
+		t.Errorf("unexpected redactions: %+v", redactions)
+	}
+}
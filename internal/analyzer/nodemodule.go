@@ -0,0 +1,501 @@
+package analyzer
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// nodePackage describes the parts of a package.json used for import resolution
+type nodePackage struct {
+	Dir                  string            `json:"-"`
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Main                 string            `json:"main"`
+	Module               string            `json:"module"`
+	Source               string            `json:"source"`
+	Exports              json.RawMessage   `json:"exports"`
+	Workspaces           json.RawMessage   `json:"workspaces"`
+	Bin                  json.RawMessage   `json:"bin"`
+	Scripts              map[string]string `json:"scripts"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// nodeWorkspace is a monorepo root with the packages it links together
+type nodeWorkspace struct {
+	Dir      string
+	Packages map[string]*nodePackage // package name -> package
+}
+
+// nodeModuleResolver maps JavaScript/TypeScript imports onto files, including imports of
+// sibling packages in npm, yarn and pnpm workspaces
+type nodeModuleResolver struct {
+	ra         *RelationshipAnalyzer
+	packages   map[string]*nodePackage   // package.json directory -> package (nil when absent)
+	dirOwners  map[string]*nodePackage   // source directory -> nearest enclosing package
+	workspaces map[string]*nodeWorkspace // directory -> enclosing workspace (nil when none)
+	analyzed   map[string]*nodePackage   // package name -> package owning analyzed files, built on first cross-root lookup
+}
+
+// newNodeModuleResolver creates a resolver for JavaScript and TypeScript imports in the analyzer's graph
+func newNodeModuleResolver(ra *RelationshipAnalyzer) *nodeModuleResolver {
+	return &nodeModuleResolver{
+		ra:         ra,
+		packages:   make(map[string]*nodePackage),
+		dirOwners:  make(map[string]*nodePackage),
+		workspaces: make(map[string]*nodeWorkspace),
+	}
+}
+
+// nodeSourceExtensions lists the extensions tried when an import omits one
+var nodeSourceExtensions = []string{".ts", ".tsx", ".js", ".jsx"}
+
+// nodeExportConditions is the order in which conditional exports are followed.
+// Source-oriented conditions come first so imports land on files that are actually analyzed.
+var nodeExportConditions = []string{"source", "development", "import", "module", "node", "require", "default", "types"}
+
+// nodeBuildDirs lists output directories that usually mirror src/
+var nodeBuildDirs = []string{"dist", "lib", "build", "out", "esm", "cjs"}
+
+// Resolve resolves a JavaScript or TypeScript import used in fromFile
+func (nr *nodeModuleResolver) Resolve(importPath, fromFile string) *ImportResolution {
+	if isRelativeImport(importPath) {
+		if target := nr.ra.resolveRelativeImport(importPath, fromFile); target != "" {
+			return &ImportResolution{Files: []string{target}}
+		}
+		return &ImportResolution{}
+	}
+
+	name, subpath := splitNodeSpecifier(importPath)
+
+	if builtin := strings.TrimPrefix(name, "node:"); nodeBuiltinModules[builtin] || strings.HasPrefix(name, "node:") {
+		return &ImportResolution{External: true, Stdlib: true, Module: "node:" + builtin}
+	}
+
+	if workspace := nr.workspaceFor(filepath.Dir(fromFile)); workspace != nil {
+		if pkg := workspace.Packages[name]; pkg != nil {
+			resolution := &ImportResolution{Package: pkg.Dir, Module: pkg.Name, Version: pkg.Version}
+			if target := nr.resolveEntry(pkg, subpath); target != "" {
+				resolution.Files = []string{target}
+			}
+			return resolution
+		}
+	}
+
+	// Packages of other source roots resolve to their analyzed sources
+	if isMultiRoot(nr.ra.graph) {
+		if pkg := nr.analyzedPackages()[name]; pkg != nil {
+			resolution := &ImportResolution{Package: pkg.Dir, Module: pkg.Name, Version: pkg.Version}
+			if target := nr.resolveEntry(pkg, subpath); target != "" {
+				resolution.Files = []string{target}
+			}
+			return resolution
+		}
+	}
+
+	resolution := &ImportResolution{External: true, Module: name}
+	if owner := nr.ownerFor(filepath.Dir(fromFile)); owner != nil {
+		resolution.Version = owner.dependencyVersion(name)
+	}
+	return resolution
+}
+
+// resolveEntry maps a package subpath ("." for the package itself) onto a source file
+func (nr *nodeModuleResolver) resolveEntry(pkg *nodePackage, subpath string) string {
+	var targets []string
+	if len(pkg.Exports) > 0 && string(pkg.Exports) != "null" {
+		targets = resolveNodeExports(pkg.Exports, subpath)
+	} else if subpath == "." {
+		targets = append(targets, pkg.Source, pkg.Module, pkg.Main, "index", "src/index")
+	} else {
+		targets = append(targets, subpath, filepath.ToSlash(filepath.Join("src", subpath)))
+	}
+
+	for _, target := range targets {
+		if target == "" {
+			continue
+		}
+		if file := nr.sourceFileFor(filepath.Join(pkg.Dir, filepath.FromSlash(target))); file != "" {
+			return file
+		}
+	}
+	return ""
+}
+
+// sourceFileFor finds the analyzed file behind a package entry point. Entry points often name
+// compiled output, so build directories are mapped back to src/ and extensions are swapped.
+func (nr *nodeModuleResolver) sourceFileFor(path string) string {
+	candidates := []string{path}
+	for _, dir := range nodeBuildDirs {
+		for _, prefix := range []string{dir, filepath.Join(dir, "src")} {
+			marker := string(filepath.Separator) + prefix + string(filepath.Separator)
+			if index := strings.Index(path, marker); index >= 0 {
+				candidates = append(candidates, path[:index]+string(filepath.Separator)+"src"+string(filepath.Separator)+path[index+len(marker):])
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		base := candidate
+		for _, ext := range []string{".d.ts", ".mjs", ".cjs", ".js", ".jsx", ".ts", ".tsx"} {
+			if strings.HasSuffix(base, ext) {
+				base = strings.TrimSuffix(base, ext)
+				break
+			}
+		}
+		if _, exists := nr.ra.graph.Files[candidate]; exists {
+			return candidate
+		}
+		for _, ext := range nodeSourceExtensions {
+			if _, exists := nr.ra.graph.Files[base+ext]; exists {
+				return base + ext
+			}
+		}
+		for _, ext := range nodeSourceExtensions {
+			index := filepath.Join(base, "index"+ext)
+			if _, exists := nr.ra.graph.Files[index]; exists {
+				return index
+			}
+		}
+	}
+	return ""
+}
+
+// analyzedPackages returns the named packages owning analyzed JavaScript and TypeScript files
+func (nr *nodeModuleResolver) analyzedPackages() map[string]*nodePackage {
+	if nr.analyzed != nil {
+		return nr.analyzed
+	}
+
+	nr.analyzed = make(map[string]*nodePackage)
+	for filePath, fileNode := range nr.ra.graph.Files {
+		if fileNode.Language != "javascript" && fileNode.Language != "typescript" {
+			continue
+		}
+		owner := nr.ownerFor(filepath.Dir(filePath))
+		if owner == nil || owner.Name == "" {
+			continue
+		}
+		// Prefer the shallowest package when names collide
+		if existing := nr.analyzed[owner.Name]; existing == nil || len(owner.Dir) < len(existing.Dir) {
+			nr.analyzed[owner.Name] = owner
+		}
+	}
+	return nr.analyzed
+}
+
+// workspaceFor finds the workspace enclosing dir by walking up to a root package.json with
+// a workspaces field or a pnpm-workspace.yaml
+func (nr *nodeModuleResolver) workspaceFor(dir string) *nodeWorkspace {
+	if workspace, cached := nr.workspaces[dir]; cached {
+		return workspace
+	}
+
+	var workspace *nodeWorkspace
+	var patterns []string
+	if pnpm := parsePnpmWorkspace(filepath.Join(dir, "pnpm-workspace.yaml")); pnpm != nil {
+		patterns = pnpm
+	} else if pkg := nr.packageAt(dir); pkg != nil {
+		patterns = pkg.workspacePatterns()
+	}
+
+	if patterns != nil {
+		workspace = nr.loadWorkspace(dir, patterns)
+	} else if parent := filepath.Dir(dir); parent != dir {
+		workspace = nr.workspaceFor(parent)
+	}
+
+	nr.workspaces[dir] = workspace
+	return workspace
+}
+
+// loadWorkspace expands workspace package globs below dir
+func (nr *nodeModuleResolver) loadWorkspace(dir string, patterns []string) *nodeWorkspace {
+	workspace := &nodeWorkspace{Dir: dir, Packages: make(map[string]*nodePackage)}
+
+	excluded := make(map[string]bool)
+	var included []string
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		matches := expandWorkspaceGlob(dir, strings.TrimPrefix(pattern, "!"))
+		if negated {
+			for _, match := range matches {
+				excluded[match] = true
+			}
+		} else {
+			included = append(included, matches...)
+		}
+	}
+
+	// The root package can be imported by name as well
+	included = append(included, dir)
+	for _, packageDir := range included {
+		if excluded[packageDir] {
+			continue
+		}
+		if pkg := nr.packageAt(packageDir); pkg != nil && pkg.Name != "" {
+			if _, exists := workspace.Packages[pkg.Name]; !exists {
+				workspace.Packages[pkg.Name] = pkg
+			}
+		}
+	}
+	return workspace
+}
+
+// ownerFor finds the nearest package.json above dir
+func (nr *nodeModuleResolver) ownerFor(dir string) *nodePackage {
+	if owner, cached := nr.dirOwners[dir]; cached {
+		return owner
+	}
+
+	owner := nr.packageAt(dir)
+	if owner == nil {
+		if parent := filepath.Dir(dir); parent != dir {
+			owner = nr.ownerFor(parent)
+		}
+	}
+
+	nr.dirOwners[dir] = owner
+	return owner
+}
+
+// packageAt loads and caches the package.json in dir
+func (nr *nodeModuleResolver) packageAt(dir string) *nodePackage {
+	if pkg, cached := nr.packages[dir]; cached {
+		return pkg
+	}
+
+	pkg, err := parsePackageJSON(filepath.Join(dir, "package.json"))
+	if err != nil {
+		pkg = nil
+	}
+	nr.packages[dir] = pkg
+	return pkg
+}
+
+// workspacePatterns returns the workspaces globs in either the array or the yarn object form
+func (np *nodePackage) workspacePatterns() []string {
+	if len(np.Workspaces) == 0 {
+		return nil
+	}
+
+	var patterns []string
+	if err := json.Unmarshal(np.Workspaces, &patterns); err == nil {
+		return patterns
+	}
+
+	var yarn struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(np.Workspaces, &yarn); err == nil {
+		return yarn.Packages
+	}
+	return nil
+}
+
+// dependencyVersion returns the version range a package declares for a dependency
+func (np *nodePackage) dependencyVersion(name string) string {
+	for _, deps := range []map[string]string{np.Dependencies, np.DevDependencies, np.PeerDependencies, np.OptionalDependencies} {
+		if version, ok := deps[name]; ok {
+			return version
+		}
+	}
+	return ""
+}
+
+// parsePackageJSON reads a package.json file
+func parsePackageJSON(path string) (*nodePackage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &nodePackage{}
+	if err := json.Unmarshal(data, pkg); err != nil {
+		return nil, err
+	}
+	pkg.Dir = filepath.Dir(path)
+	return pkg, nil
+}
+
+// parsePnpmWorkspace reads the packages list of a pnpm-workspace.yaml, or nil when there is none
+func parsePnpmWorkspace(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	patterns := make([]string, 0)
+	inPackages := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Top-level keys end the packages list
+		if !strings.HasPrefix(raw, " ") && !strings.HasPrefix(raw, "\t") && !strings.HasPrefix(line, "-") {
+			inPackages = strings.HasPrefix(line, "packages:")
+			continue
+		}
+
+		if inPackages && strings.HasPrefix(line, "-") {
+			pattern := strings.TrimSpace(strings.TrimPrefix(line, "-"))
+			if index := strings.Index(pattern, " #"); index >= 0 {
+				pattern = strings.TrimSpace(pattern[:index])
+			}
+			patterns = append(patterns, strings.Trim(pattern, `"'`))
+		}
+	}
+	return patterns
+}
+
+// resolveNodeExports returns the export targets for a subpath, most preferred first
+func resolveNodeExports(exports json.RawMessage, subpath string) []string {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(exports, &entries); err != nil || !hasSubpathKeys(entries) {
+		// "exports": "./index.js" or a conditions object both describe the "." entry only
+		if subpath != "." {
+			return nil
+		}
+		return exportTargets(exports, "")
+	}
+
+	if target, ok := entries[subpath]; ok {
+		return exportTargets(target, "")
+	}
+
+	// Subpath patterns such as "./components/*": pick the longest matching prefix
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	for _, key := range keys {
+		prefix, suffix, ok := strings.Cut(key, "*")
+		if !ok || !strings.HasPrefix(subpath, prefix) || !strings.HasSuffix(subpath, suffix) || len(subpath) < len(prefix)+len(suffix) {
+			continue
+		}
+		return exportTargets(entries[key], subpath[len(prefix):len(subpath)-len(suffix)])
+	}
+	return nil
+}
+
+// exportTargets flattens a target string, conditions object or fallback array
+func exportTargets(target json.RawMessage, wildcard string) []string {
+	var path string
+	if err := json.Unmarshal(target, &path); err == nil {
+		return []string{strings.ReplaceAll(path, "*", wildcard)}
+	}
+
+	var conditions map[string]json.RawMessage
+	if err := json.Unmarshal(target, &conditions); err == nil {
+		var targets []string
+		for _, condition := range nodeExportConditions {
+			if nested, ok := conditions[condition]; ok {
+				targets = append(targets, exportTargets(nested, wildcard)...)
+			}
+		}
+		return targets
+	}
+
+	var fallbacks []json.RawMessage
+	if err := json.Unmarshal(target, &fallbacks); err == nil {
+		var targets []string
+		for _, fallback := range fallbacks {
+			targets = append(targets, exportTargets(fallback, wildcard)...)
+		}
+		return targets
+	}
+	return nil
+}
+
+// hasSubpathKeys reports whether an exports object is keyed by subpaths rather than conditions
+func hasSubpathKeys(entries map[string]json.RawMessage) bool {
+	for key := range entries {
+		if strings.HasPrefix(key, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// expandWorkspaceGlob returns the directories below root matching a workspace pattern.
+// A "**" segment matches any number of directories; node_modules is never entered.
+func expandWorkspaceGlob(root, pattern string) []string {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), "/")
+	if !strings.Contains(pattern, "**") {
+		matches, _ := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		return matches
+	}
+
+	base, rest, _ := strings.Cut(pattern, "**")
+	start := filepath.Join(root, filepath.FromSlash(strings.TrimSuffix(base, "/")))
+	rest = strings.TrimPrefix(rest, "/")
+
+	var matches []string
+	filepath.WalkDir(start, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil
+		}
+		if entry.Name() == "node_modules" {
+			return filepath.SkipDir
+		}
+		if rest == "" || matchesTrailingSegments(path, rest) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	return matches
+}
+
+// matchesTrailingSegments reports whether the last directories of path match a slash-separated glob
+func matchesTrailingSegments(path, pattern string) bool {
+	segments := strings.Split(filepath.ToSlash(path), "/")
+	patterns := strings.Split(pattern, "/")
+	if len(segments) < len(patterns) {
+		return false
+	}
+	segments = segments[len(segments)-len(patterns):]
+	for i, segmentPattern := range patterns {
+		if ok, _ := filepath.Match(segmentPattern, segments[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// splitNodeSpecifier splits a bare specifier into the package name and a "./" subpath
+func splitNodeSpecifier(specifier string) (string, string) {
+	parts := strings.SplitN(specifier, "/", 3)
+	nameParts := 1
+	if strings.HasPrefix(specifier, "@") && len(parts) > 1 {
+		nameParts = 2
+	}
+	if len(parts) <= nameParts {
+		return specifier, "."
+	}
+	name := strings.Join(parts[:nameParts], "/")
+	return name, "./" + strings.TrimPrefix(specifier[len(name):], "/")
+}
+
+// nodeBuiltinModules lists modules built into Node.js
+var nodeBuiltinModules = map[string]bool{
+	"assert": true, "async_hooks": true, "buffer": true, "child_process": true, "cluster": true,
+	"console": true, "constants": true, "crypto": true, "dgram": true, "diagnostics_channel": true,
+	"dns": true, "domain": true, "events": true, "fs": true, "http": true, "http2": true, "https": true,
+	"inspector": true, "module": true, "net": true, "os": true, "path": true, "perf_hooks": true,
+	"process": true, "punycode": true, "querystring": true, "readline": true, "repl": true,
+	"stream": true, "string_decoder": true, "timers": true, "tls": true, "trace_events": true,
+	"tty": true, "url": true, "util": true, "v8": true, "vm": true, "wasi": true, "worker_threads": true,
+	"zlib": true,
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestNodeWorkspaceResolution(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "package.json", `{"name": "acme", "private": true, "workspaces": ["packages/*", "apps/**", "!packages/legacy"]}`)
+	writeTestFile(t, dir, "packages/ui/package.json", `{
+  "name": "@acme/ui",
+  "version": "2.1.0",
+  "exports": {
+    ".": {"types": "./dist/index.d.ts", "import": "./dist/index.mjs", "require": "./dist/index.cjs"},
+    "./components/*": "./dist/components/*.js",
+    "./package.json": "./package.json"
+  }
+}`)
+	writeTestFile(t, dir, "packages/utils/package.json", `{"name": "@acme/utils", "version": "0.3.0", "main": "lib/index.js"}`)
+	writeTestFile(t, dir, "packages/legacy/package.json", `{"name": "@acme/legacy", "main": "index.js"}`)
+	writeTestFile(t, dir, "apps/web/package.json", `{"name": "web", "dependencies": {"react": "^18.3.1", "@acme/ui": "workspace:*"}}`)
+
+	graph := newEmptyTestGraph()
+	page := filepath.Join(dir, "apps", "web", "src", "page.tsx")
+	uiIndex := filepath.Join(dir, "packages", "ui", "src", "index.ts")
+	button := filepath.Join(dir, "packages", "ui", "src", "components", "Button.tsx")
+	utilsIndex := filepath.Join(dir, "packages", "utils", "src", "index.ts")
+	format := filepath.Join(dir, "packages", "utils", "src", "format.ts")
+	legacy := filepath.Join(dir, "packages", "legacy", "index.js")
+	addTestFile(graph, page, "typescript")
+	addTestFile(graph, uiIndex, "typescript")
+	addTestFile(graph, button, "typescript")
+	addTestFile(graph, utilsIndex, "typescript")
+	addTestFile(graph, format, "typescript")
+	addTestFile(graph, legacy, "javascript")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+
+	tests := []struct {
+		name       string
+		importPath string
+		files      []string
+		pkg        string
+		module     string
+		version    string
+		external   bool
+		stdlib     bool
+	}{
+		{"exports conditions map dist to src", "@acme/ui", []string{uiIndex}, filepath.Join(dir, "packages", "ui"), "@acme/ui", "2.1.0", false, false},
+		{"exports subpath pattern", "@acme/ui/components/Button", []string{button}, filepath.Join(dir, "packages", "ui"), "@acme/ui", "2.1.0", false, false},
+		{"main field", "@acme/utils", []string{utilsIndex}, filepath.Join(dir, "packages", "utils"), "@acme/utils", "0.3.0", false, false},
+		{"deep import without exports", "@acme/utils/format", []string{format}, filepath.Join(dir, "packages", "utils"), "@acme/utils", "0.3.0", false, false},
+		{"excluded workspace package", "@acme/legacy", nil, "", "@acme/legacy", "", true, false},
+		{"third party", "react", nil, "", "react", "^18.3.1", true, false},
+		{"node builtin", "node:fs/promises", nil, "", "node:fs", "", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution := analyzer.resolveImport(&types.Import{Path: tt.importPath}, page)
+
+			if !reflect.DeepEqual(resolution.Files, tt.files) {
+				t.Errorf("Files = %v, expected %v", resolution.Files, tt.files)
+			}
+			if resolution.Package != tt.pkg {
+				t.Errorf("Package = %q, expected %q", resolution.Package, tt.pkg)
+			}
+			if resolution.Module != tt.module || resolution.Version != tt.version {
+				t.Errorf("Module = %s@%s, expected %s@%s", resolution.Module, resolution.Version, tt.module, tt.version)
+			}
+			if resolution.External != tt.external || resolution.Stdlib != tt.stdlib {
+				t.Errorf("External/Stdlib = %v/%v, expected %v/%v", resolution.External, resolution.Stdlib, tt.external, tt.stdlib)
+			}
+		})
+	}
+}
+
+func TestPnpmWorkspaceImportEdges(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "pnpm-workspace.yaml", "packages:\n  - 'packages/*' # libraries\n  - \"!**/test/**\"\ncatalog:\n  react: ^18\n")
+	writeTestFile(t, dir, "package.json", `{"name": "root", "private": true}`)
+	writeTestFile(t, dir, "packages/core/package.json", `{"name": "@acme/core", "module": "./src/index.js"}`)
+	writeTestFile(t, dir, "packages/cli/package.json", `{"name": "@acme/cli"}`)
+
+	graph := newEmptyTestGraph()
+	cli := filepath.Join(dir, "packages", "cli", "index.js")
+	core := filepath.Join(dir, "packages", "core", "src", "index.js")
+	addTestFile(graph, cli, "javascript", "@acme/core")
+	addTestFile(graph, core, "javascript")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{ByType: make(map[RelationshipType]int)}
+	analyzer.analyzeImportRelationships(metrics)
+
+	if _, exists := graph.Edges[types.EdgeId("import-"+cli+"-"+core)]; !exists {
+		t.Error("expected import edge into the workspace package source")
+	}
+	if _, exists := graph.Edges[types.EdgeId("external-import-"+cli+"-@acme/core")]; exists {
+		t.Error("workspace package import should not be external")
+	}
+}
+
+func TestParsePnpmWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "pnpm-workspace.yaml", "packages:\n  - 'packages/*'\n  - apps/**\n  - '!**/test/**'\n")
+
+	patterns := parsePnpmWorkspace(path)
+	expected := []string{"packages/*", "apps/**", "!**/test/**"}
+	if !reflect.DeepEqual(patterns, expected) {
+		t.Errorf("parsePnpmWorkspace() = %v, expected %v", patterns, expected)
+	}
+}
+
+func TestSplitNodeSpecifier(t *testing.T) {
+	tests := []struct {
+		specifier string
+		name      string
+		subpath   string
+	}{
+		{"react", "react", "."},
+		{"lodash/fp/map", "lodash", "./fp/map"},
+		{"@acme/ui", "@acme/ui", "."},
+		{"@acme/ui/components/Button", "@acme/ui", "./components/Button"},
+	}
+
+	for _, tt := range tests {
+		name, subpath := splitNodeSpecifier(tt.specifier)
+		if name != tt.name || subpath != tt.subpath {
+			t.Errorf("splitNodeSpecifier(%q) = %q, %q, expected %q, %q", tt.specifier, name, subpath, tt.name, tt.subpath)
+		}
+	}
+}
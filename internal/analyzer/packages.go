@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// PackageSummary holds the aggregated metrics of the files in one directory
+type PackageSummary struct {
+	Dir        string   `json:"dir"`
+	Label      string   `json:"label"`
+	Language   string   `json:"language"`
+	Files      int      `json:"files"`
+	Symbols    int      `json:"symbols"`
+	Lines      int      `json:"lines"`
+	FanIn      int      `json:"fan_in"`  // Packages that import this one
+	FanOut     int      `json:"fan_out"` // Packages this one imports
+	Importance float64  `json:"importance"`
+	DependsOn  []string `json:"depends_on"`
+}
+
+// PackageDependency is an import dependency between two package directories
+type PackageDependency struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Imports int    `json:"imports"` // Files in From that import To
+}
+
+// aggregatePackages adds a package node for every directory holding analyzed files, with file, symbol
+// and line counts, and rolls file imports up into depends edges between packages. A package's
+// importance is the summed centrality of its files, so it must run after computeCentrality.
+func (ra *RelationshipAnalyzer) aggregatePackages() {
+	filePaths := make([]string, 0, len(ra.graph.Files))
+	for filePath := range ra.graph.Files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	packages := make(map[string]*types.GraphNode)
+	for _, filePath := range filePaths {
+		fileNode := ra.graph.Files[filePath]
+		dir := filepath.Dir(filePath)
+		node := packages[dir]
+		if node == nil {
+			node = ra.graph.Nodes[ra.ensurePackageNode(dir, "", fileNode.Language)]
+			node.Importance = 0
+			for _, key := range []string{"files", "symbols", "lines", "fan_in", "fan_out"} {
+				node.Metadata[key] = 0
+			}
+			packages[dir] = node
+		}
+		node.Metadata["files"] = node.Metadata["files"].(int) + 1
+		node.Metadata["symbols"] = node.Metadata["symbols"].(int) + len(fileNode.Symbols)
+		node.Metadata["lines"] = node.Metadata["lines"].(int) + fileNode.Lines
+		node.Importance += FileImportance(ra.graph, filePath)
+	}
+	normalizeImportance(ra.graph, "package")
+
+	// Distinct importing files per pair of directories; package imports also produce file edges
+	importers := make(map[[2]string]map[string]bool)
+	for _, edge := range ra.graph.Edges {
+		if edge.Type != string(RelationshipImport) {
+			continue
+		}
+		source := ra.extractFileFromNodeId(edge.From)
+		targetDir := ""
+		if node := ra.graph.Nodes[edge.To]; node != nil && node.Type == "package" {
+			targetDir = node.FilePath
+		} else if target := ra.extractFileFromNodeId(edge.To); ra.graph.Files[target] != nil {
+			targetDir = filepath.Dir(target)
+		}
+		key := [2]string{filepath.Dir(source), targetDir}
+		if ra.graph.Files[source] == nil || key[0] == key[1] || packages[key[1]] == nil {
+			continue
+		}
+		if importers[key] == nil {
+			importers[key] = make(map[string]bool)
+		}
+		importers[key][source] = true
+	}
+
+	for key, files := range importers {
+		from, to := packages[key[0]], packages[key[1]]
+		edgeId := types.EdgeId(fmt.Sprintf("depends-%s-%s", key[0], key[1]))
+		ra.graph.Edges[edgeId] = &types.GraphEdge{
+			Id:     edgeId,
+			From:   from.Id,
+			To:     to.Id,
+			Type:   string(RelationshipDepends),
+			Weight: float64(len(files)),
+			Metadata: map[string]interface{}{
+				"imports": len(files),
+			},
+		}
+		from.Metadata["fan_out"] = from.Metadata["fan_out"].(int) + 1
+		to.Metadata["fan_in"] = to.Metadata["fan_in"].(int) + 1
+	}
+}
+
+// PackageGraph returns the analyzed packages, most central first, and the dependencies between them
+// ordered by source and target. The graph must already have its relationships analyzed.
+func PackageGraph(graph *types.CodeGraph) ([]PackageSummary, []PackageDependency) {
+	metric := func(node *types.GraphNode, key string) int {
+		value, _ := node.Metadata[key].(int)
+		return value
+	}
+
+	summaries := make(map[types.NodeId]*PackageSummary)
+	for _, node := range graph.Nodes {
+		if node.Type != "package" || metric(node, "files") == 0 {
+			continue
+		}
+		language, _ := node.Metadata["language"].(string)
+		summaries[node.Id] = &PackageSummary{
+			Dir:        node.FilePath,
+			Label:      node.Label,
+			Language:   language,
+			Files:      metric(node, "files"),
+			Symbols:    metric(node, "symbols"),
+			Lines:      metric(node, "lines"),
+			FanIn:      metric(node, "fan_in"),
+			FanOut:     metric(node, "fan_out"),
+			Importance: node.Importance,
+			DependsOn:  make([]string, 0),
+		}
+	}
+
+	dependencies := make([]PackageDependency, 0)
+	for _, edge := range graph.Edges {
+		if edge.Type != string(RelationshipDepends) {
+			continue
+		}
+		from, to := summaries[edge.From], summaries[edge.To]
+		if from == nil || to == nil {
+			continue
+		}
+		imports, _ := edge.Metadata["imports"].(int)
+		dependencies = append(dependencies, PackageDependency{From: from.Dir, To: to.Dir, Imports: imports})
+		from.DependsOn = append(from.DependsOn, to.Dir)
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].From != dependencies[j].From {
+			return dependencies[i].From < dependencies[j].From
+		}
+		return dependencies[i].To < dependencies[j].To
+	})
+
+	packages := make([]PackageSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		sort.Strings(summary.DependsOn)
+		packages = append(packages, *summary)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Importance != packages[j].Importance {
+			return packages[i].Importance > packages[j].Importance
+		}
+		return packages[i].Dir < packages[j].Dir
+	})
+
+	return packages, dependencies
+}
+
+// relativeDirs maps each directory to its path below the deepest directory containing all of them,
+// so absolute analysis paths render compactly
+func relativeDirs(dirs []string) map[string]string {
+	root := ""
+	for i, dir := range dirs {
+		if i == 0 {
+			root = dir
+			continue
+		}
+		for root != "." && root != "/" && dir != root && !strings.HasPrefix(dir, root+string(filepath.Separator)) {
+			root = filepath.Dir(root)
+		}
+	}
+
+	names := make(map[string]string, len(dirs))
+	for _, dir := range dirs {
+		name, err := filepath.Rel(root, dir)
+		if err != nil {
+			name = dir
+		}
+		names[dir] = filepath.ToSlash(name)
+	}
+	return names
+}
@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestPackageGraph(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/app/main.ts", "typescript", "../db/query", "../util/format", "./routes")
+	addTestFile(graph, "src/app/routes.ts", "typescript", "../db/query")
+	addTestFile(graph, "src/db/query.ts", "typescript", "../util/format")
+	addTestFile(graph, "src/util/format.ts", "typescript", "lodash")
+	graph.Files["src/app/main.ts"].Lines = 40
+	graph.Files["src/app/routes.ts"].Lines = 25
+	addTestSymbol(graph, "src/app/main.ts", "main", types.SymbolTypeFunction, 5)
+	addTestSymbol(graph, "src/db/query.ts", "query", types.SymbolTypeFunction, 1)
+	addTestSymbol(graph, "src/db/query.ts", "connect", types.SymbolTypeFunction, 9)
+
+	if _, err := NewRelationshipAnalyzer(graph).AnalyzeAllRelationships(); err != nil {
+		t.Fatalf("AnalyzeAllRelationships() error = %v", err)
+	}
+
+	packages, dependencies := PackageGraph(graph)
+	if len(packages) != 3 {
+		t.Fatalf("PackageGraph() returned %d packages, expected 3: %+v", len(packages), packages)
+	}
+
+	// Both app files import db, but the external lodash import is not a package dependency
+	expected := []PackageDependency{
+		{From: "src/app", To: "src/db", Imports: 2},
+		{From: "src/app", To: "src/util", Imports: 1},
+		{From: "src/db", To: "src/util", Imports: 1},
+	}
+	if !reflect.DeepEqual(dependencies, expected) {
+		t.Errorf("dependencies = %+v, expected %+v", dependencies, expected)
+	}
+
+	byDir := make(map[string]PackageSummary)
+	for _, pkg := range packages {
+		byDir[pkg.Dir] = pkg
+	}
+	app := byDir["src/app"]
+	if app.Files != 2 || app.Symbols != 1 || app.Lines != 65 || app.FanIn != 0 || app.FanOut != 2 {
+		t.Errorf("unexpected app package metrics: %+v", app)
+	}
+	if !reflect.DeepEqual(app.DependsOn, []string{"src/db", "src/util"}) {
+		t.Errorf("app depends on %v, expected [src/db src/util]", app.DependsOn)
+	}
+	if util := byDir["src/util"]; util.FanIn != 2 || util.FanOut != 0 {
+		t.Errorf("unexpected util package metrics: %+v", util)
+	}
+	if packages[0].Dir != "src/util" || packages[0].Importance != 1 {
+		t.Errorf("expected the util package to be the most central, got %+v", packages[0])
+	}
+
+	content := NewMarkdownGenerator(graph).GenerateContextMap()
+	if !strings.Contains(content, "3 packages with 3 dependencies between them.") ||
+		!strings.Contains(content, "| `app` | 2 | 1 | 65 | 0 | 2 | `db`, `util` |") {
+		t.Errorf("unexpected package section:\n%s", content)
+	}
+}
+
+func TestRelativeDirs(t *testing.T) {
+	names := relativeDirs([]string{"/repo/internal/api", "/repo/internal/db", "/repo/cmd"})
+	expected := map[string]string{"/repo/internal/api": "internal/api", "/repo/internal/db": "internal/db", "/repo/cmd": "cmd"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("relativeDirs() = %v, expected %v", names, expected)
+	}
+	if names := relativeDirs([]string{"src"}); names["src"] != "." {
+		t.Errorf("relativeDirs() of a single dir = %v, expected .", names)
+	}
+}
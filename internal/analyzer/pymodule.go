@@ -0,0 +1,356 @@
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pythonModuleResolver maps Python module names onto files using detected source roots
+type pythonModuleResolver struct {
+	ra          *RelationshipAnalyzer
+	projectDirs map[string]string   // source directory -> enclosing project directory
+	sourceRoots map[string][]string // project directory -> source roots
+	packageDirs map[string]bool     // directories containing Python files, directly or below
+}
+
+// newPythonModuleResolver creates a resolver for Python imports in the analyzer's graph
+func newPythonModuleResolver(ra *RelationshipAnalyzer) *pythonModuleResolver {
+	return &pythonModuleResolver{
+		ra:          ra,
+		projectDirs: make(map[string]string),
+		sourceRoots: make(map[string][]string),
+	}
+}
+
+var (
+	// pyprojectListPattern matches the quoted entries of setuptools find where = ["src"] and hatch packages = ["src/pkg"]
+	pyprojectListPattern = regexp.MustCompile(`"([^"]+)"`)
+	// pyprojectFromPattern matches poetry package entries such as { include = "pkg", from = "src" }
+	pyprojectFromPattern = regexp.MustCompile(`from\s*=\s*"([^"]+)"`)
+	// pyprojectPackageDirPattern matches setuptools package-dir = {"" = "src"}
+	pyprojectPackageDirPattern = regexp.MustCompile(`""\s*=\s*"([^"]+)"`)
+)
+
+// Resolve resolves a Python import, which may be relative (leading dots) or absolute
+func (pr *pythonModuleResolver) Resolve(importPath string, specifiers []string, fromFile string) *ImportResolution {
+	resolution := &ImportResolution{}
+
+	if strings.HasPrefix(importPath, ".") {
+		dots := len(importPath) - len(strings.TrimLeft(importPath, "."))
+		base := filepath.Dir(fromFile)
+		for i := 1; i < dots; i++ {
+			base = filepath.Dir(base)
+		}
+		pr.resolveFrom(base, splitPythonModule(importPath[dots:]), specifiers, resolution)
+		return resolution
+	}
+
+	parts := splitPythonModule(importPath)
+	for _, root := range pr.rootsFor(fromFile) {
+		if pr.resolveFrom(root, parts, specifiers, resolution) {
+			return resolution
+		}
+	}
+
+	top := parts[0]
+	resolution.External = true
+	resolution.Module = top
+	resolution.Stdlib = pythonStdlibModules[top]
+	return resolution
+}
+
+// resolveFrom resolves module parts below base, plus specifiers that name submodules.
+// It reports whether the module itself was found.
+func (pr *pythonModuleResolver) resolveFrom(base string, parts, specifiers []string, resolution *ImportResolution) bool {
+	found := false
+	moduleDir := filepath.Join(append([]string{base}, parts...)...)
+
+	if len(parts) == 0 {
+		// "from . import x": the package itself is the base directory
+		found = pr.isPackageDir(base)
+	} else if files, pkg, ok := pr.lookupModule(moduleDir); ok {
+		resolution.Files = append(resolution.Files, files...)
+		if pkg != "" {
+			resolution.Package = pkg
+		}
+		found = true
+	}
+
+	// "from pkg import submodule" imports the submodule file as well
+	for _, specifier := range specifiers {
+		if specifier == "*" || specifier == "" {
+			continue
+		}
+		if files, _, ok := pr.lookupModule(filepath.Join(moduleDir, specifier)); ok {
+			resolution.Files = append(resolution.Files, files...)
+			found = true
+		}
+	}
+
+	// Names imported from a package without a submodule match come from its __init__.py
+	if len(parts) == 0 && found && len(resolution.Files) == 0 {
+		if files, pkg, ok := pr.lookupModule(base); ok {
+			resolution.Files = append(resolution.Files, files...)
+			resolution.Package = pkg
+		}
+	}
+
+	return found
+}
+
+// lookupModule finds the files behind a module path (without extension).
+// Modules map to name.py, regular packages to name/__init__.py and
+// namespace packages to a directory of Python files without __init__.py.
+func (pr *pythonModuleResolver) lookupModule(modulePath string) ([]string, string, bool) {
+	if fileNode := pr.ra.graph.Files[modulePath+".py"]; fileNode != nil {
+		return []string{modulePath + ".py"}, "", true
+	}
+
+	initFile := filepath.Join(modulePath, "__init__.py")
+	if fileNode := pr.ra.graph.Files[initFile]; fileNode != nil {
+		return []string{initFile}, modulePath, true
+	}
+
+	if pr.isPackageDir(modulePath) {
+		return nil, modulePath, true
+	}
+
+	return nil, "", false
+}
+
+// isPackageDir reports whether dir contains analyzed Python files at any depth
+func (pr *pythonModuleResolver) isPackageDir(dir string) bool {
+	if pr.packageDirs == nil {
+		pr.packageDirs = make(map[string]bool)
+		for filePath, fileNode := range pr.ra.graph.Files {
+			if fileNode.Language != "python" {
+				continue
+			}
+			for d := filepath.Dir(filePath); ; d = filepath.Dir(d) {
+				if pr.packageDirs[d] {
+					break
+				}
+				pr.packageDirs[d] = true
+				if filepath.Dir(d) == d {
+					break
+				}
+			}
+		}
+	}
+	return pr.packageDirs[dir]
+}
+
+// rootsFor returns the source roots used for absolute imports from fromFile
+func (pr *pythonModuleResolver) rootsFor(fromFile string) []string {
+	roots := make([]string, 0, 4)
+	seen := make(map[string]bool)
+	add := func(root string) {
+		root = filepath.Clean(root)
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+
+	if project := pr.projectDir(filepath.Dir(fromFile)); project != "" {
+		for _, root := range pr.projectRoots(project) {
+			add(root)
+		}
+	}
+
+	// The directory above the outermost regular package is a root as well
+	top := filepath.Dir(fromFile)
+	for {
+		if _, exists := pr.ra.graph.Files[filepath.Join(top, "__init__.py")]; !exists {
+			break
+		}
+		parent := filepath.Dir(top)
+		if parent == top {
+			break
+		}
+		top = parent
+	}
+	add(top)
+
+	return roots
+}
+
+// projectDir finds the closest directory above dir holding Python project metadata
+func (pr *pythonModuleResolver) projectDir(dir string) string {
+	if project, cached := pr.projectDirs[dir]; cached {
+		return project
+	}
+
+	project := ""
+	for _, marker := range []string{"pyproject.toml", "setup.cfg", "setup.py"} {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			project = dir
+			break
+		}
+	}
+	if project == "" {
+		if parent := filepath.Dir(dir); parent != dir {
+			project = pr.projectDir(parent)
+		}
+	}
+
+	pr.projectDirs[dir] = project
+	return project
+}
+
+// projectRoots returns the configured source roots of a project, then src/ and the project itself
+func (pr *pythonModuleResolver) projectRoots(project string) []string {
+	if roots, cached := pr.sourceRoots[project]; cached {
+		return roots
+	}
+
+	roots := make([]string, 0)
+	for _, root := range parsePyprojectRoots(filepath.Join(project, "pyproject.toml")) {
+		roots = append(roots, filepath.Join(project, root))
+	}
+	for _, root := range parseSetupCfgRoots(filepath.Join(project, "setup.cfg")) {
+		roots = append(roots, filepath.Join(project, root))
+	}
+	if info, err := os.Stat(filepath.Join(project, "src")); err == nil && info.IsDir() {
+		roots = append(roots, filepath.Join(project, "src"))
+	}
+	roots = append(roots, project)
+
+	pr.sourceRoots[project] = roots
+	return roots
+}
+
+// parsePyprojectRoots extracts package source directories from setuptools, poetry and hatch settings
+func parsePyprojectRoots(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var roots []string
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && !strings.HasPrefix(line, "[[") {
+			section = strings.Trim(line, "[] ")
+			continue
+		}
+
+		switch section {
+		case "tool.setuptools.packages.find":
+			if strings.HasPrefix(line, "where") {
+				for _, match := range pyprojectListPattern.FindAllStringSubmatch(line, -1) {
+					roots = append(roots, match[1])
+				}
+			}
+		case "tool.setuptools":
+			if strings.HasPrefix(line, "package-dir") {
+				if match := pyprojectPackageDirPattern.FindStringSubmatch(line); match != nil {
+					roots = append(roots, match[1])
+				}
+			}
+		case "tool.setuptools.package-dir":
+			if match := pyprojectPackageDirPattern.FindStringSubmatch(line); match != nil {
+				roots = append(roots, match[1])
+			}
+		case "tool.poetry":
+			if match := pyprojectFromPattern.FindStringSubmatch(line); match != nil {
+				roots = append(roots, match[1])
+			}
+		case "tool.hatch.build.targets.wheel":
+			if strings.HasPrefix(line, "packages") {
+				for _, match := range pyprojectListPattern.FindAllStringSubmatch(line, -1) {
+					roots = append(roots, filepath.Dir(filepath.FromSlash(match[1])))
+				}
+			}
+		}
+	}
+
+	return roots
+}
+
+// parseSetupCfgRoots extracts package_dir and packages.find settings from setup.cfg
+func parseSetupCfgRoots(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var roots []string
+	section := ""
+	inPackageDir := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			inPackageDir = false
+			continue
+		}
+
+		// Continuation lines of a multi-line package_dir value are indented
+		if inPackageDir && line != "" && (strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")) {
+			if strings.HasPrefix(line, "=") {
+				roots = append(roots, strings.TrimSpace(strings.TrimPrefix(line, "=")))
+			}
+			continue
+		}
+		inPackageDir = false
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch {
+		case section == "options" && key == "package_dir":
+			if strings.HasPrefix(value, "=") {
+				roots = append(roots, strings.TrimSpace(strings.TrimPrefix(value, "=")))
+			} else if value == "" {
+				inPackageDir = true
+			}
+		case section == "options.packages.find" && key == "where":
+			roots = append(roots, value)
+		}
+	}
+
+	return roots
+}
+
+// splitPythonModule splits a dotted module name into its parts
+func splitPythonModule(module string) []string {
+	if module == "" {
+		return nil
+	}
+	return strings.Split(module, ".")
+}
+
+// pythonStdlibModules lists top-level standard library modules
+var pythonStdlibModules = map[string]bool{
+	"__future__": true, "abc": true, "argparse": true, "array": true, "ast": true, "asyncio": true,
+	"atexit": true, "base64": true, "bisect": true, "builtins": true, "bz2": true, "calendar": true,
+	"cmath": true, "codecs": true, "collections": true, "concurrent": true, "configparser": true,
+	"contextlib": true, "contextvars": true, "copy": true, "csv": true, "ctypes": true, "dataclasses": true,
+	"datetime": true, "decimal": true, "difflib": true, "dis": true, "email": true, "enum": true,
+	"errno": true, "faulthandler": true, "fnmatch": true, "fractions": true, "functools": true, "gc": true,
+	"getpass": true, "gettext": true, "glob": true, "graphlib": true, "gzip": true, "hashlib": true,
+	"heapq": true, "hmac": true, "html": true, "http": true, "imaplib": true, "importlib": true,
+	"inspect": true, "io": true, "ipaddress": true, "itertools": true, "json": true, "keyword": true,
+	"locale": true, "logging": true, "lzma": true, "math": true, "mimetypes": true, "multiprocessing": true,
+	"numbers": true, "operator": true, "os": true, "pathlib": true, "pickle": true, "platform": true,
+	"pprint": true, "queue": true, "random": true, "re": true, "secrets": true, "select": true,
+	"selectors": true, "shlex": true, "shutil": true, "signal": true, "smtplib": true, "socket": true,
+	"sqlite3": true, "ssl": true, "stat": true, "statistics": true, "string": true, "struct": true,
+	"subprocess": true, "sys": true, "sysconfig": true, "tarfile": true, "tempfile": true, "textwrap": true,
+	"threading": true, "time": true, "timeit": true, "tomllib": true, "traceback": true, "types": true,
+	"typing": true, "unicodedata": true, "unittest": true, "urllib": true, "uuid": true, "venv": true,
+	"warnings": true, "weakref": true, "xml": true, "zipfile": true, "zlib": true, "zoneinfo": true,
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestPythonSrcLayoutResolution(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "pyproject.toml", "[project]\nname = \"shop\"\n\n[tool.setuptools.packages.find]\nwhere = [\"src\"]\n")
+
+	graph := newEmptyTestGraph()
+	src := filepath.Join(dir, "src", "shop")
+	views := filepath.Join(src, "api", "views.py")
+	addTestFile(graph, filepath.Join(src, "__init__.py"), "python")
+	addTestFile(graph, filepath.Join(src, "api", "__init__.py"), "python")
+	addTestFile(graph, views, "python")
+	addTestFile(graph, filepath.Join(src, "models.py"), "python")
+	addTestFile(graph, filepath.Join(src, "services", "billing.py"), "python") // namespace package
+
+	analyzer := NewRelationshipAnalyzer(graph)
+
+	tests := []struct {
+		name       string
+		importPath string
+		specifiers []string
+		files      []string
+		pkg        string
+		external   bool
+		stdlib     bool
+	}{
+		{"absolute module", "shop.models", nil, []string{filepath.Join(src, "models.py")}, "", false, false},
+		{"regular package", "shop.api", nil, []string{filepath.Join(src, "api", "__init__.py")}, filepath.Join(src, "api"), false, false},
+		{"namespace package", "shop.services", []string{"billing"}, []string{filepath.Join(src, "services", "billing.py")}, filepath.Join(src, "services"), false, false},
+		{"relative parent module", "..models", []string{"Order"}, []string{filepath.Join(src, "models.py")}, "", false, false},
+		{"relative package import", ".", []string{"views"}, []string{views}, "", false, false},
+		{"stdlib", "os.path", nil, nil, "", true, true},
+		{"third party", "django.db", nil, nil, "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution := analyzer.resolveImport(&types.Import{Path: tt.importPath, Specifiers: tt.specifiers}, views)
+
+			if !reflect.DeepEqual(resolution.Files, tt.files) {
+				t.Errorf("Files = %v, expected %v", resolution.Files, tt.files)
+			}
+			if resolution.Package != tt.pkg {
+				t.Errorf("Package = %q, expected %q", resolution.Package, tt.pkg)
+			}
+			if resolution.External != tt.external || resolution.Stdlib != tt.stdlib {
+				t.Errorf("External/Stdlib = %v/%v, expected %v/%v", resolution.External, resolution.Stdlib, tt.external, tt.stdlib)
+			}
+		})
+	}
+}
+
+func TestPythonCircularImportDetection(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "app/__init__.py", "python")
+	addTestFile(graph, "app/orders.py", "python", "app.customers")
+	addTestFile(graph, "app/customers.py", "python", "app.orders")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{
+		ByType:       make(map[RelationshipType]int),
+		CircularDeps: make([]CircularDependency, 0),
+	}
+	analyzer.detectCircularDependencies(metrics)
+
+	if len(metrics.CircularDeps) == 0 {
+		t.Fatal("expected a circular dependency between orders and customers")
+	}
+}
+
+func TestParseSetupCfgRoots(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "setup.cfg", "[metadata]\nname = shop\n\n[options]\npackage_dir =\n    =src\n\n[options.packages.find]\nwhere = lib\n")
+
+	roots := parseSetupCfgRoots(path)
+	if !reflect.DeepEqual(roots, []string{"src", "lib"}) {
+		t.Errorf("parseSetupCfgRoots() = %v, expected [src lib]", roots)
+	}
+}
+
+func TestParsePyprojectRoots(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "pyproject.toml", `[tool.poetry]
+name = "svc"
+packages = [{ include = "svc", from = "source" }]
+
+[tool.hatch.build.targets.wheel]
+packages = ["lib/svc_extra"]
+`)
+
+	roots := parsePyprojectRoots(path)
+	if !reflect.DeepEqual(roots, []string{"source", "lib"}) {
+		t.Errorf("parsePyprojectRoots() = %v, expected [source lib]", roots)
+	}
+}
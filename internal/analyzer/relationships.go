@@ -3,6 +3,7 @@ package analyzer
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/nuthan-ms/codecontext/pkg/types"
@@ -10,14 +11,33 @@ import (
 
 // RelationshipAnalyzer analyzes various types of relationships between code elements
 type RelationshipAnalyzer struct {
-	graph *types.CodeGraph
+	graph         *types.CodeGraph
+	goModules     *goModuleResolver
+	pythonModules *pythonModuleResolver
+	javaModules   *javaModuleResolver
+	rustModules   *rustModuleResolver
+	nodeModules   *nodeModuleResolver
+
+	callableByName map[string][]*types.Symbol // Callable symbols by name, built on first call resolution
+	typesByName    map[string][]*types.Symbol // Class, interface and type symbols by name
+	symbolsByName  map[string][]*types.Symbol // Every declared symbol by name
+	symbolFiles    map[types.SymbolId]string  // Symbol -> defining file
+
+	importResolutions map[importKey]*ImportResolution // Resolved imports by importing file and import
+	filesByDir        map[string][]string             // Graph files by directory, sorted
 }
 
 // NewRelationshipAnalyzer creates a new relationship analyzer
 func NewRelationshipAnalyzer(graph *types.CodeGraph) *RelationshipAnalyzer {
-	return &RelationshipAnalyzer{
+	ra := &RelationshipAnalyzer{
 		graph: graph,
 	}
+	ra.goModules = newGoModuleResolver(ra)
+	ra.pythonModules = newPythonModuleResolver(ra)
+	ra.javaModules = newJavaModuleResolver(ra)
+	ra.rustModules = newRustModuleResolver(ra)
+	ra.nodeModules = newNodeModuleResolver(ra)
+	return ra
 }
 
 // RelationshipType represents different types of relationships
@@ -32,6 +52,7 @@ const (
 	RelationshipContains   RelationshipType = "contains"
 	RelationshipUses       RelationshipType = "uses"
 	RelationshipDepends    RelationshipType = "depends"
+	RelationshipDocuments  RelationshipType = "documents"
 )
 
 // RelationshipMetrics holds metrics about relationships
@@ -46,11 +67,13 @@ type RelationshipMetrics struct {
 	IsolatedFiles      []string                 `json:"isolated_files"`
 }
 
-// CircularDependency represents a circular dependency between files
+// CircularDependency represents a group of files or package directories that depend on each other
 type CircularDependency struct {
-	Files []string `json:"files"`
-	Path  []string `json:"path"`
-	Type  string   `json:"type"`
+	Files           []string         `json:"files"`                      // Members of the strongly connected component
+	Path            []string         `json:"path"`                       // Shortest cycle in the group
+	Type            string           `json:"type"`                       // CycleTypeImport or CycleTypePackage
+	Cycles          [][]string       `json:"cycles,omitempty"`           // Shortest cycle through each member
+	SuggestedBreaks []DependencyLink `json:"suggested_breaks,omitempty"` // Edges whose removal breaks every cycle
 }
 
 // FileHotspot represents a file with high dependency activity
@@ -79,15 +102,30 @@ func (ra *RelationshipAnalyzer) AnalyzeAllRelationships() (*RelationshipMetrics,
 	// Analyze call relationships
 	ra.analyzeCallRelationships(metrics)
 
+	// Analyze inheritance relationships
+	ra.analyzeInheritanceRelationships(metrics)
+
+	// Match Go types against interfaces by method set
+	ra.analyzeGoInterfaceSatisfaction(metrics)
+
 	// Detect circular dependencies
 	ra.detectCircularDependencies(metrics)
 
+	// Rank files and symbols by centrality
+	ra.computeCentrality()
+
+	// Roll files up into packages
+	ra.aggregatePackages()
+
 	// Identify hotspot files
 	ra.identifyHotspotFiles(metrics)
 
 	// Find isolated files
 	ra.findIsolatedFiles(metrics)
 
+	// Link external imports to the dependencies declared in manifests
+	ra.linkDependencies()
+
 	// Calculate totals
 	for _, count := range metrics.ByType {
 		metrics.TotalRelationships += count
@@ -102,27 +140,51 @@ func (ra *RelationshipAnalyzer) analyzeImportRelationships(metrics *Relationship
 
 	for filePath, fileNode := range ra.graph.Files {
 		for _, imp := range fileNode.Imports {
-			targetFile := ra.resolveImportPath(imp.Path, filePath)
-
-			if targetFile != "" {
-				// Create or update import relationship
-				edgeId := types.EdgeId(fmt.Sprintf("import-%s-%s", filePath, targetFile))
+			resolution := ra.resolveImport(imp, filePath)
+
+			if len(resolution.Files) > 0 || resolution.Package != "" {
+				for _, targetFile := range resolution.Files {
+					// Create or update import relationship
+					edgeId := types.EdgeId(fmt.Sprintf("import-%s-%s", filePath, targetFile))
+
+					if _, exists := ra.graph.Edges[edgeId]; !exists {
+						edge := &types.GraphEdge{
+							Id:     edgeId,
+							From:   types.NodeId(fmt.Sprintf("file-%s", filePath)),
+							To:     types.NodeId(fmt.Sprintf("file-%s", targetFile)),
+							Type:   string(RelationshipImport),
+							Weight: 1.0,
+							Metadata: map[string]interface{}{
+								"import_path":   imp.Path,
+								"specifiers":    imp.Specifiers,
+								"is_default":    imp.IsDefault,
+								"resolved_path": targetFile,
+							},
+						}
+						if resolution.Package != "" {
+							edge.Metadata["package"] = resolution.Package
+						}
+						ra.graph.Edges[edgeId] = edge
+					}
+				}
 
-				if _, exists := ra.graph.Edges[edgeId]; !exists {
-					edge := &types.GraphEdge{
+				// Package-level edge for languages that import whole packages
+				if resolution.Package != "" {
+					packageNode := ra.ensurePackageNode(resolution.Package, imp.Path, fileNode.Language)
+					edgeId := types.EdgeId(fmt.Sprintf("import-package-%s-%s", filePath, resolution.Package))
+					ra.graph.Edges[edgeId] = &types.GraphEdge{
 						Id:     edgeId,
 						From:   types.NodeId(fmt.Sprintf("file-%s", filePath)),
-						To:     types.NodeId(fmt.Sprintf("file-%s", targetFile)),
+						To:     packageNode,
 						Type:   string(RelationshipImport),
 						Weight: 1.0,
 						Metadata: map[string]interface{}{
-							"import_path":   imp.Path,
-							"specifiers":    imp.Specifiers,
-							"is_default":    imp.IsDefault,
-							"resolved_path": targetFile,
+							"import_path": imp.Path,
+							"alias":       imp.Alias,
+							"package":     resolution.Package,
+							"module":      resolution.Module,
 						},
 					}
-					ra.graph.Edges[edgeId] = edge
 				}
 
 				importCount++
@@ -142,6 +204,15 @@ func (ra *RelationshipAnalyzer) analyzeImportRelationships(metrics *Relationship
 						"is_external": true,
 					},
 				}
+				if resolution.Module != "" {
+					edge.Metadata["module"] = resolution.Module
+				}
+				if resolution.Version != "" {
+					edge.Metadata["module_version"] = resolution.Version
+				}
+				if resolution.Stdlib {
+					edge.Metadata["is_stdlib"] = true
+				}
 				ra.graph.Edges[edgeId] = edge
 				importCount++
 			}
@@ -169,8 +240,8 @@ func (ra *RelationshipAnalyzer) analyzeSymbolUsageRelationships(metrics *Relatio
 			references := ra.extractSymbolReferences(symbol)
 
 			for _, ref := range references {
-				targetSymbol := ra.findSymbolByName(ref.Name, ref.Context)
-				if targetSymbol != nil {
+				targetSymbol, resolution, confidence := ra.findSymbolByName(ref.Name, filePath)
+				if targetSymbol != nil && targetSymbol.Id != symbol.Id {
 					// Create reference relationship
 					edgeId := types.EdgeId(fmt.Sprintf("ref-%s-%s", symbol.Id, targetSymbol.Id))
 					edge := &types.GraphEdge{
@@ -183,12 +254,15 @@ func (ra *RelationshipAnalyzer) analyzeSymbolUsageRelationships(metrics *Relatio
 							"reference_type": ref.Type,
 							"context":        ref.Context,
 							"source_file":    filePath,
-							"target_file":    targetSymbol.FullyQualifiedName,
+							"target_file":    ra.symbolFile(targetSymbol.Id),
+							"resolution":     resolution,
+							"confidence":     confidence,
+							"ambiguous":      confidence < resolutionConfidence[resolution],
 						},
 					}
 					ra.graph.Edges[edgeId] = edge
 
-					if symbol.FullyQualifiedName != targetSymbol.FullyQualifiedName {
+					if ra.symbolFile(targetSymbol.Id) != filePath {
 						referenceCount++
 					}
 					usageCount++
@@ -203,86 +277,33 @@ func (ra *RelationshipAnalyzer) analyzeSymbolUsageRelationships(metrics *Relatio
 	metrics.CrossFileRefs += referenceCount
 }
 
-// analyzeCallRelationships analyzes function/method call relationships
+// analyzeCallRelationships resolves the call sites extracted from function bodies into calls edges
 func (ra *RelationshipAnalyzer) analyzeCallRelationships(metrics *RelationshipMetrics) {
 	callCount := 0
+	crossFileCount := 0
 
-	// For now, this is a basic implementation
-	// In a full implementation, we would parse function bodies to extract calls
-	// This requires more sophisticated AST analysis
-
-	for _, symbol := range ra.graph.Symbols {
-		if symbol.Type == types.SymbolTypeFunction || symbol.Type == types.SymbolTypeMethod {
-			// Analyze function signature for parameter types (simplified)
-			if strings.Contains(symbol.Signature, "=>") || strings.Contains(symbol.Signature, "return") {
-				// This is a placeholder for actual call analysis
-				callCount++
-			}
-		}
-	}
-
-	metrics.ByType[RelationshipCalls] = callCount
-}
-
-// detectCircularDependencies detects circular import dependencies
-func (ra *RelationshipAnalyzer) detectCircularDependencies(metrics *RelationshipMetrics) {
-	visited := make(map[string]bool)
-	recursionStack := make(map[string]bool)
-
-	for filePath := range ra.graph.Files {
-		if !visited[filePath] {
-			if cycle := ra.detectCycleDFS(filePath, visited, recursionStack, make([]string, 0)); cycle != nil {
-				metrics.CircularDeps = append(metrics.CircularDeps, CircularDependency{
-					Files: cycle,
-					Path:  cycle,
-					Type:  "import",
-				})
+	for filePath, fileNode := range ra.graph.Files {
+		for _, call := range fileNode.Calls {
+			target, resolution := ra.resolveCall(call, filePath)
+			if target == nil {
+				continue
 			}
-		}
-	}
-}
-
-// detectCycleDFS performs DFS to detect cycles in the dependency graph
-func (ra *RelationshipAnalyzer) detectCycleDFS(filePath string, visited, recursionStack map[string]bool, path []string) []string {
-	visited[filePath] = true
-	recursionStack[filePath] = true
-	path = append(path, filePath)
-
-	fileNode := ra.graph.Files[filePath]
-	if fileNode == nil {
-		return nil
-	}
-
-	for _, imp := range fileNode.Imports {
-		targetFile := ra.resolveImportPath(imp.Path, filePath)
-		if targetFile == "" {
-			continue
-		}
 
-		if !visited[targetFile] {
-			if cycle := ra.detectCycleDFS(targetFile, visited, recursionStack, path); cycle != nil {
-				return cycle
-			}
-		} else if recursionStack[targetFile] {
-			// Found a cycle
-			cycleStart := -1
-			for i, p := range path {
-				if p == targetFile {
-					cycleStart = i
-					break
+			if ra.addCallEdge(filePath, call, target, resolution) {
+				callCount++
+				if ra.symbolFile(target.Id) != filePath {
+					crossFileCount++
 				}
 			}
-			if cycleStart != -1 {
-				return append(path[cycleStart:], targetFile)
-			}
 		}
 	}
 
-	recursionStack[filePath] = false
-	return nil
+	metrics.ByType[RelationshipCalls] = callCount
+	metrics.SymbolToSymbol += callCount
+	metrics.CrossFileRefs += crossFileCount
 }
 
-// identifyHotspotFiles identifies files with high dependency activity
+// identifyHotspotFiles identifies files with high dependency activity, ranked by centrality
 func (ra *RelationshipAnalyzer) identifyHotspotFiles(metrics *RelationshipMetrics) {
 	fileScores := make(map[string]*FileHotspot)
 
@@ -311,16 +332,21 @@ func (ra *RelationshipAnalyzer) identifyHotspotFiles(metrics *RelationshipMetric
 		}
 	}
 
-	// Calculate scores and identify top hotspots
-	for _, hotspot := range fileScores {
-		// Simple scoring: imports + references * 2 (being referenced is more important)
-		hotspot.Score = float64(hotspot.ImportCount) + float64(hotspot.ReferenceCount)*2.0
+	// Score by centrality on a 0-100 scale and keep files that are imported or import several files
+	for filePath, hotspot := range fileScores {
+		hotspot.Score = FileImportance(ra.graph, filePath) * 100
 
-		// Only include files with significant activity
-		if hotspot.Score >= 2.0 {
+		if hotspot.ReferenceCount > 0 || hotspot.ImportCount >= 2 {
 			metrics.HotspotFiles = append(metrics.HotspotFiles, *hotspot)
 		}
 	}
+
+	sort.Slice(metrics.HotspotFiles, func(i, j int) bool {
+		if metrics.HotspotFiles[i].Score != metrics.HotspotFiles[j].Score {
+			return metrics.HotspotFiles[i].Score > metrics.HotspotFiles[j].Score
+		}
+		return metrics.HotspotFiles[i].FilePath < metrics.HotspotFiles[j].FilePath
+	})
 }
 
 // findIsolatedFiles finds files with no dependencies
@@ -437,14 +463,16 @@ func (ra *RelationshipAnalyzer) isBuiltinType(typeName string) bool {
 	return false
 }
 
-// findSymbolByName finds a symbol by name within a context
-func (ra *RelationshipAnalyzer) findSymbolByName(name, context string) *types.Symbol {
-	for _, symbol := range ra.graph.Symbols {
-		if symbol.Name == name {
-			return symbol
-		}
+// findSymbolByName resolves a name referenced from fromFile through the file's scope, its imports
+// and its package before falling back to a global match. The confidence reflects the strategy that
+// matched and drops with the number of candidates when the global match is ambiguous.
+func (ra *RelationshipAnalyzer) findSymbolByName(name, fromFile string) (*types.Symbol, string, float64) {
+	qualifier, symbolName := splitReferenceName(name)
+	symbol, resolution, candidates := ra.resolveScopedName(symbolName, qualifier, fromFile, ra.declaredSymbolsNamed)
+	if symbol == nil {
+		return nil, "", 0
 	}
-	return nil
+	return symbol, resolution, resolutionConfidence[resolution] / float64(candidates)
 }
 
 // extractFileFromNodeId extracts the file path from a node ID
@@ -458,8 +486,17 @@ func (ra *RelationshipAnalyzer) extractFileFromNodeId(nodeId types.NodeId) strin
 
 // resolveImportPath resolves an import path to an actual file path
 func (ra *RelationshipAnalyzer) resolveImportPath(importPath, fromFile string) string {
+	resolution := ra.resolveImport(&types.Import{Path: importPath}, fromFile)
+	if len(resolution.Files) == 0 {
+		return ""
+	}
+	return resolution.Files[0]
+}
+
+// resolveRelativeImport resolves a relative JavaScript/TypeScript import to a file path
+func (ra *RelationshipAnalyzer) resolveRelativeImport(importPath, fromFile string) string {
 	// Handle relative imports
-	if strings.HasPrefix(importPath, "./") || strings.HasPrefix(importPath, "../") {
+	if isRelativeImport(importPath) {
 		dir := filepath.Dir(fromFile)
 		resolved := filepath.Join(dir, importPath)
 
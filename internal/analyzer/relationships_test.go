@@ -470,3 +470,75 @@ func TestResolveImportPath(t *testing.T) {
 		})
 	}
 }
+
+func TestFindSymbolByNameScoping(t *testing.T) {
+	graph := newEmptyTestGraph()
+
+	addTestFile(graph, "src/app.ts", "typescript", "./models")
+	graph.Files["src/app.ts"].Imports[0].Specifiers = []string{"Config"}
+	addTestFile(graph, "src/models.ts", "typescript")
+	addTestFile(graph, "src/other.ts", "typescript")
+	addTestFile(graph, "src/local.ts", "typescript")
+	addTestFile(graph, "src/x.ts", "typescript")
+	addTestFile(graph, "src/y.ts", "typescript")
+	addTestFile(graph, "pkg/a/a.go", "go")
+	addTestFile(graph, "pkg/a/b.go", "go")
+	addTestFile(graph, "pkg/c/c.go", "go")
+
+	importedConfig := addTestSymbol(graph, "src/models.ts", "Config", types.SymbolTypeClass, 1)
+	addTestSymbol(graph, "src/other.ts", "Config", types.SymbolTypeClass, 1)
+	localConfig := addTestSymbol(graph, "src/local.ts", "Config", types.SymbolTypeClass, 3)
+	helperX := addTestSymbol(graph, "src/x.ts", "Helper", types.SymbolTypeClass, 1)
+	addTestSymbol(graph, "src/y.ts", "Helper", types.SymbolTypeClass, 1)
+	widget := addTestSymbol(graph, "src/y.ts", "Widget", types.SymbolTypeClass, 5)
+	packageOptions := addTestSymbol(graph, "pkg/a/b.go", "Options", types.SymbolTypeType, 1)
+	addTestSymbol(graph, "pkg/c/c.go", "Options", types.SymbolTypeType, 1)
+
+	run := addTestSymbol(graph, "src/app.ts", "run", types.SymbolTypeFunction, 10)
+	run.Signature = "(config: Config, helper: Helper)"
+
+	analyzer := NewRelationshipAnalyzer(graph)
+
+	tests := []struct {
+		name       string
+		reference  string
+		fromFile   string
+		expected   *types.Symbol
+		resolution string
+		confidence float64
+	}{
+		{"imported specifier wins over other files", "Config", "src/app.ts", importedConfig, CallResolutionImport, 0.9},
+		{"file scope", "Config", "src/local.ts", localConfig, CallResolutionLocal, 1.0},
+		{"go package scope", "Options", "pkg/a/a.go", packageOptions, CallResolutionPackage, 0.8},
+		{"unique global name", "Widget[]", "src/app.ts", widget, CallResolutionGlobal, 0.5},
+		{"ambiguous global name", "Helper", "src/app.ts", helperX, CallResolutionGlobal, 0.25},
+		{"unknown name", "Missing", "src/app.ts", nil, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symbol, resolution, confidence := analyzer.findSymbolByName(tt.reference, tt.fromFile)
+			if symbol != tt.expected {
+				t.Fatalf("findSymbolByName(%s) = %v, expected %v", tt.reference, symbol, tt.expected)
+			}
+			if resolution != tt.resolution || confidence != tt.confidence {
+				t.Errorf("resolution = %s (%.2f), expected %s (%.2f)", resolution, confidence, tt.resolution, tt.confidence)
+			}
+		})
+	}
+
+	metrics := &RelationshipMetrics{ByType: make(map[RelationshipType]int)}
+	analyzer.analyzeSymbolUsageRelationships(metrics)
+
+	edge := graph.Edges[types.EdgeId("ref-"+string(run.Id)+"-"+string(importedConfig.Id))]
+	if edge == nil {
+		t.Fatalf("expected reference edge from run to the imported Config")
+	}
+	if edge.Metadata["confidence"] != 0.9 || edge.Metadata["ambiguous"] != false {
+		t.Errorf("unexpected edge metadata: %v", edge.Metadata)
+	}
+	ambiguous := graph.Edges[types.EdgeId("ref-"+string(run.Id)+"-"+string(helperX.Id))]
+	if ambiguous == nil || ambiguous.Metadata["ambiguous"] != true {
+		t.Errorf("expected an ambiguous reference edge to Helper, got %v", ambiguous)
+	}
+}
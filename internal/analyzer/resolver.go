@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// ImportResolution describes what an import statement points at
+type ImportResolution struct {
+	Files    []string `json:"files,omitempty"`   // Internal files the import resolves to
+	Package  string   `json:"package,omitempty"` // Package directory for package-level imports
+	External bool     `json:"external"`          // Import points outside the analyzed tree
+	Module   string   `json:"module,omitempty"`  // Module that provides the import
+	Version  string   `json:"version,omitempty"` // Declared version of the providing module
+	Stdlib   bool     `json:"stdlib,omitempty"`  // Import belongs to the language standard library
+}
+
+// importKey identifies an import of a file for caching its resolution
+type importKey struct {
+	fromFile   string
+	path       string
+	specifiers string
+}
+
+// resolveImport resolves an import of fromFile using the resolver for the file's language. Call and
+// name resolution ask for the same imports over and over, so each resolution is computed once.
+func (ra *RelationshipAnalyzer) resolveImport(imp *types.Import, fromFile string) *ImportResolution {
+	key := importKey{fromFile: fromFile, path: imp.Path, specifiers: strings.Join(imp.Specifiers, ",")}
+	if resolution, exists := ra.importResolutions[key]; exists {
+		return resolution
+	}
+	if ra.importResolutions == nil {
+		ra.importResolutions = make(map[importKey]*ImportResolution)
+	}
+	resolution := ra.resolveLanguageImport(imp, fromFile)
+	ra.importResolutions[key] = resolution
+	return resolution
+}
+
+// resolveLanguageImport dispatches an import of fromFile to the resolver for the file's language
+func (ra *RelationshipAnalyzer) resolveLanguageImport(imp *types.Import, fromFile string) *ImportResolution {
+	language := ""
+	if fileNode := ra.graph.Files[fromFile]; fileNode != nil {
+		language = fileNode.Language
+	}
+
+	switch language {
+	case "go":
+		return ra.goModules.Resolve(imp.Path, fromFile)
+	case "python":
+		return ra.pythonModules.Resolve(imp.Path, imp.Specifiers, fromFile)
+	case "java":
+		return ra.javaModules.Resolve(imp.Path, imp.Specifiers, fromFile)
+	case "rust":
+		return ra.rustModules.Resolve(imp.Path, fromFile)
+	case "javascript", "typescript":
+		return ra.nodeModules.Resolve(imp.Path, fromFile)
+	default:
+		if target := ra.resolveRelativeImport(imp.Path, fromFile); target != "" {
+			return &ImportResolution{Files: []string{target}}
+		}
+		return &ImportResolution{External: true, Module: imp.Path}
+	}
+}
+
+// packageNodeId returns the graph node id used for a package directory
+func packageNodeId(dir string) types.NodeId {
+	return types.NodeId(fmt.Sprintf("package-%s", dir))
+}
+
+// ensurePackageNode adds a package node for dir to the graph if it is missing
+func (ra *RelationshipAnalyzer) ensurePackageNode(dir, importPath, language string) types.NodeId {
+	nodeId := packageNodeId(dir)
+	if _, exists := ra.graph.Nodes[nodeId]; exists {
+		return nodeId
+	}
+
+	label := importPath
+	if label == "" {
+		label = filepath.Base(dir)
+	}
+
+	ra.graph.Nodes[nodeId] = &types.GraphNode{
+		Id:       nodeId,
+		Type:     "package",
+		Label:    label,
+		FilePath: dir,
+		Metadata: map[string]interface{}{
+			"import_path": importPath,
+			"language":    language,
+		},
+	}
+	return nodeId
+}
+
+// filesInDir returns the graph files of a language located directly in dir
+func (ra *RelationshipAnalyzer) filesInDir(dir, language string, include func(string) bool) []string {
+	if ra.filesByDir == nil {
+		ra.filesByDir = make(map[string][]string)
+		for filePath := range ra.graph.Files {
+			ra.filesByDir[filepath.Dir(filePath)] = append(ra.filesByDir[filepath.Dir(filePath)], filePath)
+		}
+		for _, files := range ra.filesByDir {
+			sort.Strings(files)
+		}
+	}
+
+	files := make([]string, 0)
+	for _, filePath := range ra.filesByDir[dir] {
+		if ra.graph.Files[filePath].Language != language {
+			continue
+		}
+		if include != nil && !include(filePath) {
+			continue
+		}
+		files = append(files, filePath)
+	}
+	return files
+}
+
+// isRelativeImport reports whether an import path is relative to the importing file
+func isRelativeImport(importPath string) bool {
+	return strings.HasPrefix(importPath, "./") || strings.HasPrefix(importPath, "../")
+}
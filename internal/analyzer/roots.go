@@ -0,0 +1,209 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// RootSummary holds the size of one source root and the roots its files import
+type RootSummary struct {
+	Label     string         `json:"label"`
+	Path      string         `json:"path"`
+	Files     int            `json:"files"`
+	Symbols   int            `json:"symbols"`
+	Lines     int            `json:"lines"`
+	Languages map[string]int `json:"languages"`
+	ImportsTo map[string]int `json:"imports_to"` // Root label -> files in this root importing from it
+}
+
+// ParseSourceRoot parses a root given as "label=path" or as a bare path labeled by its base name
+func ParseSourceRoot(spec string) types.SourceRoot {
+	if label, path, found := strings.Cut(spec, "="); found && label != "" && path != "" {
+		return types.SourceRoot{Label: label, Path: path}
+	}
+	return types.SourceRoot{Path: spec}
+}
+
+// NormalizeSourceRoots cleans root paths, labels unlabeled roots after their directory and rejects
+// empty lists and duplicate labels
+func NormalizeSourceRoots(roots []types.SourceRoot) ([]types.SourceRoot, error) {
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no source roots to analyze")
+	}
+
+	normalized := make([]types.SourceRoot, 0, len(roots))
+	labels := make(map[string]bool)
+	for _, root := range roots {
+		root.Path = filepath.Clean(root.Path)
+		if root.Label == "" {
+			root.Label = filepath.Base(root.Path)
+			if absolute, err := filepath.Abs(root.Path); err == nil && (root.Label == "." || root.Label == "..") {
+				root.Label = filepath.Base(absolute)
+			}
+		}
+		if labels[root.Label] {
+			return nil, fmt.Errorf("duplicate source root label %q; label roots as label=path", root.Label)
+		}
+		labels[root.Label] = true
+		normalized = append(normalized, root)
+	}
+	return normalized, nil
+}
+
+// rootOf returns the label of the graph root containing filePath, or "" in single-root analysis
+func rootOf(graph *types.CodeGraph, filePath string) string {
+	if graph == nil || graph.Metadata == nil {
+		return ""
+	}
+	for _, root := range graph.Metadata.Roots {
+		if withinDir(filePath, root.Path) {
+			return root.Label
+		}
+	}
+	return ""
+}
+
+// withinDir reports whether path is dir or lies below it
+func withinDir(path, dir string) bool {
+	if dir == "." {
+		return !filepath.IsAbs(path) && path != ".." && !strings.HasPrefix(path, ".."+string(filepath.Separator))
+	}
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// isMultiRoot reports whether the graph was analyzed from more than one source root
+func isMultiRoot(graph *types.CodeGraph) bool {
+	return graph.Metadata != nil && len(graph.Metadata.Roots) > 1
+}
+
+// SourceRoots summarizes each source root of a multi-root graph in configuration order, including
+// the imports that cross from one root into another. It returns nil when the graph was analyzed from
+// a single directory.
+func SourceRoots(graph *types.CodeGraph) []RootSummary {
+	if graph.Metadata == nil || len(graph.Metadata.Roots) == 0 {
+		return nil
+	}
+
+	summaries := make([]RootSummary, 0, len(graph.Metadata.Roots))
+	byLabel := make(map[string]*RootSummary)
+	for _, root := range graph.Metadata.Roots {
+		summaries = append(summaries, RootSummary{
+			Label:     root.Label,
+			Path:      root.Path,
+			Languages: make(map[string]int),
+			ImportsTo: make(map[string]int),
+		})
+	}
+	for i := range summaries {
+		byLabel[summaries[i].Label] = &summaries[i]
+	}
+
+	for _, fileNode := range graph.Files {
+		summary := byLabel[fileNode.Root]
+		if summary == nil {
+			continue
+		}
+		summary.Files++
+		summary.Symbols += len(fileNode.Symbols)
+		summary.Lines += fileNode.Lines
+		summary.Languages[fileNode.Language]++
+	}
+
+	// Distinct importing files per target root; package imports also produce file edges
+	importers := make(map[[2]string]bool)
+	for _, edge := range graph.Edges {
+		if edge.Type != string(RelationshipImport) {
+			continue
+		}
+		source := strings.TrimPrefix(string(edge.From), "file-")
+		from, to := edgeRoot(graph, edge.From), edgeRoot(graph, edge.To)
+		if from == "" || to == "" || from == to || byLabel[from] == nil || importers[[2]string{source, to}] {
+			continue
+		}
+		importers[[2]string{source, to}] = true
+		byLabel[from].ImportsTo[to]++
+	}
+	return summaries
+}
+
+// edgeRoot returns the root label of the file or package an import edge endpoint refers to
+func edgeRoot(graph *types.CodeGraph, nodeId types.NodeId) string {
+	if node := graph.Nodes[nodeId]; node != nil && node.Type == "package" {
+		return rootOf(graph, node.FilePath)
+	}
+	filePath := strings.TrimPrefix(string(nodeId), "file-")
+	if fileNode := graph.Files[filePath]; fileNode != nil {
+		return fileNode.Root
+	}
+	return ""
+}
+
+// RootGraph returns the part of a multi-root graph belonging to one root: its files, their symbols,
+// its packages and dependencies, and the nodes and edges among them. Imports of other roots are
+// dropped, so each map stands alone.
+func RootGraph(graph *types.CodeGraph, label string) (*types.CodeGraph, error) {
+	var root *types.SourceRoot
+	if graph.Metadata != nil {
+		for i := range graph.Metadata.Roots {
+			if graph.Metadata.Roots[i].Label == label {
+				root = &graph.Metadata.Roots[i]
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("unknown source root %q", label)
+	}
+
+	metadata := *graph.Metadata
+	metadata.ProjectPath = root.Path
+	metadata.Roots = []types.SourceRoot{*root}
+	metadata.Languages = make(map[string]int)
+	subgraph := &types.CodeGraph{
+		Nodes:    make(map[types.NodeId]*types.GraphNode),
+		Edges:    make(map[types.EdgeId]*types.GraphEdge),
+		Files:    make(map[string]*types.FileNode),
+		Symbols:  make(map[types.SymbolId]*types.Symbol),
+		Metadata: &metadata,
+	}
+
+	for filePath, fileNode := range graph.Files {
+		if fileNode.Root != label {
+			continue
+		}
+		subgraph.Files[filePath] = fileNode
+		metadata.Languages[fileNode.Language]++
+		for _, symbolId := range fileNode.Symbols {
+			if symbol := graph.Symbols[symbolId]; symbol != nil {
+				subgraph.Symbols[symbolId] = symbol
+			}
+		}
+	}
+	for nodeId, node := range graph.Nodes {
+		if subgraph.Files[node.FilePath] != nil || ((node.Type == "package" || node.Type == "dependency") && rootOf(graph, node.FilePath) == label) {
+			subgraph.Nodes[nodeId] = node
+		}
+	}
+	for edgeId, edge := range graph.Edges {
+		if subgraph.Nodes[edge.From] != nil && subgraph.Nodes[edge.To] != nil {
+			subgraph.Edges[edgeId] = edge
+		}
+	}
+
+	metadata.TotalFiles = len(subgraph.Files)
+	metadata.TotalSymbols = len(subgraph.Symbols)
+	return subgraph, nil
+}
+
+// rootLabels returns the root labels of an ImportsTo map in sorted order
+func rootLabels(imports map[string]int) []string {
+	labels := make([]string, 0, len(imports))
+	for label := range imports {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestNormalizeSourceRoots(t *testing.T) {
+	roots, err := NormalizeSourceRoots([]types.SourceRoot{
+		ParseSourceRoot("../backend/"),
+		ParseSourceRoot("web=../frontend"),
+	})
+	if err != nil {
+		t.Fatalf("NormalizeSourceRoots() error = %v", err)
+	}
+	expected := []types.SourceRoot{{Label: "backend", Path: "../backend"}, {Label: "web", Path: "../frontend"}}
+	if !reflect.DeepEqual(roots, expected) {
+		t.Errorf("NormalizeSourceRoots() = %+v, expected %+v", roots, expected)
+	}
+
+	if _, err := NormalizeSourceRoots([]types.SourceRoot{{Path: "a/api"}, {Path: "b/api"}}); err == nil {
+		t.Error("expected an error for duplicate labels")
+	}
+	if _, err := NormalizeSourceRoots(nil); err == nil {
+		t.Error("expected an error without roots")
+	}
+}
+
+func TestMultiRootResolution(t *testing.T) {
+	dir := t.TempDir()
+	backend, shared, frontend, uikit := filepath.Join(dir, "backend"), filepath.Join(dir, "shared"), filepath.Join(dir, "frontend"), filepath.Join(dir, "uikit")
+	writeTestFile(t, backend, "go.mod", "module example.com/backend\n\ngo 1.22\n")
+	writeTestFile(t, shared, "go.mod", "module example.com/shared\n\ngo 1.22\n")
+	writeTestFile(t, frontend, "package.json", `{"name": "web", "dependencies": {"ui-kit": "^2.0.0"}}`)
+	writeTestFile(t, uikit, "package.json", `{"name": "ui-kit", "version": "2.1.0", "source": "src/index.ts"}`)
+
+	graph := newEmptyTestGraph()
+	graph.Metadata.Roots = []types.SourceRoot{
+		{Label: "backend", Path: backend},
+		{Label: "shared", Path: shared},
+		{Label: "web", Path: frontend},
+		{Label: "uikit", Path: uikit},
+	}
+	api := filepath.Join(backend, "api", "api.go")
+	lib := filepath.Join(shared, "lib", "lib.go")
+	page := filepath.Join(frontend, "src", "page.ts")
+	render := filepath.Join(uikit, "src", "index.ts")
+	addTestFile(graph, api, "go", "example.com/shared/lib", "github.com/spf13/cobra").Root = "backend"
+	addTestFile(graph, lib, "go").Root = "shared"
+	addTestFile(graph, page, "typescript", "ui-kit").Root = "web"
+	addTestFile(graph, render, "typescript").Root = "uikit"
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	if resolution := analyzer.goModules.Resolve("example.com/shared/lib", api); !reflect.DeepEqual(resolution.Files, []string{lib}) {
+		t.Errorf("Go import across roots resolved to %+v, expected %s", resolution, lib)
+	}
+	if resolution := analyzer.nodeModules.Resolve("ui-kit", page); !reflect.DeepEqual(resolution.Files, []string{render}) || resolution.Version != "2.1.0" {
+		t.Errorf("package import across roots resolved to %+v, expected %s", resolution, render)
+	}
+
+	if _, err := analyzer.AnalyzeAllRelationships(); err != nil {
+		t.Fatalf("AnalyzeAllRelationships() error = %v", err)
+	}
+	roots := SourceRoots(graph)
+	if len(roots) != 4 || roots[0].Label != "backend" || roots[0].Files != 1 {
+		t.Fatalf("unexpected source roots: %+v", roots)
+	}
+	if !reflect.DeepEqual(roots[0].ImportsTo, map[string]int{"shared": 1}) || !reflect.DeepEqual(roots[2].ImportsTo, map[string]int{"uikit": 1}) {
+		t.Errorf("unexpected cross-root imports: backend %v, web %v", roots[0].ImportsTo, roots[2].ImportsTo)
+	}
+
+	content := NewMarkdownGenerator(graph).GenerateContextMap()
+	if !strings.Contains(content, "4 source roots analyzed together.") || !strings.Contains(content, "| `backend` | `"+backend+"` | 1 | 0 | 0 | `shared` (1) |") {
+		t.Errorf("unexpected source roots section:\n%s", content)
+	}
+
+	// A root's own graph keeps only its files and the edges among them
+	web, err := RootGraph(graph, "web")
+	if err != nil {
+		t.Fatalf("RootGraph() error = %v", err)
+	}
+	if len(web.Files) != 1 || web.Files[page] == nil || web.Metadata.TotalFiles != 1 || web.Metadata.ProjectPath != frontend {
+		t.Errorf("unexpected web root graph: %d files, metadata %+v", len(web.Files), web.Metadata)
+	}
+	for _, edge := range web.Edges {
+		if web.Nodes[edge.To] == nil {
+			t.Errorf("edge %s leaves the root graph", edge.Id)
+		}
+	}
+	if strings.Contains(NewMarkdownGenerator(web).GenerateContextMap(), "Source Roots") {
+		t.Error("a single root's map should not list source roots")
+	}
+	if _, err := RootGraph(graph, "missing"); err == nil {
+		t.Error("expected an error for an unknown root")
+	}
+}
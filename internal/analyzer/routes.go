@@ -0,0 +1,564 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Route is an HTTP route registered with a web framework
+type Route struct {
+	Method    string         `json:"method"` // Upper-case HTTP method, ANY when the route accepts every method
+	Path      string         `json:"path"`   // Path as registered, including the prefixes of enclosing groups
+	Framework string         `json:"framework"`
+	Handler   string         `json:"handler,omitempty"`    // Handler expression, empty for inline handlers
+	HandlerId types.SymbolId `json:"handler_id,omitempty"` // Symbol the handler resolves to
+	File      string         `json:"file"`
+	Line      int            `json:"line"`
+}
+
+// routeFrameworks maps import paths, or their prefixes, to the web framework they provide per language
+var routeFrameworks = map[string][]struct {
+	Import    string
+	Framework string
+}{
+	"go": {
+		{"github.com/gin-gonic/gin", "gin"},
+		{"github.com/labstack/echo", "echo"},
+		{"github.com/go-chi/chi", "chi"},
+		{"github.com/gofiber/fiber", "fiber"},
+		{"github.com/gorilla/mux", "gorilla"},
+		{"net/http", "net/http"},
+	},
+	"javascript": {
+		{"express", "express"},
+		{"fastify", "fastify"},
+		{"@koa/router", "koa"},
+		{"koa-router", "koa"},
+		{"hono", "hono"},
+	},
+	"python": {
+		{"fastapi", "fastapi"},
+		{"flask", "flask"},
+	},
+	"java": {
+		{"org.springframework.web", "spring"},
+	},
+}
+
+// routeMethods lists the HTTP methods route registrations name
+var routeMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+var (
+	// goRoutePattern matches gin, echo, chi, fiber and net/http registrations such as r.GET("/users", h)
+	goRoutePattern = regexp.MustCompile(`\b(\w+)\.(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS|Any|Get|Post|Put|Patch|Delete|Head|Options|All|Handle|HandleFunc)\(\s*"([^"]*)"`)
+	// goMethodRoutePattern matches chi's r.Method("GET", "/users", h)
+	goMethodRoutePattern = regexp.MustCompile(`\b(\w+)\.(?:Method|MethodFunc)\(\s*"(\w+)"\s*,\s*"([^"]*)"`)
+	// goGroupPattern matches route groups such as api := r.Group("/api") and gorilla's PathPrefix
+	goGroupPattern = regexp.MustCompile(`\b(\w+)\s*:?=\s*(\w+)\.(?:Group|PathPrefix)\(\s*"([^"]*)"`)
+	// gorillaMethodsPattern matches the .Methods("GET") gorilla chains after a registration
+	gorillaMethodsPattern = regexp.MustCompile(`^\s*\.Methods\(([^)]*)\)`)
+
+	// jsRoutePattern matches Express, Koa and Hono registrations such as app.get('/users', handler)
+	jsRoutePattern = regexp.MustCompile("\\b(\\w+)\\.(get|post|put|patch|delete|head|options|all)\\(\\s*['\"`](/[^'\"`]*)['\"`]")
+	// fastifyRoutePattern matches fastify.route({ method: 'GET', url: '/users', handler })
+	fastifyRoutePattern = regexp.MustCompile(`\b(\w+)\.route\(\s*\{([^}]*)\}`)
+	// fastifyURLPattern, fastifyMethodPattern and fastifyHandlerPattern read fastify route options
+	fastifyURLPattern     = regexp.MustCompile("\\burl\\s*:\\s*['\"`]([^'\"`]*)")
+	fastifyMethodPattern  = regexp.MustCompile(`\bmethod\s*:\s*(\[[^\]]*\]|['"][^'"]*['"])`)
+	fastifyHandlerPattern = regexp.MustCompile(`(?:^|,)\s*handler\s*(?::\s*([\w$.]+)\s*)?(?:,|$)`)
+
+	// pythonRoutePattern matches Flask and FastAPI decorators such as @app.get("/users")
+	pythonRoutePattern = regexp.MustCompile(`(?m)^[ \t]*@(\w+)\.(route|get|post|put|patch|delete|head|options|api_route|websocket)\(\s*(?:path\s*=\s*|rule\s*=\s*)?["']([^"']*)["']([^\n]*)`)
+	// pythonRouterPattern matches Blueprints and APIRouters declaring a prefix
+	pythonRouterPattern = regexp.MustCompile(`(?m)^(\w+)\s*=\s*(?:\w+\.)?(?:Blueprint|APIRouter)\(([^\n]*)\)`)
+	// pythonPrefixPattern extracts url_prefix= or prefix= from router arguments
+	pythonPrefixPattern = regexp.MustCompile(`\b(?:url_)?prefix\s*=\s*["']([^"']*)["']`)
+	// pythonDefPattern matches the function a decorator applies to
+	pythonDefPattern = regexp.MustCompile(`(?m)^[ \t]*(?:async[ \t]+)?def[ \t]+(\w+)`)
+
+	// springMappingPattern matches Spring mapping annotations
+	springMappingPattern = regexp.MustCompile(`@(RequestMapping|GetMapping|PostMapping|PutMapping|PatchMapping|DeleteMapping)\b(?:\s*\(([^)]*)\))?`)
+	// springPathPattern extracts the path of a mapping annotation
+	springPathPattern = regexp.MustCompile(`^\s*\{?\s*"([^"]*)"|\b(?:value|path)\s*=\s*\{?\s*"([^"]*)"`)
+	// javaAnnotationsPattern matches the annotations and modifiers preceding a declaration
+	javaAnnotationsPattern = regexp.MustCompile(`^(\s*@\w+(\s*\([^)]*\))?)*\s*`)
+	// springMethodPattern matches the methods of @RequestMapping(method = RequestMethod.GET)
+	springMethodPattern = regexp.MustCompile(`RequestMethod\.(\w+)`)
+	// javaDeclarationPattern matches a class or the name of a method in a declaration
+	javaDeclarationPattern = regexp.MustCompile(`\b(?:class|interface)\s+\w+|(\w+)\s*\(`)
+
+	// methodsListPattern matches the quoted methods of methods=["GET", "POST"]
+	methodsListPattern = regexp.MustCompile(`\bmethods\s*=\s*[\[(]([^\])]*)[\])]`)
+	// quotedPattern matches quoted strings
+	quotedPattern = regexp.MustCompile(`["']([^"']*)["']`)
+	// handlerExpressionPattern matches handler names such as list, h.list or handlers.GetUser
+	handlerExpressionPattern = regexp.MustCompile(`^[\w$]+(\.[\w$]+)*$`)
+	// handlerWrapperPattern matches handlers wrapped in a call such as http.HandlerFunc(list)
+	handlerWrapperPattern = regexp.MustCompile(`^[\w$.]+\((.*)\)$`)
+)
+
+// FindRoutes lists the HTTP routes registered in non-test files: Express, Fastify, Koa and Hono
+// routes, gin, echo, chi, fiber, gorilla and net/http registrations, Flask and FastAPI decorators,
+// Spring request mappings and Next.js pages and API routes. Routes are sorted by path and method.
+func FindRoutes(graph *types.CodeGraph) []Route {
+	ra := NewRelationshipAnalyzer(graph)
+	routes := make([]Route, 0)
+
+	for filePath, fileNode := range graph.Files {
+		if fileNode.IsTest || fileNode.IsGenerated {
+			continue
+		}
+		routes = append(routes, nextJSRoutes(graph, filePath, fileNode)...)
+
+		framework := routeFramework(fileNode)
+		if framework == "" {
+			continue
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			continue
+		}
+		content := string(data)
+
+		var found []Route
+		switch fileNode.Language {
+		case "go":
+			found = goRoutes(content, framework)
+		case "javascript", "typescript":
+			found = jsRoutes(content, framework)
+		case "python":
+			found = pythonRoutes(content, framework)
+		case "java":
+			found = springRoutes(content)
+		}
+		for _, route := range found {
+			route.File = filePath
+			route.HandlerId = ra.routeHandler(filePath, route.Handler)
+			routes = append(routes, route)
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		if routes[i].Method != routes[j].Method {
+			return routes[i].Method < routes[j].Method
+		}
+		if routes[i].File != routes[j].File {
+			return routes[i].File < routes[j].File
+		}
+		return routes[i].Line < routes[j].Line
+	})
+	return routes
+}
+
+// routeFramework returns the web framework a file imports, or "" when it imports none
+func routeFramework(fileNode *types.FileNode) string {
+	language := fileNode.Language
+	if language == "typescript" {
+		language = "javascript"
+	}
+	for _, candidate := range routeFrameworks[language] {
+		for _, imp := range fileNode.Imports {
+			if imp.Path == candidate.Import || strings.HasPrefix(imp.Path, candidate.Import+"/") || strings.HasPrefix(imp.Path, candidate.Import+".") {
+				return candidate.Framework
+			}
+		}
+	}
+	return ""
+}
+
+// goRoutes extracts the routes a Go file registers, joining the prefixes of route groups
+func goRoutes(content, framework string) []Route {
+	prefixes := make(map[string]string)
+	for _, match := range goGroupPattern.FindAllStringSubmatch(content, -1) {
+		prefixes[match[1]] = prefixes[match[2]] + match[3]
+	}
+
+	routes := make([]Route, 0)
+	for _, match := range goRoutePattern.FindAllStringSubmatchIndex(content, -1) {
+		receiver, registration, path := content[match[2]:match[3]], content[match[4]:match[5]], content[match[6]:match[7]]
+		if !goRegistrationAllowed(framework, registration) {
+			continue
+		}
+		method := strings.ToUpper(registration)
+		arguments, end := routeArguments(content[match[1]:])
+		switch registration {
+		case "Any", "All":
+			method = "ANY"
+		case "Handle", "HandleFunc":
+			method = "ANY"
+			// Go 1.22 patterns lead with the method: "GET /users/{id}"
+			if pattern, patternPath, ok := strings.Cut(path, " "); ok && strings.ToUpper(pattern) == pattern {
+				method, path = pattern, strings.TrimSpace(patternPath)
+			}
+			if methods := gorillaMethodsPattern.FindStringSubmatch(content[match[1]+end:]); methods != nil {
+				method = strings.Join(quotedValues(methods[1]), ",")
+			}
+		}
+		if !strings.HasPrefix(path, "/") {
+			continue
+		}
+		routes = append(routes, Route{
+			Method:    method,
+			Path:      prefixes[receiver] + path,
+			Framework: framework,
+			Handler:   handlerExpression(arguments),
+			Line:      lineOf(content, match[0]),
+		})
+	}
+	for _, match := range goMethodRoutePattern.FindAllStringSubmatchIndex(content, -1) {
+		arguments, _ := routeArguments(content[match[1]:])
+		routes = append(routes, Route{
+			Method:    strings.ToUpper(content[match[4]:match[5]]),
+			Path:      prefixes[content[match[2]:match[3]]] + content[match[6]:match[7]],
+			Framework: framework,
+			Handler:   handlerExpression(arguments),
+			Line:      lineOf(content, match[0]),
+		})
+	}
+	return routes
+}
+
+// goRegistrationAllowed reports whether a framework registers routes with a method name: gin and
+// echo use GET, chi and fiber use Get, and every router accepts Handle and HandleFunc
+func goRegistrationAllowed(framework, registration string) bool {
+	if registration == "Handle" || registration == "HandleFunc" {
+		return true
+	}
+	switch framework {
+	case "gin", "echo":
+		return registration == "Any" || registration == strings.ToUpper(registration)
+	case "chi", "fiber":
+		return registration == "All" || registration != strings.ToUpper(registration)
+	default:
+		return false
+	}
+}
+
+// jsRoutes extracts the routes a JavaScript or TypeScript file registers
+func jsRoutes(content, framework string) []Route {
+	routes := make([]Route, 0)
+	for _, match := range jsRoutePattern.FindAllStringSubmatchIndex(content, -1) {
+		method := strings.ToUpper(content[match[4]:match[5]])
+		if method == "ALL" {
+			method = "ANY"
+		}
+		arguments, _ := routeArguments(content[match[1]:])
+		routes = append(routes, Route{
+			Method:    method,
+			Path:      content[match[6]:match[7]],
+			Framework: framework,
+			Handler:   handlerExpression(arguments),
+			Line:      lineOf(content, match[0]),
+		})
+	}
+	for _, match := range fastifyRoutePattern.FindAllStringSubmatchIndex(content, -1) {
+		options := content[match[4]:match[5]]
+		url := fastifyURLPattern.FindStringSubmatch(options)
+		if url == nil {
+			continue
+		}
+		method := "ANY"
+		if methods := fastifyMethodPattern.FindStringSubmatch(options); methods != nil {
+			method = strings.ToUpper(strings.Join(quotedValues(methods[1]), ","))
+		}
+		// handler: list names the handler, a bare handler is shorthand for handler: handler
+		handler := ""
+		if named := fastifyHandlerPattern.FindStringSubmatch(strings.TrimSpace(options)); named != nil {
+			handler = named[1]
+			if handler == "" {
+				handler = "handler"
+			}
+		}
+		routes = append(routes, Route{Method: method, Path: url[1], Framework: framework, Handler: handler, Line: lineOf(content, match[0])})
+	}
+	return routes
+}
+
+// pythonRoutes extracts the routes Flask and FastAPI decorators declare, joining router prefixes
+func pythonRoutes(content, framework string) []Route {
+	prefixes := make(map[string]string)
+	for _, match := range pythonRouterPattern.FindAllStringSubmatch(content, -1) {
+		if prefix := pythonPrefixPattern.FindStringSubmatch(match[2]); prefix != nil {
+			prefixes[match[1]] = prefix[1]
+		}
+	}
+
+	routes := make([]Route, 0)
+	for _, match := range pythonRoutePattern.FindAllStringSubmatchIndex(content, -1) {
+		decorator, rest := content[match[4]:match[5]], content[match[8]:match[9]]
+		var method string
+		switch decorator {
+		case "route", "api_route":
+			method = "GET"
+			if methods := methodsListPattern.FindStringSubmatch(rest); methods != nil {
+				method = strings.ToUpper(strings.Join(quotedValues(methods[1]), ","))
+			}
+		case "websocket":
+			method = "WS"
+		default:
+			method = strings.ToUpper(decorator)
+		}
+		handler := ""
+		if def := pythonDefPattern.FindStringSubmatch(content[match[1]:]); def != nil {
+			handler = def[1]
+		}
+		routes = append(routes, Route{
+			Method:    method,
+			Path:      prefixes[content[match[2]:match[3]]] + content[match[6]:match[7]],
+			Framework: framework,
+			Handler:   handler,
+			Line:      lineOf(content, match[0]),
+		})
+	}
+	return routes
+}
+
+// springRoutes extracts the routes Spring mapping annotations declare, joining the class-level
+// @RequestMapping prefix
+func springRoutes(content string) []Route {
+	routes := make([]Route, 0)
+	prefix := ""
+	for _, match := range springMappingPattern.FindAllStringSubmatchIndex(content, -1) {
+		annotation := content[match[2]:match[3]]
+		arguments := ""
+		if match[4] >= 0 {
+			arguments = content[match[4]:match[5]]
+		}
+		path := ""
+		if paths := springPathPattern.FindStringSubmatch(arguments); paths != nil {
+			path = paths[1] + paths[2]
+		}
+
+		// The annotated declaration follows any further annotations
+		declaration := content[match[1]:]
+		declaration = declaration[len(javaAnnotationsPattern.FindString(declaration)):]
+		if end := strings.IndexAny(declaration, "{;"); end >= 0 {
+			declaration = declaration[:end]
+		}
+		target := javaDeclarationPattern.FindStringSubmatch(declaration)
+		if target == nil {
+			continue
+		}
+		if target[1] == "" {
+			prefix = path
+			continue
+		}
+
+		method := strings.ToUpper(strings.TrimSuffix(annotation, "Mapping"))
+		if annotation == "RequestMapping" {
+			method = "ANY"
+			if methods := springMethodPattern.FindAllStringSubmatch(arguments, -1); methods != nil {
+				names := make([]string, 0, len(methods))
+				for _, name := range methods {
+					names = append(names, name[1])
+				}
+				method = strings.Join(names, ",")
+			}
+		}
+		routes = append(routes, Route{
+			Method:    method,
+			Path:      joinRoutePath(prefix, path),
+			Framework: "spring",
+			Handler:   target[1],
+			Line:      lineOf(content, match[0]),
+		})
+	}
+	return routes
+}
+
+// nextJSRoutes lists the pages and API routes of a Next.js file, deriving the path from the file's
+// location under pages/ or app/
+func nextJSRoutes(graph *types.CodeGraph, filePath string, fileNode *types.FileNode) []Route {
+	routes := make([]Route, 0)
+	seen := make(map[string]bool)
+	for _, symbolId := range fileNode.Symbols {
+		symbol := graph.Symbols[symbolId]
+		if symbol == nil || symbol.Type != types.SymbolTypeRoute {
+			continue
+		}
+		path := nextJSPath(filePath)
+		method := "GET"
+		if strings.HasPrefix(path, "/api/") || path == "/api" {
+			method = "ANY"
+		}
+		for _, httpMethod := range routeMethods {
+			if symbol.Name == httpMethod {
+				method = httpMethod
+			}
+		}
+		if seen[method] {
+			continue
+		}
+		seen[method] = true
+		routes = append(routes, Route{
+			Method:    method,
+			Path:      path,
+			Framework: "next.js",
+			Handler:   symbol.Name,
+			HandlerId: symbol.Id,
+			File:      filePath,
+			Line:      symbol.Location.StartLine,
+		})
+	}
+	return routes
+}
+
+// nextJSPath derives the URL path of a file under a Next.js pages/ or app/ directory
+func nextJSPath(filePath string) string {
+	path := filepath.ToSlash(filePath)
+	for _, root := range []string{"/pages/", "/app/"} {
+		if index := strings.LastIndex(path, root); index >= 0 {
+			path = path[index+len(root)-1:]
+			break
+		}
+	}
+	path = strings.TrimSuffix(path, filepath.Ext(path))
+	for _, suffix := range []string{"/index", "/page", "/route"} {
+		path = strings.TrimSuffix(path, suffix)
+	}
+	// Route groups such as (marketing) do not appear in the URL
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" && !(strings.HasPrefix(segment, "(") && strings.HasSuffix(segment, ")")) {
+			segments = append(segments, segment)
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// routeArguments splits the remaining arguments of a registration call, starting after its first
+// argument, at top-level commas; it returns them with the offset just past the closing parenthesis
+func routeArguments(rest string) ([]string, int) {
+	arguments := make([]string, 0)
+	depth, start := 0, 0
+	var quote rune
+	for i, r := range rest {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			if depth == 0 {
+				return append(arguments, strings.TrimSpace(rest[start:i])), i + 1
+			}
+			depth--
+		case r == ',' && depth == 0:
+			arguments = append(arguments, strings.TrimSpace(rest[start:i]))
+			start = i + 1
+		}
+	}
+	return append(arguments, strings.TrimSpace(rest[start:])), len(rest)
+}
+
+// handlerExpression returns the handler named by the last argument of a registration, unwrapping
+// adapters such as http.HandlerFunc(list); inline functions have no name and yield ""
+func handlerExpression(arguments []string) string {
+	if len(arguments) == 0 {
+		return ""
+	}
+	handler := arguments[len(arguments)-1]
+	for {
+		wrapped := handlerWrapperPattern.FindStringSubmatch(handler)
+		if wrapped == nil {
+			break
+		}
+		handler = strings.TrimSpace(wrapped[1])
+	}
+	handler = strings.TrimPrefix(handler, "&")
+	if !handlerExpressionPattern.MatchString(handler) {
+		return ""
+	}
+	return handler
+}
+
+// routeHandler resolves a handler expression to a callable symbol, preferring the registering file,
+// then its directory, then a symbol unique in the graph
+func (ra *RelationshipAnalyzer) routeHandler(filePath, handler string) types.SymbolId {
+	if handler == "" {
+		return ""
+	}
+	name := handler[strings.LastIndex(handler, ".")+1:]
+	ra.indexSymbols()
+	candidates := ra.callableByName[name]
+	for _, candidate := range candidates {
+		if ra.symbolFiles[candidate.Id] == filePath {
+			return candidate.Id
+		}
+	}
+	for _, candidate := range candidates {
+		if filepath.Dir(ra.symbolFiles[candidate.Id]) == filepath.Dir(filePath) {
+			return candidate.Id
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0].Id
+	}
+	return ""
+}
+
+// joinRoutePath joins a route prefix and path with a single slash
+func joinRoutePath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// quotedValues returns the quoted strings in text
+func quotedValues(text string) []string {
+	values := make([]string, 0)
+	for _, match := range quotedPattern.FindAllStringSubmatch(text, -1) {
+		values = append(values, match[1])
+	}
+	return values
+}
+
+// lineOf returns the 1-based line of an offset in content
+func lineOf(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
+
+// WriteRouteTable writes routes as a table of method, path, handler and location, listing at
+// most limit routes when limit is positive
+func WriteRouteTable(sb *strings.Builder, routes []Route, limit int) {
+	if len(routes) == 0 {
+		sb.WriteString("*No HTTP routes found.*\n")
+		return
+	}
+
+	frameworks := make(map[string]bool)
+	for _, route := range routes {
+		frameworks[route.Framework] = true
+	}
+	sb.WriteString(fmt.Sprintf("%d routes registered with %s.\n\n", len(routes), strings.Join(sortedKeys(frameworks), ", ")))
+	sb.WriteString("| Method | Path | Handler | Location |\n")
+	sb.WriteString("|--------|------|---------|----------|\n")
+	for i, route := range routes {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("\n*... and %d more routes*\n", len(routes)-limit))
+			break
+		}
+		handler := "*inline*"
+		if route.Handler != "" {
+			handler = fmt.Sprintf("`%s`", route.Handler)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %s | `%s:%d` |\n", route.Method, route.Path, handler, route.File, route.Line))
+	}
+}
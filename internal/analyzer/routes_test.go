@@ -0,0 +1,215 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestFindRoutes(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		language string
+		imports  []string
+		content  string
+		expected []string // "METHOD path handler line" in FindRoutes order
+	}{
+		{
+			name:     "server.js",
+			language: "javascript",
+			imports:  []string{"express"},
+			content: `const app = express();
+app.get('/users', listUsers);
+app.post("/users", auth, async (req, res) => {
+  res.send(cache.get('key'));
+});
+app.all('/health', health.check)
+`,
+			expected: []string{"ANY /health health.check 6", "GET /users listUsers 2", "POST /users  3"},
+		},
+		{
+			name:     "fastify.ts",
+			language: "typescript",
+			imports:  []string{"fastify"},
+			content:  "server.route({\n  method: ['GET', 'HEAD'],\n  url: '/items/:id',\n  handler: getItem,\n})\n",
+			expected: []string{"GET,HEAD /items/:id getItem 1"},
+		},
+		{
+			name:     "gin.go",
+			language: "go",
+			imports:  []string{"github.com/gin-gonic/gin"},
+			content: `func routes(r *gin.Engine, h *Handlers) {
+	api := r.Group("/api")
+	v1 := api.Group("/v1")
+	v1.GET("/users/:id", h.getUser)
+	r.Any("/ping", func(c *gin.Context) {})
+	r.Get("/ignored", h.getUser)
+}
+`,
+			expected: []string{"GET /api/v1/users/:id h.getUser 4", "ANY /ping  5"},
+		},
+		{
+			name:     "mux.go",
+			language: "go",
+			imports:  []string{"net/http"},
+			content: `func routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /items/{id}", getItem)
+	mux.Handle("/static/", http.StripPrefix("/static/", files))
+	resp, _ := http.Get("/not-a-route")
+}
+`,
+			expected: []string{"GET /items/{id} getItem 2", "ANY /static/  3"},
+		},
+		{
+			name:     "chi.go",
+			language: "go",
+			imports:  []string{"github.com/go-chi/chi/v5"},
+			content:  "r.Get(\"/orders\", listOrders)\nr.Method(\"DELETE\", \"/orders/{id}\", http.HandlerFunc(deleteOrder))\n",
+			expected: []string{"GET /orders listOrders 1", "DELETE /orders/{id} deleteOrder 2"},
+		},
+		{
+			name:     "gorilla.go",
+			language: "go",
+			imports:  []string{"github.com/gorilla/mux"},
+			content:  "s := r.PathPrefix(\"/admin\").Subrouter()\ns.HandleFunc(\"/stats\", stats).Methods(\"GET\", \"POST\")\n",
+			expected: []string{"GET,POST /admin/stats stats 2"},
+		},
+		{
+			name:     "flask_app.py",
+			language: "python",
+			imports:  []string{"flask"},
+			content: `bp = Blueprint("users", __name__, url_prefix="/users")
+
+@bp.route("/<int:id>", methods=["GET", "PUT"])
+@login_required
+def user(id):
+    pass
+
+@bp.delete("/<int:id>")
+def delete_user(id):
+    pass
+`,
+			expected: []string{"DELETE /users/<int:id> delete_user 8", "GET,PUT /users/<int:id> user 3"},
+		},
+		{
+			name:     "fastapi_app.py",
+			language: "python",
+			imports:  []string{"fastapi"},
+			content:  "router = APIRouter(prefix=\"/items\", tags=[\"items\"])\n\n@router.get(\"/{item_id}\", response_model=Item)\nasync def read_item(item_id: int):\n    pass\n",
+			expected: []string{"GET /items/{item_id} read_item 3"},
+		},
+		{
+			name:     "UserController.java",
+			language: "java",
+			imports:  []string{"org.springframework.web.bind.annotation.RestController"},
+			content: `@RestController
+@RequestMapping("/api/users")
+public class UserController {
+    @GetMapping("/{id}")
+    public User get(@PathVariable long id) { return null; }
+
+    @RequestMapping(value = "/search", method = {RequestMethod.GET, RequestMethod.POST})
+    @ResponseBody
+    public List<User> search(String query) { return null; }
+
+    @PostMapping
+    public User create(@RequestBody User user) { return null; }
+}
+`,
+			expected: []string{"POST /api/users create 11", "GET,POST /api/users/search search 7", "GET /api/users/{id} get 4"},
+		},
+		{
+			name:     "unrelated.js",
+			language: "javascript",
+			content:  "map.get('/users', fallback)\n",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := newEmptyTestGraph()
+			path := writeTestFile(t, dir, tt.name, tt.content)
+			addTestFile(graph, path, tt.language, tt.imports...)
+
+			found := make([]string, 0)
+			for _, route := range FindRoutes(graph) {
+				found = append(found, strings.Join([]string{route.Method, route.Path, route.Handler, strconv.Itoa(route.Line)}, " "))
+			}
+			if !reflect.DeepEqual(found, tt.expected) {
+				t.Errorf("FindRoutes() = %q, expected %q", found, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRouteHandlersAndNextJS(t *testing.T) {
+	dir := t.TempDir()
+	server := writeTestFile(t, dir, "server.go", `package main
+
+func main() {
+	r := gin.Default()
+	r.GET("/users", listUsers)
+	r.POST("/users", func(c *gin.Context) {})
+}
+
+func listUsers(c *gin.Context) {}
+`)
+	page := filepath.Join(dir, "web", "app", "(shop)", "products", "[id]", "page.tsx")
+	api := filepath.Join(dir, "web", "app", "api", "orders", "route.ts")
+
+	graph := newEmptyTestGraph()
+	addTestFile(graph, server, "go", "github.com/gin-gonic/gin")
+	addTestSymbol(graph, server, "main", types.SymbolTypeFunction, 3)
+	handler := addTestSymbol(graph, server, "listUsers", types.SymbolTypeFunction, 9)
+	addTestFile(graph, page, "typescript")
+	addTestSymbol(graph, page, "ProductPage", types.SymbolTypeRoute, 1)
+	addTestFile(graph, api, "typescript")
+	addTestSymbol(graph, api, "POST", types.SymbolTypeRoute, 4)
+
+	routes := FindRoutes(graph)
+	byPath := make(map[string]Route)
+	for _, route := range routes {
+		byPath[route.Method+" "+route.Path] = route
+	}
+	if len(routes) != 4 {
+		t.Fatalf("expected 4 routes, got %+v", routes)
+	}
+	if route := byPath["GET /users"]; route.HandlerId != handler.Id || route.Framework != "gin" {
+		t.Errorf("handler not resolved: %+v", route)
+	}
+	if route := byPath["GET /products/[id]"]; route.Framework != "next.js" || route.File != page {
+		t.Errorf("unexpected page route: %+v", route)
+	}
+	if _, ok := byPath["POST /api/orders"]; !ok {
+		t.Errorf("missing API route in %+v", routes)
+	}
+
+	// Registered handlers are entry points, named by their routes
+	found := make(map[string]string)
+	for _, entryPoint := range FindEntryPoints(graph) {
+		if entryPoint.Kind == EntryPointHTTP {
+			found[entryPoint.Name] = entryPoint.Detail
+		}
+	}
+	if found["listUsers"] != "gin: GET /users" || found["POST /users"] != "gin, inline handler" {
+		t.Errorf("unexpected HTTP entry points: %v", found)
+	}
+
+	var sb strings.Builder
+	WriteRouteTable(&sb, routes, 2)
+	for _, expected := range []string{
+		"4 routes registered with gin, next.js.",
+		"| POST | `/api/orders` | `POST` | `" + api + ":4` |",
+		"*... and 2 more routes*",
+	} {
+		if !strings.Contains(sb.String(), expected) {
+			t.Errorf("route table missing %q:\n%s", expected, sb.String())
+		}
+	}
+}
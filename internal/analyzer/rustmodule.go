@@ -0,0 +1,438 @@
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// cargoManifest describes the parts of a Cargo.toml used for import resolution
+type cargoManifest struct {
+	Dir          string            // Directory containing Cargo.toml
+	Name         string            // Crate name as used in paths (dashes become underscores)
+	Members      []string          // Workspace member globs, relative to Dir
+	Dependencies map[string]string // Dependency crate name -> version requirement
+	PathDeps     map[string]string // Dependency crate name -> local crate directory
+	IsWorkspace  bool              // Manifest declares a [workspace]
+}
+
+// rustModuleResolver maps Rust use paths and mod declarations onto files
+type rustModuleResolver struct {
+	ra         *RelationshipAnalyzer
+	manifests  map[string]*cargoManifest // Cargo.toml directory -> manifest (nil when absent)
+	crates     map[string]*cargoManifest // source directory -> nearest crate manifest
+	workspaces map[string]*cargoManifest // crate directory -> enclosing workspace manifest
+}
+
+// newRustModuleResolver creates a resolver for Rust imports in the analyzer's graph
+func newRustModuleResolver(ra *RelationshipAnalyzer) *rustModuleResolver {
+	return &rustModuleResolver{
+		ra:         ra,
+		manifests:  make(map[string]*cargoManifest),
+		crates:     make(map[string]*cargoManifest),
+		workspaces: make(map[string]*cargoManifest),
+	}
+}
+
+var (
+	// cargoStringPattern matches quoted strings in Cargo.toml values
+	cargoStringPattern = regexp.MustCompile(`"([^"]*)"`)
+	// cargoInlineKeyPattern matches keys of inline dependency tables such as { version = "1", path = "../x" }
+	cargoInlineKeyPattern = regexp.MustCompile(`(\w+)\s*=\s*"([^"]*)"`)
+)
+
+// rustStdlibCrates lists crates shipped with the Rust toolchain
+var rustStdlibCrates = map[string]bool{
+	"std": true, "core": true, "alloc": true, "proc_macro": true, "test": true,
+}
+
+// Resolve resolves a Rust use path or mod declaration used in fromFile. Paths starting with
+// crate, self or super are walked from the corresponding module; bare paths are tried as child
+// modules, then 2015-style crate paths, then crates of the workspace and local path dependencies.
+func (rr *rustModuleResolver) Resolve(importPath, fromFile string) *ImportResolution {
+	segments := strings.Split(strings.TrimPrefix(importPath, "::"), "::")
+	crate := rr.crateForDir(filepath.Dir(fromFile))
+
+	switch segments[0] {
+	case "crate":
+		if root := rr.crateRootFile(fromFile, crate); root != "" {
+			return rr.fileResolution(rr.walk(root, segments[1:]))
+		}
+		return &ImportResolution{}
+	case "self", "super":
+		module := fromFile
+		i := 0
+		if segments[0] == "self" {
+			i = 1
+		}
+		for ; i < len(segments) && segments[i] == "super"; i++ {
+			module = rr.parentModuleFile(module, crate)
+			if module == "" {
+				return &ImportResolution{}
+			}
+		}
+		return rr.fileResolution(rr.walk(module, segments[i:]))
+	}
+
+	if rustStdlibCrates[segments[0]] {
+		return &ImportResolution{External: true, Stdlib: true, Module: segments[0]}
+	}
+
+	// Child modules of the current module shadow crates in 2018 paths
+	if file, matched := rr.walkMatched(fromFile, segments); matched > 0 {
+		return rr.fileResolution(file)
+	}
+	if root := rr.crateRootFile(fromFile, crate); root != "" && root != fromFile {
+		if file, matched := rr.walkMatched(root, segments); matched > 0 {
+			return rr.fileResolution(file)
+		}
+	}
+
+	if dir := rr.localCrateDir(segments[0], crate); dir != "" {
+		if root := rr.libRootFile(dir); root != "" {
+			return rr.fileResolution(rr.walk(root, segments[1:]))
+		}
+	}
+
+	resolution := &ImportResolution{External: true, Module: segments[0]}
+	if crate != nil {
+		resolution.Version = rr.dependencyVersion(crate, segments[0])
+	}
+	return resolution
+}
+
+// fileResolution wraps a resolved module file
+func (rr *rustModuleResolver) fileResolution(file string) *ImportResolution {
+	if file == "" {
+		return &ImportResolution{}
+	}
+	return &ImportResolution{Files: []string{file}}
+}
+
+// walk follows path segments from a module file as far as they name modules. Remaining
+// segments are items of the last module, so the file of that module is returned.
+func (rr *rustModuleResolver) walk(moduleFile string, segments []string) string {
+	file, _ := rr.walkMatched(moduleFile, segments)
+	return file
+}
+
+// walkMatched is walk that also reports how many segments named module files
+func (rr *rustModuleResolver) walkMatched(moduleFile string, segments []string) (string, int) {
+	file := moduleFile
+	dir := rustModuleDir(moduleFile)
+	matched := 0
+	for _, segment := range segments {
+		if segment == "*" || segment == "" {
+			break
+		}
+		child := rr.childModuleFile(dir, segment)
+		if child == "" {
+			break
+		}
+		file = child
+		dir = filepath.Join(dir, segment)
+		matched++
+	}
+	return file, matched
+}
+
+// childModuleFile returns the file for module name declared in a module whose children live in dir
+func (rr *rustModuleResolver) childModuleFile(dir, name string) string {
+	for _, candidate := range []string{
+		filepath.Join(dir, name+".rs"),
+		filepath.Join(dir, name, "mod.rs"),
+	} {
+		if _, exists := rr.ra.graph.Files[candidate]; exists {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// parentModuleFile returns the file of the module enclosing the module defined by moduleFile
+func (rr *rustModuleResolver) parentModuleFile(moduleFile string, crate *cargoManifest) string {
+	if isRustCrateRoot(moduleFile) {
+		return ""
+	}
+
+	// foo/bar.rs and foo/bar/mod.rs both declare bar inside the module whose children live in foo
+	parentDir := filepath.Dir(moduleFile)
+	if filepath.Base(moduleFile) == "mod.rs" {
+		parentDir = filepath.Dir(parentDir)
+	}
+
+	for _, candidate := range []string{
+		parentDir + ".rs",
+		filepath.Join(parentDir, "mod.rs"),
+		filepath.Join(parentDir, "lib.rs"),
+		filepath.Join(parentDir, "main.rs"),
+	} {
+		if _, exists := rr.ra.graph.Files[candidate]; exists {
+			return candidate
+		}
+	}
+	return rr.crateRootFile(moduleFile, crate)
+}
+
+// crateRootFile finds the lib.rs, main.rs or target file that forms the crate of fromFile
+func (rr *rustModuleResolver) crateRootFile(fromFile string, crate *cargoManifest) string {
+	if isRustCrateRoot(fromFile) {
+		return fromFile
+	}
+
+	for dir := filepath.Dir(fromFile); ; dir = filepath.Dir(dir) {
+		for _, name := range []string{"lib.rs", "main.rs"} {
+			candidate := filepath.Join(dir, name)
+			if _, exists := rr.ra.graph.Files[candidate]; exists {
+				return candidate
+			}
+		}
+		if (crate != nil && dir == crate.Dir) || filepath.Dir(dir) == dir {
+			return ""
+		}
+	}
+}
+
+// libRootFile returns the library root of the crate in dir
+func (rr *rustModuleResolver) libRootFile(dir string) string {
+	for _, name := range []string{"lib.rs", "main.rs"} {
+		candidate := filepath.Join(dir, "src", name)
+		if _, exists := rr.ra.graph.Files[candidate]; exists {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// localCrateDir finds a crate named name among path dependencies and workspace members
+func (rr *rustModuleResolver) localCrateDir(name string, crate *cargoManifest) string {
+	if crate == nil {
+		return ""
+	}
+	if crate.Name == name {
+		return crate.Dir
+	}
+	if dir, ok := crate.PathDeps[name]; ok {
+		return dir
+	}
+
+	workspace := rr.workspaceFor(crate)
+	if workspace == nil {
+		return ""
+	}
+	for _, member := range workspace.Members {
+		matches, _ := filepath.Glob(filepath.Join(workspace.Dir, filepath.FromSlash(member)))
+		for _, dir := range matches {
+			if manifest := rr.manifest(dir); manifest != nil && manifest.Name == name {
+				return dir
+			}
+		}
+	}
+	return ""
+}
+
+// dependencyVersion returns the declared version of a dependency, following workspace inheritance
+func (rr *rustModuleResolver) dependencyVersion(crate *cargoManifest, name string) string {
+	if version := crate.Dependencies[name]; version != "" {
+		return version
+	}
+	if workspace := rr.workspaceFor(crate); workspace != nil {
+		return workspace.Dependencies[name]
+	}
+	return ""
+}
+
+// crateForDir finds the nearest Cargo.toml with a [package] section above dir
+func (rr *rustModuleResolver) crateForDir(dir string) *cargoManifest {
+	if crate, cached := rr.crates[dir]; cached {
+		return crate
+	}
+
+	var crate *cargoManifest
+	if manifest := rr.manifest(dir); manifest != nil && manifest.Name != "" {
+		crate = manifest
+	} else if parent := filepath.Dir(dir); parent != dir {
+		crate = rr.crateForDir(parent)
+	}
+
+	rr.crates[dir] = crate
+	return crate
+}
+
+// workspaceFor finds the workspace manifest enclosing a crate, if any
+func (rr *rustModuleResolver) workspaceFor(crate *cargoManifest) *cargoManifest {
+	if workspace, cached := rr.workspaces[crate.Dir]; cached {
+		return workspace
+	}
+
+	var workspace *cargoManifest
+	for dir := crate.Dir; ; dir = filepath.Dir(dir) {
+		if manifest := rr.manifest(dir); manifest != nil && manifest.IsWorkspace {
+			workspace = manifest
+			break
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	rr.workspaces[crate.Dir] = workspace
+	return workspace
+}
+
+// manifest loads and caches the Cargo.toml in dir
+func (rr *rustModuleResolver) manifest(dir string) *cargoManifest {
+	if manifest, cached := rr.manifests[dir]; cached {
+		return manifest
+	}
+
+	manifest, err := parseCargoToml(filepath.Join(dir, "Cargo.toml"))
+	if err != nil {
+		manifest = nil
+	}
+	rr.manifests[dir] = manifest
+	return manifest
+}
+
+// rustModuleDir returns the directory holding the child modules of the module defined by file
+func rustModuleDir(file string) string {
+	base := filepath.Base(file)
+	if base == "mod.rs" || isRustCrateRoot(file) {
+		return filepath.Dir(file)
+	}
+	return filepath.Join(filepath.Dir(file), strings.TrimSuffix(base, ".rs"))
+}
+
+// isRustCrateRoot reports whether file is a crate root by Cargo's target conventions
+func isRustCrateRoot(file string) bool {
+	switch filepath.Base(file) {
+	case "lib.rs", "main.rs", "build.rs":
+		return true
+	}
+	switch filepath.Base(filepath.Dir(file)) {
+	case "bin", "tests", "examples", "benches":
+		return true
+	}
+	return false
+}
+
+// parseCargoToml reads the package name, workspace members and dependencies of a Cargo.toml
+func parseCargoToml(path string) (*cargoManifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	manifest := &cargoManifest{
+		Dir:          filepath.Dir(path),
+		Dependencies: make(map[string]string),
+		PathDeps:     make(map[string]string),
+	}
+
+	section := ""
+	inMembers := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripTomlComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		// Multi-line members = [ ... ] arrays
+		if inMembers {
+			for _, match := range cargoStringPattern.FindAllStringSubmatch(line, -1) {
+				manifest.Members = append(manifest.Members, match[1])
+			}
+			inMembers = !strings.Contains(line, "]")
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			if section == "workspace" {
+				manifest.IsWorkspace = true
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch {
+		case section == "package" && key == "name":
+			manifest.Name = rustCrateName(strings.Trim(value, `"`))
+		case section == "workspace" && key == "members":
+			for _, match := range cargoStringPattern.FindAllStringSubmatch(value, -1) {
+				manifest.Members = append(manifest.Members, match[1])
+			}
+			inMembers = strings.HasPrefix(value, "[") && !strings.Contains(value, "]")
+		case isCargoDependencySection(section):
+			manifest.addDependency(key, value)
+		case strings.Contains(section, "dependencies."):
+			// [dependencies.serde] tables list one dependency's settings line by line
+			name := section[strings.LastIndex(section, "dependencies.")+len("dependencies."):]
+			manifest.addDependency(name, "{ "+key+" = "+value+" }")
+		}
+	}
+
+	return manifest, scanner.Err()
+}
+
+// addDependency records a dependency declared as a version string or an inline table
+func (cm *cargoManifest) addDependency(name, value string) {
+	name = rustCrateName(strings.TrimSuffix(name, ".workspace"))
+
+	if strings.HasPrefix(value, `"`) {
+		cm.Dependencies[name] = strings.Trim(value, `"`)
+		return
+	}
+
+	for _, match := range cargoInlineKeyPattern.FindAllStringSubmatch(value, -1) {
+		switch match[1] {
+		case "version":
+			cm.Dependencies[name] = match[2]
+		case "path":
+			cm.PathDeps[name] = filepath.Join(cm.Dir, filepath.FromSlash(match[2]))
+		}
+	}
+	if _, exists := cm.Dependencies[name]; !exists {
+		cm.Dependencies[name] = ""
+	}
+}
+
+// isCargoDependencySection reports whether a section lists dependencies, including target-specific ones
+func isCargoDependencySection(section string) bool {
+	for _, suffix := range []string{"dependencies", "dev-dependencies", "build-dependencies"} {
+		if section == suffix || strings.HasSuffix(section, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// rustCrateName converts a package name to the identifier used in paths
+func rustCrateName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// stripTomlComment removes a trailing # comment outside of quoted strings
+func stripTomlComment(line string) string {
+	inString := false
+	for i, r := range line {
+		switch r {
+		case '"':
+			inString = !inString
+		case '#':
+			if !inString {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
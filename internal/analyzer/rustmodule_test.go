@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestRustModuleResolution(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "Cargo.toml", "[workspace]\nmembers = [\n    \"crates/*\",\n]\n\n[workspace.dependencies]\nserde = { version = \"1.0\", features = [\"derive\"] }\n")
+	writeTestFile(t, dir, "crates/app/Cargo.toml", "[package]\nname = \"app\"\n\n[dependencies]\nserde.workspace = true\ntokio = \"1.38\" # runtime\nshared-util = { path = \"../shared-util\" }\n")
+	writeTestFile(t, dir, "crates/shared-util/Cargo.toml", "[package]\nname = \"shared-util\"\n")
+
+	graph := newEmptyTestGraph()
+	src := filepath.Join(dir, "crates", "app", "src")
+	lib := filepath.Join(src, "lib.rs")
+	config := filepath.Join(src, "config.rs")
+	netMod := filepath.Join(src, "net", "mod.rs")
+	tcp := filepath.Join(src, "net", "tcp.rs")
+	tls := filepath.Join(src, "net", "tcp", "tls.rs")
+	util := filepath.Join(dir, "crates", "shared-util", "src", "lib.rs")
+	strs := filepath.Join(dir, "crates", "shared-util", "src", "strings.rs")
+	for _, file := range []string{lib, config, netMod, tcp, tls, util, strs} {
+		addTestFile(graph, file, "rust")
+	}
+
+	analyzer := NewRelationshipAnalyzer(graph)
+
+	tests := []struct {
+		name       string
+		fromFile   string
+		importPath string
+		files      []string
+		external   bool
+		stdlib     bool
+		version    string
+	}{
+		{"mod declaration to file", lib, "self::config", []string{config}, false, false, ""},
+		{"mod declaration to mod.rs", lib, "self::net", []string{netMod}, false, false, ""},
+		{"mod declaration from non-root file", tcp, "self::tls", []string{tls}, false, false, ""},
+		{"crate path to item", tls, "crate::config::Settings", []string{config}, false, false, ""},
+		{"super from nested file", tls, "super::Listener", []string{tcp}, false, false, ""},
+		{"super from mod.rs", netMod, "super::config", []string{config}, false, false, ""},
+		{"bare child module", netMod, "tcp::connect", []string{tcp}, false, false, ""},
+		{"workspace member crate", lib, "shared_util::strings::pad", []string{strs}, false, false, ""},
+		{"stdlib", lib, "std::collections::HashMap", nil, true, true, ""},
+		{"workspace dependency", lib, "serde::Deserialize", nil, true, false, "1.0"},
+		{"dependency", lib, "tokio::spawn", nil, true, false, "1.38"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution := analyzer.resolveImport(&types.Import{Path: tt.importPath}, tt.fromFile)
+
+			if !reflect.DeepEqual(resolution.Files, tt.files) {
+				t.Errorf("Files = %v, expected %v", resolution.Files, tt.files)
+			}
+			if resolution.External != tt.external || resolution.Stdlib != tt.stdlib {
+				t.Errorf("External/Stdlib = %v/%v, expected %v/%v", resolution.External, resolution.Stdlib, tt.external, tt.stdlib)
+			}
+			if resolution.Version != tt.version {
+				t.Errorf("Version = %q, expected %q", resolution.Version, tt.version)
+			}
+		})
+	}
+}
+
+func TestRustModImportEdges(t *testing.T) {
+	graph := newEmptyTestGraph()
+	addTestFile(graph, "src/main.rs", "rust", "self::cli", "crate::cli::Args")
+	addTestFile(graph, "src/cli.rs", "rust")
+
+	analyzer := NewRelationshipAnalyzer(graph)
+	metrics := &RelationshipMetrics{ByType: make(map[RelationshipType]int)}
+	analyzer.analyzeImportRelationships(metrics)
+
+	if _, exists := graph.Edges[types.EdgeId("import-src/main.rs-src/cli.rs")]; !exists {
+		t.Error("expected import edge from main.rs to cli.rs")
+	}
+}
+
+func TestParseCargoToml(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "Cargo.toml", `[package]
+name = "my-app" # binary
+
+[dependencies]
+anyhow = "1"
+local = { path = "../local" }
+
+[dependencies.reqwest]
+version = "0.12"
+features = ["json"]
+
+[target.'cfg(unix)'.dev-dependencies]
+nix = { version = "0.29" }
+`)
+
+	manifest, err := parseCargoToml(path)
+	if err != nil {
+		t.Fatalf("parseCargoToml() error = %v", err)
+	}
+
+	if manifest.Name != "my_app" {
+		t.Errorf("name = %q, expected my_app", manifest.Name)
+	}
+	expected := map[string]string{"anyhow": "1", "local": "", "reqwest": "0.12", "nix": "0.29"}
+	if !reflect.DeepEqual(manifest.Dependencies, expected) {
+		t.Errorf("dependencies = %v, expected %v", manifest.Dependencies, expected)
+	}
+	if manifest.PathDeps["local"] != filepath.Join(filepath.Dir(dir), "local") {
+		t.Errorf("path dependency = %q", manifest.PathDeps["local"])
+	}
+}
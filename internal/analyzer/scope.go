@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// resolutionConfidence rates how certain each name resolution strategy is
+var resolutionConfidence = map[string]float64{
+	CallResolutionLocal:   1.0,
+	CallResolutionImport:  0.9,
+	CallResolutionPackage: 0.8,
+	CallResolutionGlobal:  0.5,
+}
+
+// resolveScopedName finds the symbol a name used in filePath refers to. It searches the file itself,
+// the files behind its imports and specifiers, the file's package, and finally every file of the same
+// language. candidates returns the symbols with a name in id order; the count reports how many symbols
+// matched at the step that produced the result, so callers can tell ambiguous global matches apart.
+func (ra *RelationshipAnalyzer) resolveScopedName(name, qualifier, filePath string, candidates func(string) []*types.Symbol) (*types.Symbol, string, int) {
+	fileNode := ra.graph.Files[filePath]
+	if fileNode == nil {
+		return nil, "", 0
+	}
+	symbols := candidates(name)
+	if len(symbols) == 0 {
+		return nil, "", 0
+	}
+
+	if qualifier == "" {
+		if target := ra.pickSymbolIn([]string{filePath}, symbols); target != nil {
+			return target, CallResolutionLocal, 1
+		}
+	}
+
+	// Imported names and names qualified by an import binding
+	reference := &types.Call{Callee: name, Receiver: qualifier}
+	if files := ra.importedFilesFor(fileNode, reference); len(files) > 0 {
+		if target := ra.pickSymbolIn(files, symbols); target != nil {
+			return target, CallResolutionImport, 1
+		}
+	}
+	// Names from a standard library or third-party package, such as io.Reader, are not declared here
+	if ra.bindsExternalImport(fileNode, reference) {
+		return nil, "", 0
+	}
+
+	// Fully qualified names such as crate::shapes::Shape or com.acme.model.Base
+	if qualifier != "" && (fileNode.Language == "rust" || fileNode.Language == "java") {
+		importPath := qualifier
+		if fileNode.Language == "java" {
+			importPath = qualifier + "." + name
+		}
+		resolution := ra.resolveImport(&types.Import{Path: importPath}, filePath)
+		if target := ra.pickSymbolIn(resolution.Files, symbols); target != nil {
+			return target, CallResolutionImport, 1
+		}
+	}
+
+	// Go and Java packages span every file of a directory
+	if qualifier == "" && (fileNode.Language == "go" || fileNode.Language == "java") {
+		siblings := ra.filesInDir(filepath.Dir(filePath), fileNode.Language, nil)
+		if target := ra.pickSymbolIn(siblings, symbols); target != nil {
+			return target, CallResolutionPackage, 1
+		}
+	}
+
+	// Fall back to every symbol of the same language with that name
+	var first *types.Symbol
+	count := 0
+	for _, symbol := range symbols {
+		if symbol.Language != fileNode.Language {
+			continue
+		}
+		if first == nil {
+			first = symbol
+		}
+		count++
+	}
+	if first == nil {
+		return nil, "", 0
+	}
+	return first, CallResolutionGlobal, count
+}
+
+// pickSymbolIn returns the first of symbols defined in one of files
+func (ra *RelationshipAnalyzer) pickSymbolIn(files []string, symbols []*types.Symbol) *types.Symbol {
+	inFiles := make(map[string]bool, len(files))
+	for _, file := range files {
+		inFiles[file] = true
+	}
+	for _, symbol := range symbols {
+		if inFiles[ra.symbolFile(symbol.Id)] {
+			return symbol
+		}
+	}
+	return nil
+}
+
+// declaredSymbolsNamed returns every declared symbol with a name, sorted by id. Import symbols are
+// left out because they are named after what they import rather than what they declare.
+func (ra *RelationshipAnalyzer) declaredSymbolsNamed(name string) []*types.Symbol {
+	ra.indexSymbols()
+	return ra.symbolsByName[name]
+}
+
+// splitReferenceName reduces a referenced type such as "models.User[]" or "Map<K, V>" to its
+// qualifier and name
+func splitReferenceName(reference string) (string, string) {
+	name := strings.TrimSpace(reference)
+	if index := strings.IndexAny(name, "<[(|&?"); index >= 0 {
+		name = name[:index]
+	}
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		return name[:index], name[index+1:]
+	}
+	return "", name
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/nuthan-ms/codecontext/internal/git"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Snapshot the public API and report semver-breaking changes",
+	Long: `Analyze the codebase and record the exported symbols of every package with
+their normalised signatures. With --write the snapshot is saved as JSON; with
+--against it is compared to a saved snapshot or to the same sources at a git
+ref, and every change is classified as major, minor or patch:
+
+  codecontext api --write api.json         # record the released API
+  codecontext api --against api.json       # compare against it
+  codecontext api --against v1.4.0         # compare against a git tag
+
+Removed symbols, reduced visibility, removed or added required parameters and
+changed return types are major; added symbols and optional parameters are minor.
+The command exits with a non-zero status when the required release is at least
+--fail-on, so it can gate publishing.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reportAPI(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+	apiCmd.Flags().StringP("target", "t", ".", "target directory to analyze")
+	apiCmd.Flags().StringP("format", "f", "markdown", "output format (markdown, json)")
+	addRootFlag(apiCmd)
+	apiCmd.Flags().String("write", "", "file to save the API snapshot to")
+	apiCmd.Flags().String("against", "", "snapshot file or git ref to compare the API against")
+	apiCmd.Flags().String("fail-on", analyzer.SemverMajor, "release level that fails the command (major, minor, patch, none)")
+}
+
+func reportAPI(cmd *cobra.Command) error {
+	targetDir, _ := cmd.Flags().GetString("target")
+	format, _ := cmd.Flags().GetString("format")
+	writePath, _ := cmd.Flags().GetString("write")
+	against, _ := cmd.Flags().GetString("against")
+	failOn, _ := cmd.Flags().GetString("fail-on")
+
+	switch failOn {
+	case analyzer.SemverMajor, analyzer.SemverMinor, analyzer.SemverPatch, "none":
+	default:
+		return fmt.Errorf("unsupported --fail-on level: %s", failOn)
+	}
+	if format != "json" && format != "markdown" {
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	if viper.GetBool("verbose") {
+		fmt.Printf("🔍 Analyzing directory: %s\n", targetDir)
+	}
+
+	graph, err := analyzeSource(cmd, analyzer.NewGraphBuilder(), targetDir)
+	if err != nil {
+		return err
+	}
+	snapshot := analyzer.NewAPISnapshot(graph)
+
+	if writePath != "" {
+		data, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode API snapshot: %w", err)
+		}
+		if err := os.WriteFile(writePath, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write API snapshot: %w", err)
+		}
+	}
+
+	if against == "" {
+		// Without a baseline there is nothing to compare; show the snapshot unless it was saved
+		if writePath != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "API snapshot written to %s\n", writePath)
+			return nil
+		}
+		if format == "json" {
+			data, err := json.MarshalIndent(snapshot, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode API snapshot: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+		fmt.Fprint(cmd.OutOrStdout(), formatAPISnapshot(snapshot))
+		return nil
+	}
+
+	baseline, err := loadAPIBaseline(cmd, against, targetDir)
+	if err != nil {
+		return err
+	}
+	report := analyzer.CompareAPISnapshots(baseline, snapshot)
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode API report: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("# API Changes since %s\n\n", against))
+		analyzer.WriteAPIReport(&sb, report, 0)
+		fmt.Fprint(cmd.OutOrStdout(), sb.String())
+	}
+
+	if failOn != "none" && len(report.Changes) > 0 && analyzer.SemverAtLeast(report.Level, failOn) {
+		return fmt.Errorf("public API changes require a %s release", report.Level)
+	}
+	return nil
+}
+
+// loadAPIBaseline reads the snapshot to compare against: a snapshot file when one exists, otherwise
+// the sources analyzed at the given git ref
+func loadAPIBaseline(cmd *cobra.Command, against, targetDir string) (*analyzer.APISnapshot, error) {
+	if _, err := os.Stat(against); err == nil {
+		return analyzer.LoadAPISnapshot(against)
+	}
+
+	tempDir, err := os.MkdirTemp("", "codecontext-api-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	ctx := commandContext(cmd)
+	extract := func(dir, destination string) error {
+		gitAnalyzer, err := git.NewGitAnalyzer(dir)
+		if err != nil {
+			return err
+		}
+		if err := gitAnalyzer.ExtractTree(ctx, against, destination); err != nil {
+			return fmt.Errorf("%s is neither a snapshot file nor a git ref: %w", against, err)
+		}
+		return nil
+	}
+
+	roots, err := sourceRoots(cmd)
+	if err != nil {
+		return nil, err
+	}
+	builder := analyzer.NewGraphBuilder()
+	builder.SetFileTimeout(viper.GetDuration("file_timeout"))
+	builder.SetAnnotationBlame(annotationBlame())
+
+	var graph *types.CodeGraph
+	if roots != nil {
+		// Extract each root under its label so package paths match the current snapshot
+		refRoots := make([]types.SourceRoot, 0, len(roots))
+		for _, root := range roots {
+			destination := filepath.Join(tempDir, root.Label)
+			if err := extract(root.Path, destination); err != nil {
+				return nil, err
+			}
+			refRoots = append(refRoots, types.SourceRoot{Label: root.Label, Path: destination})
+		}
+		graph, err = builder.AnalyzeRootsContext(ctx, refRoots)
+	} else {
+		if err := extract(targetDir, tempDir); err != nil {
+			return nil, err
+		}
+		graph, err = builder.AnalyzeDirectoryContext(ctx, tempDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s: %w", against, err)
+	}
+	return analyzer.NewAPISnapshot(graph), nil
+}
+
+// formatAPISnapshot lists the exported symbols of every package as markdown
+func formatAPISnapshot(snapshot *analyzer.APISnapshot) string {
+	var sb strings.Builder
+	sb.WriteString("# Public API\n\n")
+	if len(snapshot.Packages) == 0 {
+		sb.WriteString("*No exported symbols found.*\n")
+		return sb.String()
+	}
+
+	packages := make([]string, 0, len(snapshot.Packages))
+	for pkg := range snapshot.Packages {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+	for _, pkg := range packages {
+		sb.WriteString(fmt.Sprintf("## `%s`\n\n", pkg))
+		for _, symbol := range snapshot.Packages[pkg] {
+			if symbol.Signature != "" {
+				sb.WriteString(fmt.Sprintf("- `%s` (%s): `%s`\n", symbol.Name, symbol.Kind, symbol.Signature))
+			} else {
+				sb.WriteString(fmt.Sprintf("- `%s` (%s)\n", symbol.Name, symbol.Kind))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
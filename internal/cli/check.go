@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check imports against the architecture layer rules",
+	Long: `Analyze the codebase and check every resolved import against the layers
+and rules declared in the architecture section of the config file. The command
+exits with a non-zero status when any import violates a rule, so it can gate CI:
+
+  architecture:
+    layers:
+      - name: domain
+        paths: ["internal/domain/**"]
+      - name: infrastructure
+        paths: ["internal/infrastructure/**"]
+    rules:
+      - from: domain
+        deny: [infrastructure]`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return checkArchitecture(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().StringP("target", "t", ".", "target directory to analyze")
+	checkCmd.Flags().StringP("format", "f", "markdown", "output format (markdown, json)")
+	addRootFlag(checkCmd)
+}
+
+// architectureConfig reads and validates the layer rules from the config file
+func architectureConfig() (analyzer.ArchitectureConfig, error) {
+	var config analyzer.ArchitectureConfig
+	if err := viper.UnmarshalKey("architecture", &config); err != nil {
+		return config, fmt.Errorf("invalid architecture config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return config, fmt.Errorf("invalid architecture config: %w", err)
+	}
+	return config, nil
+}
+
+func checkArchitecture(cmd *cobra.Command) error {
+	targetDir, _ := cmd.Flags().GetString("target")
+	format, _ := cmd.Flags().GetString("format")
+
+	config, err := architectureConfig()
+	if err != nil {
+		return err
+	}
+	if !config.Enabled() {
+		return fmt.Errorf("no architecture layers configured; add an architecture section to the config file")
+	}
+
+	if viper.GetBool("verbose") {
+		fmt.Printf("🔍 Analyzing directory: %s\n", targetDir)
+	}
+
+	graph, err := analyzeSource(cmd, analyzer.NewGraphBuilder(), targetDir)
+	if err != nil {
+		return err
+	}
+
+	violations := analyzer.CheckArchitecture(graph, config)
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(violations, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode violations: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	case "markdown":
+		var sb strings.Builder
+		sb.WriteString("# Architecture Check\n\n")
+		analyzer.WriteLayerViolations(&sb, violations, 0)
+		fmt.Fprint(cmd.OutOrStdout(), sb.String())
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("%d architecture violations found", len(violations))
+	}
+	return nil
+}
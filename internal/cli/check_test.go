@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"domain/user.ts":       "import { connect } from '../infrastructure/db';\n\nexport function load() {\n  connect();\n}\n",
+		"infrastructure/db.ts": "export function connect() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	viper.Set("architecture", map[string]interface{}{
+		"layers": []map[string]interface{}{
+			{"name": "domain", "paths": []string{"domain/**"}},
+			{"name": "infrastructure", "paths": []string{"infrastructure/**"}},
+		},
+		"rules": []map[string]interface{}{
+			{"from": "domain", "deny": []string{"infrastructure"}},
+		},
+	})
+	defer viper.Set("architecture", nil)
+
+	var output bytes.Buffer
+	checkCmd.SetOut(&output)
+	require.NoError(t, checkCmd.Flags().Set("target", dir))
+	err := checkArchitecture(checkCmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 architecture violations found")
+	assert.Contains(t, output.String(), "imports `../infrastructure/db` (domain → infrastructure): domain must not import infrastructure")
+
+	// A misspelt layer is a config error rather than a passing check
+	viper.Set("architecture", map[string]interface{}{
+		"layers": []map[string]interface{}{
+			{"name": "domain", "paths": []string{"domain/**"}},
+			{"name": "infrastructure", "paths": []string{"infrastructure/**"}},
+		},
+		"rules": []map[string]interface{}{
+			{"from": "domain", "deny": []string{"infrastucture"}},
+		},
+	})
+	_, err = architectureConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown layer "infrastucture"`)
+	assert.Error(t, checkArchitecture(checkCmd))
+
+	// Without layers there is nothing to check
+	viper.Set("architecture", nil)
+	assert.Error(t, checkArchitecture(checkCmd))
+}
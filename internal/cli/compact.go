@@ -70,7 +70,9 @@ func executeCompaction(cmd *cobra.Command) error {
 
 	// Build graph from directory
 	builder := analyzer.NewGraphBuilder()
-	graph, err := builder.AnalyzeDirectory(targetDir)
+	builder.SetFileTimeout(viper.GetDuration("file_timeout"))
+	builder.SetAnnotationBlame(annotationBlame())
+	graph, err := builder.AnalyzeDirectoryContext(commandContext(cmd), targetDir)
 	if err != nil {
 		return fmt.Errorf("failed to analyze directory: %w", err)
 	}
@@ -117,8 +119,14 @@ func executeCompaction(cmd *cobra.Command) error {
 		fmt.Printf("   Symbols removed: %d\n", len(result.RemovedItems.Symbols))
 		fmt.Println("   Run without --preview to apply changes")
 	} else {
+		redactor, err := newRedactor()
+		if err != nil {
+			return err
+		}
+
 		// Generate and write compacted context map
 		generator := analyzer.NewMarkdownGenerator(result.CompactedGraph)
+		generator.SetRedactor(redactor)
 		compactedContent := generator.GenerateContextMap()
 		
 		// Write to output file
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var deadCodeCmd = &cobra.Command{
+	Use:   "deadcode",
+	Short: "Report unused exports, unimported files and unreachable functions",
+	Long: `Analyze the codebase and report exported symbols nothing references,
+files no other file imports, and functions unreachable from the detected
+entry points (main functions, init functions and tests).
+
+Public library APIs and framework-invoked handlers can be excluded with
+allow-lists, either through flags or the dead_code section of the config file:
+
+  dead_code:
+    allow_symbols: ["Handle*", "*Handler"]
+    allow_files: ["pkg/api/*.go"]`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reportDeadCode(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(deadCodeCmd)
+	deadCodeCmd.Flags().StringP("target", "t", ".", "target directory to analyze")
+	deadCodeCmd.Flags().StringP("format", "f", "markdown", "output format (markdown, json)")
+	addRootFlag(deadCodeCmd)
+	deadCodeCmd.Flags().StringSlice("allow-symbol", nil, "symbol name patterns to treat as used")
+	deadCodeCmd.Flags().StringSlice("allow-file", nil, "file path patterns whose symbols are treated as used")
+
+	viper.BindPFlag("dead_code.allow_symbols", deadCodeCmd.Flags().Lookup("allow-symbol"))
+	viper.BindPFlag("dead_code.allow_files", deadCodeCmd.Flags().Lookup("allow-file"))
+}
+
+// deadCodeOptions reads the dead code allow-lists from flags or the config file
+func deadCodeOptions() analyzer.DeadCodeOptions {
+	return analyzer.DeadCodeOptions{
+		AllowSymbols: viper.GetStringSlice("dead_code.allow_symbols"),
+		AllowFiles:   viper.GetStringSlice("dead_code.allow_files"),
+	}
+}
+
+func reportDeadCode(cmd *cobra.Command) error {
+	targetDir, _ := cmd.Flags().GetString("target")
+	format, _ := cmd.Flags().GetString("format")
+
+	if viper.GetBool("verbose") {
+		fmt.Printf("🔍 Analyzing directory: %s\n", targetDir)
+	}
+
+	graph, err := analyzeSource(cmd, analyzer.NewGraphBuilder(), targetDir)
+	if err != nil {
+		return err
+	}
+
+	report := analyzer.FindDeadCode(graph, deadCodeOptions())
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	case "markdown":
+		var sb strings.Builder
+		sb.WriteString("# Dead Code Report\n\n")
+		analyzer.WriteDeadCodeReport(&sb, report, 0)
+		fmt.Fprint(cmd.OutOrStdout(), sb.String())
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	return nil
+}
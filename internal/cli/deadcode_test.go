@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadCodeCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.ts":   "import { run } from './app';\n\nfunction main() {\n  run();\n}\n",
+		"app.ts":    "export function run() {}\n\nexport function onRequest() {}\n\nexport function unused() {}\n",
+		"legacy.ts": "export function old() {}\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	viper.Set("dead_code.allow_symbols", []string{"on*"})
+	defer viper.Set("dead_code.allow_symbols", nil)
+
+	var output bytes.Buffer
+	deadCodeCmd.SetOut(&output)
+	require.NoError(t, deadCodeCmd.Flags().Set("target", dir))
+	require.NoError(t, deadCodeCmd.Flags().Set("format", "json"))
+	defer deadCodeCmd.Flags().Set("format", "markdown")
+	require.NoError(t, reportDeadCode(deadCodeCmd))
+
+	var report analyzer.DeadCodeReport
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+
+	unused := make([]string, 0)
+	for _, symbol := range report.UnusedExports {
+		unused = append(unused, symbol.Name)
+	}
+	assert.ElementsMatch(t, []string{"unused", "old"}, unused)
+	assert.Equal(t, []string{filepath.Join(dir, "legacy.ts")}, report.UnimportedFiles)
+}
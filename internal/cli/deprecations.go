@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var deprecationsCmd = &cobra.Command{
+	Use:   "deprecations",
+	Short: "Report deprecated symbols and the code still using them",
+	Long: `Analyze the codebase and report the symbols marked deprecated, with every
+call and reference still using them and the files holding the most migration
+work.
+
+Deprecations are recognised from Go "Deprecated:" notices, JSDoc and Javadoc
+@deprecated tags, Java @Deprecated annotations, Rust #[deprecated] attributes
+and Python functions issuing a DeprecationWarning.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reportDeprecations(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(deprecationsCmd)
+	deprecationsCmd.Flags().StringP("target", "t", ".", "target directory to analyze")
+	deprecationsCmd.Flags().StringP("format", "f", "markdown", "output format (markdown, json)")
+	addRootFlag(deprecationsCmd)
+}
+
+func reportDeprecations(cmd *cobra.Command) error {
+	targetDir, _ := cmd.Flags().GetString("target")
+	format, _ := cmd.Flags().GetString("format")
+
+	if viper.GetBool("verbose") {
+		fmt.Printf("🔍 Analyzing directory: %s\n", targetDir)
+	}
+
+	graph, err := analyzeSource(cmd, analyzer.NewGraphBuilder(), targetDir)
+	if err != nil {
+		return err
+	}
+
+	report := analyzer.FindDeprecations(graph)
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	case "markdown":
+		var sb strings.Builder
+		sb.WriteString("# Deprecation Report\n\n")
+		analyzer.WriteDeprecationReport(&sb, report, 0)
+		for _, entry := range report.Deprecated {
+			if len(entry.Usages) == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n### Usages of `%s`\n\n", entry.Symbol.Name))
+			for _, usage := range entry.Usages {
+				user := "(top level)"
+				if usage.User != "" {
+					user = usage.User
+				}
+				sb.WriteString(fmt.Sprintf("- `%s:%d` in %s (%s)\n", usage.File, usage.Line, user, usage.Kind))
+			}
+		}
+		fmt.Fprint(cmd.OutOrStdout(), sb.String())
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecationsCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.22\n",
+		"util.go": "package app\n\n// Old returns one.\n//\n// Deprecated: Use New instead.\nfunc Old() int { return 1 }\n\n// New returns one.\nfunc New() int { return 1 }\n",
+		"main.go": "package app\n\nfunc run() int {\n\treturn Old() + New()\n}\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	var output bytes.Buffer
+	deprecationsCmd.SetOut(&output)
+	require.NoError(t, deprecationsCmd.Flags().Set("target", dir))
+	require.NoError(t, deprecationsCmd.Flags().Set("format", "json"))
+	defer deprecationsCmd.Flags().Set("format", "markdown")
+	require.NoError(t, reportDeprecations(deprecationsCmd))
+
+	var report analyzer.DeprecationReport
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+
+	require.Len(t, report.Deprecated, 1)
+	assert.Equal(t, "Old", report.Deprecated[0].Symbol.Name)
+	assert.Equal(t, "Use New instead.", report.Deprecated[0].Message)
+	require.Len(t, report.Deprecated[0].Usages, 1)
+	assert.Equal(t, filepath.Join(dir, "main.go"), report.Deprecated[0].Usages[0].File)
+	assert.Equal(t, 4, report.Deprecated[0].Usages[0].Line)
+	assert.Equal(t, "run", report.Deprecated[0].Usages[0].User)
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/nuthan-ms/codecontext/internal/analyzer"
 	"github.com/nuthan-ms/codecontext/internal/cache"
+	"github.com/nuthan-ms/codecontext/internal/redact"
+	"github.com/nuthan-ms/codecontext/pkg/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -28,6 +31,9 @@ func init() {
 	generateCmd.Flags().StringP("target", "t", ".", "target directory to analyze")
 	generateCmd.Flags().BoolP("watch", "w", false, "enable watch mode for continuous updates")
 	generateCmd.Flags().StringP("format", "f", "markdown", "output format (markdown, json, yaml)")
+	generateCmd.Flags().Bool("split", false, "write one context map per source root instead of a combined map")
+	generateCmd.Flags().String("redaction-report", "", "file to list the secrets redacted from the context map in")
+	addRootFlag(generateCmd)
 
 	// Bind flags to viper with error handling
 	if err := viper.BindPFlag("target", generateCmd.Flags().Lookup("target")); err != nil {
@@ -71,6 +77,15 @@ func generateContextMap(cmd *cobra.Command) error {
 		fmt.Printf("📄 Output file: %s\n", outputFile)
 	}
 
+	architecture, err := architectureConfig()
+	if err != nil {
+		return err
+	}
+	redactor, err := newRedactor()
+	if err != nil {
+		return err
+	}
+
 	// Initialize cache for better performance
 	cacheDir := filepath.Join(os.TempDir(), "codecontext", "cache")
 	cacheConfig := &cache.Config{
@@ -105,9 +120,9 @@ func generateContextMap(cmd *cobra.Command) error {
 		progressManager.UpdateIndeterminate(message)
 	})
 	
-	graph, err := builder.AnalyzeDirectory(targetDir)
+	graph, err := analyzeSource(cmd, builder, targetDir)
 	if err != nil {
-		return fmt.Errorf("failed to analyze directory: %w", err)
+		return err
 	}
 
 	progressManager.UpdateIndeterminate("📝 Generating context map...")
@@ -118,15 +133,40 @@ func generateContextMap(cmd *cobra.Command) error {
 			stats["totalFiles"], stats["totalSymbols"])
 	}
 
-	// Generate markdown content from real data
-	generator := analyzer.NewMarkdownGenerator(graph)
-	content := generator.GenerateContextMap()
+	// One combined map, or one map per source root when splitting
+	outputFiles := []string{outputFile}
+	outputGraphs := []*types.CodeGraph{graph}
+	if split, _ := cmd.Flags().GetBool("split"); split && len(graph.Metadata.Roots) > 0 {
+		outputFiles, outputGraphs = nil, nil
+		for _, root := range graph.Metadata.Roots {
+			rootGraph, err := analyzer.RootGraph(graph, root.Label)
+			if err != nil {
+				return err
+			}
+			outputFiles = append(outputFiles, rootOutputFile(outputFile, root.Label))
+			outputGraphs = append(outputGraphs, rootGraph)
+		}
+	}
 
 	progressManager.UpdateIndeterminate("💾 Writing output file...")
 
-	// Write real content
-	if err := writeOutputFile(outputFile, content); err != nil {
-		return fmt.Errorf("failed to write output file: %w", err)
+	redactions := make([]redact.Finding, 0)
+	for i, output := range outputFiles {
+		// Generate markdown content from real data
+		generator := analyzer.NewMarkdownGenerator(outputGraphs[i])
+		generator.SetDeadCodeOptions(deadCodeOptions())
+		generator.SetArchitecture(architecture)
+		generator.SetRedactor(redactor)
+		content := generator.GenerateContextMap()
+		for _, finding := range generator.Redactions() {
+			finding.Source = output
+			redactions = append(redactions, finding)
+		}
+
+		// Write real content
+		if err := writeOutputFile(output, content); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
 	}
 
 	progressManager.UpdateIndeterminate("✅ Complete")
@@ -135,11 +175,62 @@ func generateContextMap(cmd *cobra.Command) error {
 
 	duration := time.Since(start)
 	fmt.Printf("✅ Context map generated successfully in %v\n", duration)
-	fmt.Printf("   Output file: %s\n", outputFile)
+	for _, output := range outputFiles {
+		fmt.Printf("   Output file: %s\n", output)
+	}
+	if err := reportRedactions(cmd, redactions); err != nil {
+		return err
+	}
+	if skipped := graph.Metadata.Errors; len(skipped) > 0 {
+		fmt.Printf("⚠️  %d files could not be analyzed and were skipped\n", len(skipped))
+		if viper.GetBool("verbose") {
+			for _, fileError := range skipped {
+				fmt.Printf("   %s: %s\n", fileError.Path, fileError.Error)
+			}
+		}
+	}
 
 	return nil
 }
 
+// redactionConfig reads the redaction settings from the config file
+func redactionConfig() (redact.Config, error) {
+	var config redact.Config
+	if err := viper.UnmarshalKey("redaction", &config); err != nil {
+		return config, fmt.Errorf("invalid redaction config: %w", err)
+	}
+	return config, nil
+}
+
+// newRedactor creates the redactor applied to context maps, nil when redaction is disabled
+func newRedactor() (*redact.Redactor, error) {
+	config, err := redactionConfig()
+	if err != nil {
+		return nil, err
+	}
+	return redact.New(config)
+}
+
+// reportRedactions summarises the redacted values and lists them in the --redaction-report file
+func reportRedactions(cmd *cobra.Command, redactions []redact.Finding) error {
+	reportFile, _ := cmd.Flags().GetString("redaction-report")
+	if len(redactions) > 0 {
+		fmt.Printf("🔒 %d sensitive values were redacted\n", len(redactions))
+	}
+	if reportFile == "" {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Redaction Report\n\n")
+	redact.WriteReport(&sb, redactions, 0)
+	if err := os.WriteFile(reportFile, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write redaction report: %w", err)
+	}
+	fmt.Printf("   Redaction report: %s\n", reportFile)
+	return nil
+}
+
 func writeOutputFile(filename, content string) error {
 	return os.WriteFile(filename, []byte(content), 0644)
 }
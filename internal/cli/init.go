@@ -74,6 +74,52 @@ languages:
     extensions: [".go"]
     parser: "tree-sitter-go"
 
+# Source Roots
+# Several checkouts can be analyzed into one context map, with imports
+# resolved across them; use 'generate --split' for one map per root, e.g.
+# source_paths:
+#   - backend=../backend
+#   - frontend=../frontend
+
+# Analysis
+# Files taking longer than this to parse are skipped and reported, e.g.
+# file_timeout: 10s
+# TODO and other annotations without an owner, as in TODO(owner), are
+# attributed to their authors with git blame, which runs git once per
+# annotated file changed since the last analysis; turn it off for very large
+# repositories, e.g.
+# blame_annotations: false
+
+# Dead Code Detection
+# Symbols and files matching these patterns are treated as used, e.g. public
+# library APIs or handlers a framework invokes
+dead_code:
+  allow_symbols: []
+  allow_files: []
+
+# Architecture Layers
+# Imports between layers are checked by 'codecontext check', e.g.
+# architecture:
+#   layers:
+#     - name: domain
+#       paths: ["internal/domain/**"]
+#     - name: infrastructure
+#       paths: ["internal/infrastructure/**"]
+#   rules:
+#     - from: domain
+#       deny: [infrastructure]
+
+# Redaction
+# Secrets are replaced with [REDACTED:detector] in context maps and MCP
+# results: private keys, credentials in URLs, common API key formats, .env
+# secrets and high-entropy strings. Add patterns, whose first capture group
+# is redacted when present, or allow known values, e.g.
+# redaction:
+#   patterns:
+#     - name: internal-token
+#       pattern: "itk_[a-z0-9]{32}"
+#   allow: ["^test_"]
+
 # Compact Profiles
 compact_profiles:
   minimal:
@@ -34,12 +34,14 @@ func init() {
 	mcpCmd.Flags().BoolP("watch", "w", true, "enable real-time file watching")
 	mcpCmd.Flags().IntP("debounce", "d", 500, "debounce interval for file changes (ms)")
 	mcpCmd.Flags().StringP("name", "n", "codecontext", "MCP server name")
+	addRootFlag(mcpCmd)
 
 	// Bind flags to viper
 	viper.BindPFlag("mcp.target", mcpCmd.Flags().Lookup("target"))
 	viper.BindPFlag("mcp.watch", mcpCmd.Flags().Lookup("watch"))
 	viper.BindPFlag("mcp.debounce", mcpCmd.Flags().Lookup("debounce"))
 	viper.BindPFlag("mcp.name", mcpCmd.Flags().Lookup("name"))
+	viper.BindPFlag("mcp.roots", mcpCmd.Flags().Lookup("root"))
 }
 
 func runMCPServer() error {
@@ -49,12 +51,38 @@ func runMCPServer() error {
 		targetDir = "."
 	}
 
+	architecture, err := architectureConfig()
+	if err != nil {
+		return err
+	}
+	redaction, err := redactionConfig()
+	if err != nil {
+		return err
+	}
+
+	// Config file source paths apply unless a target directory was chosen
+	rootSpecs := viper.GetStringSlice("mcp.roots")
+	if len(rootSpecs) == 0 && targetDir == "." {
+		rootSpecs = viper.GetStringSlice("source_paths")
+	}
+	roots, err := parseSourceRoots(rootSpecs)
+	if err != nil {
+		return err
+	}
+
 	config := &mcp.MCPConfig{
 		Name:        viper.GetString("mcp.name"),
 		Version:     appVersion,
 		TargetDir:   targetDir,
+		Roots:       roots,
 		EnableWatch: viper.GetBool("mcp.watch"),
 		DebounceMs:  viper.GetInt("mcp.debounce"),
+		DeadCode:    deadCodeOptions(),
+		FileTimeout: viper.GetDuration("file_timeout"),
+		NoBlame:     !annotationBlame(),
+
+		Architecture: architecture,
+		Redaction:    redaction,
 	}
 
 	if viper.GetBool("verbose") {
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/nuthan-ms/codecontext/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// addRootFlag registers the repeatable --root flag on a command that analyzes source code
+func addRootFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray("root", nil, "source root to analyze, as path or label=path; repeat to analyze several roots together")
+}
+
+// sourceRoots returns the roots to analyze from --root flags, or from source_paths in the config file
+// unless --target was given. It returns nil when a single target directory should be analyzed.
+func sourceRoots(cmd *cobra.Command) ([]types.SourceRoot, error) {
+	specs, _ := cmd.Flags().GetStringArray("root")
+	if len(specs) == 0 && !cmd.Flags().Changed("target") {
+		specs = viper.GetStringSlice("source_paths")
+	}
+	return parseSourceRoots(specs)
+}
+
+// parseSourceRoots parses root specs, returning nil when there are none
+func parseSourceRoots(specs []string) ([]types.SourceRoot, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	roots := make([]types.SourceRoot, 0, len(specs))
+	for _, spec := range specs {
+		roots = append(roots, analyzer.ParseSourceRoot(spec))
+	}
+	return analyzer.NormalizeSourceRoots(roots)
+}
+
+// annotationBlame reports whether annotations are attributed to their authors with git blame, which
+// is on unless the config turns it off
+func annotationBlame() bool {
+	return !viper.IsSet("blame_annotations") || viper.GetBool("blame_annotations")
+}
+
+// analyzeSource analyzes the configured source roots, or the --target directory when none are set,
+// until the command's context is cancelled
+func analyzeSource(cmd *cobra.Command, builder *analyzer.GraphBuilder, targetDir string) (*types.CodeGraph, error) {
+	roots, err := sourceRoots(cmd)
+	if err != nil {
+		return nil, err
+	}
+	builder.SetFileTimeout(viper.GetDuration("file_timeout"))
+	builder.SetAnnotationBlame(annotationBlame())
+
+	var graph *types.CodeGraph
+	if roots != nil {
+		graph, err = builder.AnalyzeRootsContext(commandContext(cmd), roots)
+	} else {
+		graph, err = builder.AnalyzeDirectoryContext(commandContext(cmd), targetDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze directory: %w", err)
+	}
+	return graph, nil
+}
+
+// rootOutputFile names the context map of one root when maps are written per root,
+// e.g. CLAUDE.md becomes CLAUDE-frontend.md
+func rootOutputFile(outputFile, label string) string {
+	ext := filepath.Ext(outputFile)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(outputFile, ext), label, ext)
+}
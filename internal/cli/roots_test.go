@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceRoots(t *testing.T) {
+	newCommand := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().StringP("target", "t", ".", "target directory to analyze")
+		addRootFlag(cmd)
+		return cmd
+	}
+
+	// Without roots a single target directory is analyzed
+	roots, err := sourceRoots(newCommand())
+	require.NoError(t, err)
+	assert.Nil(t, roots)
+
+	// Config file source paths apply unless a target is given
+	viper.Set("source_paths", []string{"../backend", "web=../frontend"})
+	defer viper.Set("source_paths", nil)
+	roots, err = sourceRoots(newCommand())
+	require.NoError(t, err)
+	assert.Equal(t, []types.SourceRoot{{Label: "backend", Path: "../backend"}, {Label: "web", Path: "../frontend"}}, roots)
+
+	cmd := newCommand()
+	require.NoError(t, cmd.Flags().Set("target", "src"))
+	roots, err = sourceRoots(cmd)
+	require.NoError(t, err)
+	assert.Nil(t, roots)
+
+	// Root flags take precedence over the config file
+	cmd = newCommand()
+	require.NoError(t, cmd.Flags().Set("root", "api=services/api"))
+	require.NoError(t, cmd.Flags().Set("root", "services/web"))
+	roots, err = sourceRoots(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, []types.SourceRoot{{Label: "api", Path: "services/api"}, {Label: "web", Path: "services/web"}}, roots)
+
+	cmd = newCommand()
+	require.NoError(t, cmd.Flags().Set("root", "a/web"))
+	require.NoError(t, cmd.Flags().Set("root", "b/web"))
+	_, err = sourceRoots(cmd)
+	assert.Error(t, err)
+
+	assert.Equal(t, "out/CLAUDE-web.md", rootOutputFile("out/CLAUDE.md", "web"))
+	assert.Equal(t, "context-api", rootOutputFile("context", "api"))
+}
@@ -81,11 +81,16 @@ func updateContextMap(files []string) error {
 
 func startWatchMode(targetDir, outputFile string) error {
 	debounceTime := viper.GetDuration("debounce")
+	redaction, err := redactionConfig()
+	if err != nil {
+		return err
+	}
 
 	config := watcher.Config{
 		TargetDir:    targetDir,
 		OutputFile:   outputFile,
 		DebounceTime: debounceTime,
+		Redaction:    redaction,
 	}
 
 	fileWatcher, err := watcher.NewFileWatcher(config)
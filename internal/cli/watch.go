@@ -217,11 +217,17 @@ func NewWatchManager(config *WatchConfig) (*WatchManager, error) {
 		return nil, fmt.Errorf("failed to create incremental analyzer: %w", err)
 	}
 
+	redaction, err := redactionConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize file watcher
 	watcherConfig := watcher.Config{
 		TargetDir:    config.TargetDir,
 		OutputFile:   config.OutputFile,
 		DebounceTime: config.UpdateInterval,
+		Redaction:    redaction,
 		ExcludePatterns: []string{
 			".git/*",
 			"node_modules/*",
@@ -313,7 +319,9 @@ func (wm *WatchManager) performInitialAnalysis() error {
 	// If no cached graph, perform full analysis
 	if graph == nil {
 		builder := analyzer.NewGraphBuilder()
-		graph, err = builder.AnalyzeDirectory(wm.config.TargetDir)
+		builder.SetFileTimeout(viper.GetDuration("file_timeout"))
+		builder.SetAnnotationBlame(annotationBlame())
+		graph, err = builder.AnalyzeDirectoryContext(wm.ctx, wm.config.TargetDir)
 		if err != nil {
 			return fmt.Errorf("failed to analyze directory: %w", err)
 		}
@@ -426,8 +434,14 @@ func (wm *WatchManager) generateOutput() error {
 		return fmt.Errorf("no graph available for output generation")
 	}
 
+	redactor, err := newRedactor()
+	if err != nil {
+		return err
+	}
+
 	// Generate markdown content
 	generator := analyzer.NewMarkdownGenerator(graph)
+	generator.SetRedactor(redactor)
 	content := generator.GenerateContextMap()
 
 	// Write to output file
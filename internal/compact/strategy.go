@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/nuthan-ms/codecontext/internal/parser"
 	"github.com/nuthan-ms/codecontext/pkg/types"
 )
 
@@ -558,6 +559,8 @@ func (bs *BaseStrategy) copyGraph(graph *types.CodeGraph) *types.CodeGraph {
 			LastModified: file.LastModified,
 			Symbols:      make([]types.SymbolId, len(file.Symbols)),
 			Imports:      make([]*types.Import, len(file.Imports)),
+			Metrics:      file.Metrics,
+			Annotations:  file.Annotations,
 		}
 		copy(copied.Files[path].Symbols, file.Symbols)
 		copy(copied.Files[path].Imports, file.Imports)
@@ -566,12 +569,14 @@ func (bs *BaseStrategy) copyGraph(graph *types.CodeGraph) *types.CodeGraph {
 	// Copy symbols
 	for id, symbol := range graph.Symbols {
 		copied.Symbols[id] = &types.Symbol{
-			Id:        symbol.Id,
-			Name:      symbol.Name,
-			Type:      symbol.Type,
-			Location:  symbol.Location,
-			Signature: symbol.Signature,
-			Language:  symbol.Language,
+			Id:          symbol.Id,
+			Name:        symbol.Name,
+			Type:        symbol.Type,
+			Location:    symbol.Location,
+			Signature:   symbol.Signature,
+			Language:    symbol.Language,
+			Metrics:     symbol.Metrics,
+			Fingerprint: symbol.Fingerprint,
 		}
 	}
 
@@ -678,6 +683,18 @@ func (rs *RelevanceStrategy) calculateRelevanceScores(graph *types.CodeGraph, re
 		scores.Symbols[symbolId] = 0.1
 	}
 
+	// Complex code is risky to change without seeing it, so it scores by its cognitive complexity
+	for filePath, file := range graph.Files {
+		if file.Metrics != nil {
+			scores.Files[filePath] = math.Max(scores.Files[filePath], complexityRelevance(file.Metrics.MaxCognitive))
+		}
+	}
+	for symbolId, symbol := range graph.Symbols {
+		if symbol.Metrics != nil {
+			scores.Symbols[symbolId] = math.Max(scores.Symbols[symbolId], complexityRelevance(symbol.Metrics.Cognitive))
+		}
+	}
+
 	// Increase scores for preserved items
 	if requirements != nil {
 		for _, filePath := range requirements.PreserveFiles {
@@ -704,6 +721,12 @@ func (rs *RelevanceStrategy) calculateRelevanceScores(graph *types.CodeGraph, re
 	return scores
 }
 
+// complexityRelevance scales cognitive complexity to a relevance score between 0 and 1; functions
+// reaching the complexity flagged as hard to change are fully relevant
+func complexityRelevance(cognitive int) float64 {
+	return math.Min(1.0, float64(cognitive)/parser.ComplexCognitiveThreshold)
+}
+
 func (fs *FrequencyStrategy) calculateFrequencies(graph *types.CodeGraph) *FrequencyInfo {
 	frequencies := &FrequencyInfo{
 		Files:   make(map[string]int),
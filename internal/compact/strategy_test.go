@@ -286,6 +286,8 @@ func TestBaseStrategy_GetDescription(t *testing.T) {
 func TestBaseStrategy_CopyGraph(t *testing.T) {
 	strategy := NewBaseStrategy("test", "Test strategy")
 	original := createTestCodeGraph()
+	original.Files["test2.ts"].Annotations = []*types.Annotation{{Kind: "DEPRECATED", Text: "use v2", Symbol: "symbol1"}}
+	original.Symbols["symbol1"].Fingerprint = &types.Fingerprint{Hash: "abc", Tokens: 60, MinHash: []uint64{1, 2}}
 
 	copied := strategy.copyGraph(original)
 
@@ -330,6 +332,10 @@ func TestBaseStrategy_CopyGraph(t *testing.T) {
 		if copiedFile.Language != originalFile.Language {
 			t.Errorf("File language mismatch: expected %s, got %s", originalFile.Language, copiedFile.Language)
 		}
+
+		if len(copiedFile.Annotations) != len(originalFile.Annotations) {
+			t.Errorf("File annotations mismatch: expected %d, got %d", len(originalFile.Annotations), len(copiedFile.Annotations))
+		}
 	}
 
 	// Verify symbols are copied correctly
@@ -347,6 +353,10 @@ func TestBaseStrategy_CopyGraph(t *testing.T) {
 		if copiedSymbol.Type != originalSymbol.Type {
 			t.Errorf("Symbol type mismatch: expected %s, got %s", originalSymbol.Type, copiedSymbol.Type)
 		}
+
+		if copiedSymbol.Fingerprint != originalSymbol.Fingerprint {
+			t.Errorf("Symbol %s fingerprint was not copied", id)
+		}
 	}
 }
 
@@ -463,6 +473,35 @@ func TestRelevanceStrategy_CalculateRelevanceScores(t *testing.T) {
 	}
 }
 
+func TestRelevanceStrategy_KeepsComplexCode(t *testing.T) {
+	strategy := NewRelevanceStrategy()
+	graph := createTestCodeGraph()
+	graph.Symbols["symbol1"].Metrics = &types.CodeMetrics{Lines: 40, Cyclomatic: 12, Cognitive: 20}
+	graph.Files["test2.ts"].Metrics = &types.FileMetrics{Functions: 1, MaxCyclomatic: 4, MaxCognitive: 6}
+
+	scores := strategy.calculateRelevanceScores(graph, nil)
+	if scores.Symbols["symbol1"] != 1.0 {
+		t.Errorf("Expected score 1.0 for a highly complex symbol, got %f", scores.Symbols["symbol1"])
+	}
+	if scores.Symbols["symbol2"] != 0.1 {
+		t.Errorf("Expected base score for a symbol without metrics, got %f", scores.Symbols["symbol2"])
+	}
+	if scores.Files["test2.ts"] != 0.4 {
+		t.Errorf("Expected score 0.4 for a file with a moderately complex function, got %f", scores.Files["test2.ts"])
+	}
+
+	result, err := strategy.Compact(context.Background(), &CompactRequest{Graph: graph, MaxSize: 5})
+	if err != nil {
+		t.Fatalf("RelevanceStrategy.Compact failed: %v", err)
+	}
+	if result.CompactedGraph.Symbols["symbol1"] == nil || result.CompactedGraph.Files["test2.ts"] == nil {
+		t.Error("Expected complex code to survive compaction")
+	}
+	if result.CompactedGraph.Symbols["symbol1"].Metrics == nil {
+		t.Error("Expected metrics to be copied with the symbol")
+	}
+}
+
 func TestFrequencyStrategy_CalculateFrequencies(t *testing.T) {
 	strategy := NewFrequencyStrategy()
 	graph := createTestCodeGraph()
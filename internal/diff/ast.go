@@ -479,7 +479,12 @@ func (ad *ASTDiffer) buildStructuralContext(symbol *types.Symbol, file *types.Fi
 }
 
 func (ad *ASTDiffer) calculateSymbolComplexity(symbol *types.Symbol) int {
-	// Simple complexity calculation based on signature and documentation
+	// Functions and methods carry complexity measured from their body
+	if symbol.Metrics != nil {
+		return symbol.Metrics.Cyclomatic
+	}
+
+	// Estimate other symbols from signature and size
 	complexity := 1
 
 	// Add complexity for parameters
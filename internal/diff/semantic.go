@@ -212,7 +212,7 @@ func (sd *SemanticDiffer) compareSymbolSemantics(oldSymbol, newSymbol *types.Sym
 				"semantic_type":   "signature_change",
 				"breaking_change": sd.isBreakingSignatureChange(oldSymbol, newSymbol),
 				"parameter_count": sd.countParameters(newSymbol.Signature),
-				"return_type":     sd.extractReturnType(newSymbol.Signature),
+				"return_type":     sd.extractSymbolReturnType(newSymbol),
 			},
 		}
 		changes = append(changes, change)
@@ -316,27 +316,45 @@ func (sd *SemanticDiffer) assessVisibilityChangeImpact(oldSymbol, newSymbol *typ
 
 // isBreakingSignatureChange determines if a signature change is breaking
 func (sd *SemanticDiffer) isBreakingSignatureChange(oldSymbol, newSymbol *types.Symbol) bool {
-	oldParamCount := sd.countParameters(oldSymbol.Signature)
-	newParamCount := sd.countParameters(newSymbol.Signature)
+	return sd.signatureBreak(oldSymbol, newSymbol) != ""
+}
+
+// signatureBreak explains why a signature change breaks callers, or returns "" when it does not
+func (sd *SemanticDiffer) signatureBreak(oldSymbol, newSymbol *types.Symbol) string {
+	oldParams := SplitParameters(oldSymbol.Signature)
+	newParams := SplitParameters(newSymbol.Signature)
 
 	// Removing parameters is always breaking
-	if newParamCount < oldParamCount {
-		return true
+	if len(newParams) < len(oldParams) {
+		return "parameters removed"
 	}
 
-	// Adding required parameters is breaking
-	if newParamCount > oldParamCount && !sd.hasDefaultParameters(newSymbol.Signature) {
-		return true
+	// Adding required parameters is breaking, as is making an optional parameter required
+	if sd.requiredParameters(newParams) > sd.requiredParameters(oldParams) {
+		return "required parameters added"
 	}
 
 	// Return type changes can be breaking
-	oldReturnType := sd.extractReturnType(oldSymbol.Signature)
-	newReturnType := sd.extractReturnType(newSymbol.Signature)
+	oldReturnType := sd.extractSymbolReturnType(oldSymbol)
+	newReturnType := sd.extractSymbolReturnType(newSymbol)
 	if oldReturnType != newReturnType {
-		return true
+		return "return type changed"
 	}
 
-	return false
+	return ""
+}
+
+// BreakingChange explains why changing oldSymbol into newSymbol breaks code using it: reduced
+// visibility, removed or added required parameters, or a changed return type. It returns "" when
+// the change is compatible.
+func (sd *SemanticDiffer) BreakingChange(oldSymbol, newSymbol *types.Symbol) string {
+	if sd.isBreakingVisibilityChange(oldSymbol, newSymbol) {
+		return fmt.Sprintf("visibility reduced from %s to %s", oldSymbol.Visibility, newSymbol.Visibility)
+	}
+	if oldSymbol.Signature != newSymbol.Signature {
+		return sd.signatureBreak(oldSymbol, newSymbol)
+	}
+	return ""
 }
 
 // isBreakingVisibilityChange determines if a visibility change is breaking
@@ -409,22 +427,82 @@ func (sd *SemanticDiffer) buildDocumentationChangeContext(oldSymbol, newSymbol *
 // Helper methods for signature analysis
 
 func (sd *SemanticDiffer) countParameters(signature string) int {
-	// Simple parameter counting (language-agnostic)
-	if !strings.Contains(signature, "(") {
-		return 0
-	}
+	return len(SplitParameters(signature))
+}
 
-	paramSection := strings.Split(signature, "(")[1]
-	if !strings.Contains(paramSection, ")") {
-		return 0
+// SplitParameters splits the first parameter list of a signature, after any Go receiver, at the
+// commas outside nested brackets, so generic and function-typed parameters count once
+func SplitParameters(signature string) []string {
+	if strings.HasPrefix(signature, "func (") {
+		if end := closingParen(signature, len("func ")); end >= 0 {
+			signature = signature[end+1:]
+		}
+	}
+	open := strings.Index(signature, "(")
+	if open < 0 {
+		return nil
+	}
+	end := closingParen(signature, open)
+	if end < 0 {
+		return nil
+	}
+
+	params := make([]string, 0)
+	depth, start := 0, open+1
+	for i := open + 1; i <= end; i++ {
+		switch signature[i] {
+		case '(', '[', '{', '<':
+			depth++
+		case ')', ']', '}', '>':
+			if signature[i] == '>' && (signature[i-1] == '=' || signature[i-1] == '-') {
+				// Arrow of a function type
+				continue
+			}
+			depth--
+		}
+		if (signature[i] == ',' && depth == 0) || i == end {
+			// Trailing commas leave an empty entry
+			if param := strings.TrimSpace(signature[start:i]); param != "" {
+				params = append(params, param)
+			}
+			start = i + 1
+		}
 	}
+	return params
+}
 
-	paramSection = strings.Split(paramSection, ")")[0]
-	if strings.TrimSpace(paramSection) == "" {
-		return 0
+// requiredParameters counts the parameters callers must pass: those without a default value that
+// are neither optional (TypeScript name?) nor variadic (Go and Java ..., JS ...rest, Python *args)
+func (sd *SemanticDiffer) requiredParameters(params []string) int {
+	required := 0
+	for _, param := range params {
+		name := strings.TrimSpace(strings.SplitN(param, ":", 2)[0])
+		switch {
+		case strings.Contains(param, "..."), strings.HasPrefix(param, "*"):
+		case strings.Contains(strings.ReplaceAll(param, "=>", ""), "="):
+		case strings.HasSuffix(name, "?"):
+		default:
+			required++
+		}
 	}
+	return required
+}
 
-	return strings.Count(paramSection, ",") + 1
+// closingParen returns the index of the parenthesis closing the one at open, or -1
+func closingParen(signature string, open int) int {
+	depth := 0
+	for i := open; i < len(signature); i++ {
+		switch signature[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
 }
 
 func (sd *SemanticDiffer) extractReturnType(signature string) string {
@@ -446,9 +524,40 @@ func (sd *SemanticDiffer) extractReturnType(signature string) string {
 	return "unknown"
 }
 
-func (sd *SemanticDiffer) hasDefaultParameters(signature string) bool {
-	// Simple check for default parameters
-	return strings.Contains(signature, "=") || strings.Contains(signature, "?")
+// extractSymbolReturnType extracts a symbol's return type. Go lists results after the parameters
+// without a separator, as in Name(key string) (int, error); Java declares it before the name.
+func (sd *SemanticDiffer) extractSymbolReturnType(symbol *types.Symbol) string {
+	signature := symbol.Signature
+	switch symbol.Language {
+	case "go":
+		if strings.HasPrefix(signature, "func (") {
+			// Skip the receiver of a method
+			if end := closingParen(signature, len("func ")); end >= 0 {
+				signature = signature[end+1:]
+			}
+		}
+		open := strings.Index(signature, "(")
+		if open < 0 {
+			return "unknown"
+		}
+		if end := closingParen(signature, open); end >= 0 {
+			return strings.TrimSpace(signature[end+1:])
+		}
+		return "unknown"
+	case "java":
+		open := strings.Index(signature, "(")
+		if open < 0 {
+			return "unknown"
+		}
+		// Drop the method name following the type
+		declaration := strings.TrimSpace(signature[:open])
+		if space := strings.LastIndex(declaration, " "); space >= 0 {
+			return declaration[:space]
+		}
+		return "unknown"
+	default:
+		return sd.extractReturnType(signature)
+	}
 }
 
 func (sd *SemanticDiffer) assessDocumentationQuality(doc string) string {
@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestSemanticDiffer_BreakingChange(t *testing.T) {
+	differ := NewSemanticDiffer(DefaultConfig())
+
+	tests := []struct {
+		name         string
+		language     string
+		oldSignature string
+		newSignature string
+		expected     string
+	}{
+		{"unchanged", "go", "func Get(key string) (*Item, error)", "func Get(key string) (*Item, error)", ""},
+		{"go parameter added", "go", "func Get(key string) (*Item, error)", "func Get(ctx context.Context, key string) (*Item, error)", "required parameters added"},
+		{"go parameter removed", "go", "func Get(ctx context.Context, key string) error", "func Get(key string) error", "parameters removed"},
+		{"go result changed", "go", "func Get(key string) *Item", "func Get(key string) (*Item, error)", "return type changed"},
+		{"go method parameter added", "go", "func (s *Store) Keys() []string", "func (s *Store) Keys(prefix string) []string", "required parameters added"},
+		{"go variadic added", "go", "func Printf(format string)", "func Printf(format string, args ...any)", ""},
+		{"go parameter added before variadic", "go", "func Printf(format string, args ...any)", "func Printf(level int, format string, args ...any)", "required parameters added"},
+		{"go variadic made required", "go", "func Join(sep string, parts ...string) string", "func Join(sep string, parts []string) string", "required parameters added"},
+		{"go function typed parameter", "go", "func Walk(fn func(a, b int) error)", "func Walk(fn func(a, b, c int) error)", ""},
+		{"js rest parameter added", "typescript", "(prefix: string): string[]", "(prefix: string, ...rest: string[]): string[]", ""},
+		{"js parameter added before rest", "typescript", "(...rest: string[]): string[]", "(prefix: string, ...rest: string[]): string[]", "required parameters added"},
+		{"ts optional parameter added", "typescript", "(key: string): Item", "(key: string, fallback?: Item): Item", ""},
+		{"ts callback parameter added", "typescript", "(key: string): void", "(key: string, done: (err: Error) => void): void", "required parameters added"},
+		{"ts trailing comma", "typescript", "(key: string): Item", "(key: string,): Item", ""},
+		{"ts return type changed", "typescript", "(key: string): Item", "(key: string): Promise<Item>", "return type changed"},
+		{"python default parameter added", "python", "(key: str) -> Item", "(key: str, default: int = 0) -> Item", ""},
+		{"python args added", "python", "(key: str) -> Item", "(key: str, *args, **kwargs) -> Item", ""},
+		{"java varargs added", "java", "List<String> keys(String prefix)", "List<String> keys(String prefix, String... more)", ""},
+		{"java generic parameter added", "java", "Item get(String key)", "Item get(String key, Map<String, Integer> options)", "required parameters added"},
+		{"java return type changed", "java", "Item get(String key)", "Optional<Item> get(String key)", "return type changed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldSymbol := &types.Symbol{Name: "f", Signature: tt.oldSignature, Language: tt.language, Visibility: "public"}
+			newSymbol := &types.Symbol{Name: "f", Signature: tt.newSignature, Language: tt.language, Visibility: "public"}
+			if reason := differ.BreakingChange(oldSymbol, newSymbol); reason != tt.expected {
+				t.Errorf("BreakingChange() = %q, expected %q", reason, tt.expected)
+			}
+		})
+	}
+
+	oldSymbol := &types.Symbol{Name: "f", Signature: "(key: string): Item", Visibility: "public"}
+	newSymbol := &types.Symbol{Name: "f", Signature: "(key: string): Item", Visibility: "private"}
+	if reason := differ.BreakingChange(oldSymbol, newSymbol); reason != "visibility reduced from public to private" {
+		t.Errorf("BreakingChange() = %q, expected reduced visibility", reason)
+	}
+}
+
+func TestSemanticDiffer_Parameters(t *testing.T) {
+	differ := NewSemanticDiffer(DefaultConfig())
+
+	tests := []struct {
+		signature string
+		expected  int
+	}{
+		{"func Get() error", 0},
+		{"func (s *Store) Get(key string) error", 1},
+		{"func Get(a, b int, opts ...Option) error", 3},
+		{"(cb: (a: number, b: number) => void, ...rest: any[])", 2},
+		{"Item get(Map<String, Integer> options, String key)", 2},
+		{"(key: str, default: Dict[str, int] = {}) -> Item", 2},
+		{"Store", 0},
+	}
+
+	for _, tt := range tests {
+		if count := differ.countParameters(tt.signature); count != tt.expected {
+			t.Errorf("countParameters(%q) = %d, expected %d", tt.signature, count, tt.expected)
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package git
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -328,6 +332,110 @@ func (g *GitAnalyzer) ExecuteGitCommand(ctx context.Context, args ...string) ([]
 	return output, nil
 }
 
+// ExtractTree writes the files of the repository directory as of ref into dir, leaving the working
+// tree and index untouched
+func (g *GitAnalyzer) ExtractTree(ctx context.Context, ref, dir string) error {
+	location, err := g.ExecuteGitCommand(ctx, "rev-parse", "--show-toplevel", "--show-prefix")
+	if err != nil {
+		return err
+	}
+	// Archive from the top level, which git archive requires for a subdirectory tree
+	lines := strings.SplitN(strings.TrimRight(string(location), "\n"), "\n", 2)
+	prefix := ""
+	if len(lines) == 2 {
+		prefix = lines[1]
+	}
+	archive, err := g.ExecuteGitCommand(ctx, "-C", lines[0], "archive", "--format=tar", ref+":"+prefix)
+	if err != nil {
+		return err
+	}
+
+	reader := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive of %s: %w", ref, err)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(filepath.Separator)) {
+			continue
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode)&0755|0644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(file, reader)
+			file.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// BlameLine attributes a line of a file to the commit that last changed it
+type BlameLine struct {
+	Commit    string
+	Author    string
+	Email     string
+	Timestamp time.Time
+}
+
+// BlameLines attributes the given lines of a file, numbered from 1, to the commits that last changed
+// them. Lines without a commit, such as uncommitted changes, are left out.
+func (g *GitAnalyzer) BlameLines(ctx context.Context, filePath string, lines []int) (map[int]BlameLine, error) {
+	args := []string{"blame", "--line-porcelain"}
+	for _, line := range lines {
+		args = append(args, "-L", fmt.Sprintf("%d,%d", line, line))
+	}
+	output, err := g.ExecuteGitCommand(ctx, append(args, "--", filePath)...)
+	if err != nil {
+		return nil, err
+	}
+
+	blame := make(map[int]BlameLine)
+	var current BlameLine
+	line := 0
+	for _, text := range strings.Split(string(output), "\n") {
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			// The line's content ends its entry
+			if strings.Trim(current.Commit, "0") != "" {
+				blame[line] = current
+			}
+			current, line = BlameLine{}, 0
+		case line == 0:
+			// Entries start with: commit original-line final-line [group-size]
+			fields := strings.Fields(text)
+			if len(fields) >= 3 {
+				current.Commit = fields[0]
+				line, _ = strconv.Atoi(fields[2])
+			}
+		case strings.HasPrefix(text, "author "):
+			current.Author = strings.TrimPrefix(text, "author ")
+		case strings.HasPrefix(text, "author-mail "):
+			current.Email = strings.Trim(strings.TrimPrefix(text, "author-mail "), "<>")
+		case strings.HasPrefix(text, "author-time "):
+			if seconds, err := strconv.ParseInt(strings.TrimPrefix(text, "author-time "), 10, 64); err == nil {
+				current.Timestamp = time.Unix(seconds, 0)
+			}
+		}
+	}
+	return blame, nil
+}
+
 // GetBranchInfo returns current branch information
 func (g *GitAnalyzer) GetBranchInfo() (string, error) {
 	output, err := g.ExecuteGitCommand(context.Background(), "rev-parse", "--abbrev-ref", "HEAD")
@@ -1,6 +1,9 @@
 package git
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -247,6 +250,46 @@ func TestGitAnalyzer_GetRemoteInfo(t *testing.T) {
 	t.Logf("Remote URL: %s", remote)
 }
 
+func TestGitAnalyzer_ExtractTree(t *testing.T) {
+	analyzer, err := NewGitAnalyzer(".")
+	if err != nil {
+		t.Skipf("skipping test: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := analyzer.ExtractTree(context.Background(), "HEAD", dir); err != nil {
+		t.Skipf("skipping test: %v", err)
+	}
+
+	// The tree is extracted relative to the analyzer's directory, not the repository root
+	if _, err := os.Stat(filepath.Join(dir, "analyzer.go")); err != nil {
+		t.Errorf("expected analyzer.go in the extracted tree: %v", err)
+	}
+	if err := analyzer.ExtractTree(context.Background(), "no-such-ref", t.TempDir()); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+}
+
+func TestGitAnalyzer_BlameLines(t *testing.T) {
+	analyzer, err := NewGitAnalyzer(".")
+	if err != nil {
+		t.Skipf("skipping test: %v", err)
+	}
+
+	blame, err := analyzer.BlameLines(context.Background(), "analyzer.go", []int{1, 3})
+	if err != nil {
+		t.Skipf("skipping test: %v", err)
+	}
+	for _, line := range []int{1, 3} {
+		if entry, ok := blame[line]; !ok || len(entry.Commit) != 40 || entry.Author == "" || entry.Timestamp.IsZero() {
+			t.Errorf("line %d: unexpected blame %+v", line, entry)
+		}
+	}
+	if len(blame) != 2 {
+		t.Errorf("expected two blamed lines, got %+v", blame)
+	}
+}
+
 func TestParseFileChanges(t *testing.T) {
 	analyzer := &GitAnalyzer{
 		repoPath: ".",
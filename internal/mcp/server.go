@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/nuthan-ms/codecontext/internal/analyzer"
 	"github.com/nuthan-ms/codecontext/internal/git"
+	"github.com/nuthan-ms/codecontext/internal/redact"
 	"github.com/nuthan-ms/codecontext/internal/watcher"
 	"github.com/nuthan-ms/codecontext/pkg/types"
 )
@@ -23,8 +26,18 @@ type MCPConfig struct {
 	TargetDir   string `json:"target_dir"`
 	EnableWatch bool   `json:"enable_watch"`
 	DebounceMs  int    `json:"debounce_ms"`
+
+	Roots        []types.SourceRoot          `json:"roots,omitempty"` // Analyzed instead of TargetDir when set
+	FileTimeout  time.Duration               `json:"file_timeout"`    // Longest time spent on one file, 0 for no limit
+	NoBlame      bool                        `json:"no_blame"`        // Skip attributing annotations to authors with git blame
+	DeadCode     analyzer.DeadCodeOptions    `json:"dead_code"`
+	Architecture analyzer.ArchitectureConfig `json:"architecture"`
+	Redaction    redact.Config               `json:"redaction"` // Secrets redacted from every tool result
 }
 
+// maxFileDocSections caps the documentation sections included in a file analysis
+const maxFileDocSections = 5
+
 // CodeContextMCPServer provides codecontext functionality via MCP
 type CodeContextMCPServer struct {
 	server   *mcp.Server
@@ -32,6 +45,7 @@ type CodeContextMCPServer struct {
 	watcher  *watcher.FileWatcher
 	graph    *types.CodeGraph
 	analyzer *analyzer.GraphBuilder
+	redactor *redact.Redactor
 }
 
 // Tool argument structs
@@ -70,6 +84,43 @@ type GetSemanticNeighborhoodsArgs struct {
 	MaxResults   int    `json:"max_results,omitempty"`
 }
 
+type GetCallersArgs struct {
+	SymbolName string `json:"symbol_name"`
+	FilePath   string `json:"file_path,omitempty"`
+}
+
+type GetImplementationsArgs struct {
+	InterfaceName string `json:"interface_name"`
+	FilePath      string `json:"file_path,omitempty"`
+}
+
+type FindDeadCodeArgs struct {
+	AllowSymbols []string `json:"allow_symbols,omitempty"`
+	AllowFiles   []string `json:"allow_files,omitempty"`
+}
+
+type CheckArchitectureArgs struct {
+	Layer string `json:"layer,omitempty"` // Only report violations from this layer
+}
+
+type FindClonesArgs struct {
+	MinTokens  int     `json:"min_tokens,omitempty"` // Smallest function body considered, in tokens
+	Similarity float64 `json:"similarity,omitempty"` // Lowest similarity of near clones, 0 to 1
+	FilePath   string  `json:"file_path,omitempty"`  // Only report groups with a member in this file
+}
+
+type ListRoutesArgs struct {
+	Method     string `json:"method,omitempty"`      // Only list routes accepting this HTTP method
+	PathPrefix string `json:"path_prefix,omitempty"` // Only list routes under this path
+	FilePath   string `json:"file_path,omitempty"`   // Only list routes registered in this file
+}
+
+type ListAnnotationsArgs struct {
+	Kinds    []string `json:"kinds,omitempty"`     // TODO, FIXME, HACK, XXX or DEPRECATED
+	FilePath string   `json:"file_path,omitempty"` // Only list annotations in files whose path contains this
+	Author   string   `json:"author,omitempty"`    // Only list annotations by this author
+}
+
 // NewCodeContextMCPServer creates a new MCP server instance
 func NewCodeContextMCPServer(config *MCPConfig) (*CodeContextMCPServer, error) {
 	// Redirect all logging to stderr for MCP compatibility
@@ -82,12 +133,21 @@ func NewCodeContextMCPServer(config *MCPConfig) (*CodeContextMCPServer, error) {
 		Version: config.Version,
 	}, nil)
 	log.Printf("[MCP] Created MCP server with name=%s, version=%s", config.Name, config.Version)
+
+	redactor, err := redact.New(config.Redaction)
+	if err != nil {
+		return nil, err
+	}
 	
 	s := &CodeContextMCPServer{
 		server:   server,
 		config:   config,
 		analyzer: analyzer.NewGraphBuilder(),
+		redactor: redactor,
 	}
+	s.analyzer.SetFileTimeout(config.FileTimeout)
+	s.analyzer.SetAnnotationBlame(!config.NoBlame)
+	server.AddReceivingMiddleware(s.redactToolResults)
 	log.Printf("[MCP] Created CodeContextMCPServer instance")
 
 	// Register tools
@@ -98,6 +158,34 @@ func NewCodeContextMCPServer(config *MCPConfig) (*CodeContextMCPServer, error) {
 	return s, nil
 }
 
+// redactToolResults redacts secrets from the text of every tool result before it is sent
+func (s *CodeContextMCPServer) redactToolResults(next mcp.MethodHandler[*mcp.ServerSession]) mcp.MethodHandler[*mcp.ServerSession] {
+	return func(ctx context.Context, session *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+		result, err := next(ctx, session, method, params)
+		toolResult, ok := result.(*mcp.CallToolResult)
+		if err != nil || !ok || s.redactor == nil {
+			return result, err
+		}
+
+		tool := method
+		if call, ok := params.(*mcp.CallToolParamsFor[json.RawMessage]); ok {
+			tool = call.Name
+		}
+		for _, content := range toolResult.Content {
+			text, ok := content.(*mcp.TextContent)
+			if !ok {
+				continue
+			}
+			var findings []redact.Finding
+			text.Text, findings = s.redactor.Redact(tool, text.Text)
+			for _, finding := range findings {
+				log.Printf("[MCP] Redacted %s value %s from %s line %d", finding.Detector, finding.Preview, finding.Source, finding.Line)
+			}
+		}
+		return result, err
+	}
+}
+
 // registerTools registers all MCP tools
 func (s *CodeContextMCPServer) registerTools() {
 	// Tool 1: Get codebase overview
@@ -149,7 +237,56 @@ func (s *CodeContextMCPServer) registerTools() {
 		Description: "Get semantic code neighborhoods using git patterns and hierarchical clustering",
 	}, s.getSemanticNeighborhoods)
 	
-	log.Printf("[MCP] Successfully registered 7 tools")
+	// Tool 8: Get callers
+	log.Printf("[MCP] Registering tool: get_callers")
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_callers",
+		Description: "Find the functions and methods that call a symbol, with call-site locations",
+	}, s.getCallers)
+
+	// Tool 9: Get implementations
+	log.Printf("[MCP] Registering tool: get_implementations")
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "get_implementations",
+		Description: "Find the types implementing an interface or extending a base type, including Go types that satisfy an interface implicitly",
+	}, s.getImplementations)
+
+	// Tool 10: Find dead code
+	log.Printf("[MCP] Registering tool: find_dead_code")
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "find_dead_code",
+		Description: "Report exported symbols nothing references, files nothing imports and functions unreachable from entry points",
+	}, s.findDeadCode)
+
+	// Tool 11: Check architecture layers
+	log.Printf("[MCP] Registering tool: check_architecture")
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "check_architecture",
+		Description: "Check imports against the configured architecture layers and report the ones that violate a rule",
+	}, s.checkArchitecture)
+
+	// Tool 12: Find duplicated code
+	log.Printf("[MCP] Registering tool: find_clones")
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "find_clones",
+		Description: "Find groups of functions with identical or near-identical bodies, ignoring identifier names and literal values",
+	}, s.findClones)
+
+	// Tool 13: List HTTP routes
+	log.Printf("[MCP] Registering tool: list_routes")
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "list_routes",
+		Description: "List the HTTP routes registered with Express, Fastify, gin, chi, echo, net/http, Flask, FastAPI, Spring and Next.js, with their handlers",
+	}, s.listRoutes)
+
+	// Tool 14: List annotations
+	log.Printf("[MCP] Registering tool: list_annotations")
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "list_annotations",
+		Description: "List TODO, FIXME, HACK, XXX and @deprecated comments with their location, enclosing symbol and author from git blame",
+	}, s.listAnnotations)
+
+	log.Printf("[MCP] Successfully registered 14 tools")
 }
 
 // Tool implementations
@@ -161,13 +298,16 @@ func (s *CodeContextMCPServer) getCodebaseOverview(ctx context.Context, cc *mcp.
 	
 	// Ensure we have fresh analysis
 	log.Printf("[MCP] Refreshing analysis for codebase overview...")
-	if err := s.refreshAnalysis(); err != nil {
+	if err := s.refreshAnalysis(ctx); err != nil {
 		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
 		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
 	}
 
 	log.Printf("[MCP] Generating markdown content...")
 	generator := analyzer.NewMarkdownGenerator(s.graph)
+	generator.SetDeadCodeOptions(s.config.DeadCode)
+	generator.SetArchitecture(s.config.Architecture)
+	generator.SetRedactor(nil) // Tool results are redacted, and redactions logged, by redactToolResults
 	content := generator.GenerateContextMap()
 	log.Printf("[MCP] Generated markdown content (%d chars)", len(content))
 
@@ -198,7 +338,7 @@ func (s *CodeContextMCPServer) getFileAnalysis(ctx context.Context, cc *mcp.Serv
 
 	// Ensure we have fresh analysis
 	log.Printf("[MCP] Refreshing analysis for file: %s", args.FilePath)
-	if err := s.refreshAnalysis(); err != nil {
+	if err := s.refreshAnalysis(ctx); err != nil {
 		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
 		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
 	}
@@ -216,15 +356,25 @@ func (s *CodeContextMCPServer) getFileAnalysis(ctx context.Context, cc *mcp.Serv
 	analysis := fmt.Sprintf("# File Analysis: %s\n\n", args.FilePath)
 	analysis += fmt.Sprintf("**Language:** %s\n", fileNode.Language)
 	analysis += fmt.Sprintf("**Lines:** %d\n", fileNode.Lines)
-	analysis += fmt.Sprintf("**Symbols:** %d\n\n", len(fileNode.Symbols))
+	analysis += fmt.Sprintf("**Symbols:** %d\n", len(fileNode.Symbols))
+	if metrics := fileNode.Metrics; metrics != nil {
+		analysis += fmt.Sprintf("**Complexity:** %d functions, max cyclomatic %d, max cognitive %d\n",
+			metrics.Functions, metrics.MaxCyclomatic, metrics.MaxCognitive)
+	}
+	analysis += "\n"
 
 	// List symbols in this file
 	if len(fileNode.Symbols) > 0 {
 		analysis += "## Symbols\n\n"
 		for _, symbolId := range fileNode.Symbols {
 			if symbol, exists := s.graph.Symbols[symbolId]; exists {
-				analysis += fmt.Sprintf("- **%s** (%s) - Line %d\n", 
+				analysis += fmt.Sprintf("- **%s** (%s) - Line %d", 
 					symbol.Name, symbol.Kind, symbol.Location.StartLine)
+				if metrics := symbol.Metrics; metrics != nil {
+					analysis += fmt.Sprintf(" - %d lines, cyclomatic %d, cognitive %d, nesting %d, %d params",
+						metrics.Lines, metrics.Cyclomatic, metrics.Cognitive, metrics.MaxNesting, metrics.Parameters)
+				}
+				analysis += "\n"
 			}
 		}
 	}
@@ -247,6 +397,13 @@ func (s *CodeContextMCPServer) getFileAnalysis(ctx context.Context, cc *mcp.Serv
 	}
 	log.Printf("[MCP] Found %d imports for file: %s", importCount, args.FilePath)
 
+	// Design docs and ADRs explain what the code cannot
+	references := analyzer.DocumentationFor(s.graph, args.FilePath)
+	var docs strings.Builder
+	analyzer.WriteDocReferences(&docs, references, maxFileDocSections)
+	analysis += "\n## Documentation\n\n" + docs.String()
+	log.Printf("[MCP] Found %d documentation sections for file: %s", len(references), args.FilePath)
+
 	elapsed := time.Since(start)
 	log.Printf("[MCP] Tool completed: get_file_analysis (took %v)", elapsed)
 	return &mcp.CallToolResultFor[any]{
@@ -266,7 +423,7 @@ func (s *CodeContextMCPServer) getSymbolInfo(ctx context.Context, cc *mcp.Server
 
 	// Ensure we have fresh analysis
 	log.Printf("[MCP] Refreshing analysis for symbol lookup: %s", args.SymbolName)
-	if err := s.refreshAnalysis(); err != nil {
+	if err := s.refreshAnalysis(ctx); err != nil {
 		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
 		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
 	}
@@ -326,24 +483,13 @@ func (s *CodeContextMCPServer) searchSymbols(ctx context.Context, cc *mcp.Server
 
 	// Ensure we have fresh analysis
 	log.Printf("[MCP] Refreshing analysis for symbol search...")
-	if err := s.refreshAnalysis(); err != nil {
+	if err := s.refreshAnalysis(ctx); err != nil {
 		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
 		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
 	}
 
-	var matches []*types.Symbol
-	query := strings.ToLower(args.Query)
-	log.Printf("[MCP] Searching through %d symbols for query: %s", len(s.graph.Symbols), query)
-
-	for _, symbol := range s.graph.Symbols {
-		if strings.Contains(strings.ToLower(symbol.Name), query) {
-			matches = append(matches, symbol)
-			if len(matches) >= args.Limit {
-				log.Printf("[MCP] Reached limit of %d matches", args.Limit)
-				break
-			}
-		}
-	}
+	log.Printf("[MCP] Searching through %d symbols for query: %s", len(s.graph.Symbols), args.Query)
+	matches := rankSymbolMatches(s.graph, args.Query, args.Limit)
 
 	if len(matches) == 0 {
 		result := fmt.Sprintf("No symbols found matching '%s'", args.Query)
@@ -374,7 +520,7 @@ func (s *CodeContextMCPServer) getDependencies(ctx context.Context, cc *mcp.Serv
 	
 	// Ensure we have fresh analysis
 	log.Printf("[MCP] Refreshing analysis for dependency analysis...")
-	if err := s.refreshAnalysis(); err != nil {
+	if err := s.refreshAnalysis(ctx); err != nil {
 		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
 		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
 	}
@@ -533,7 +679,7 @@ func (s *CodeContextMCPServer) getSemanticNeighborhoods(ctx context.Context, cc
 
 	// Ensure we have fresh analysis
 	if s.graph == nil {
-		if err := s.refreshAnalysis(); err != nil {
+		if err := s.refreshAnalysis(ctx); err != nil {
 			log.Printf("[MCP] Failed to refresh analysis: %v", err)
 			return &mcp.CallToolResultFor[any]{
 				Content: []mcp.Content{&mcp.TextContent{Text: "Failed to analyze codebase: " + err.Error()}},
@@ -561,16 +707,263 @@ func (s *CodeContextMCPServer) getSemanticNeighborhoods(ctx context.Context, cc
 	}, nil
 }
 
+func (s *CodeContextMCPServer) getCallers(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[GetCallersArgs]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	log.Printf("[MCP] Tool called: get_callers with args: %+v", args)
+	start := time.Now()
+
+	if args.SymbolName == "" {
+		log.Printf("[MCP] ERROR: symbol_name is required")
+		return nil, fmt.Errorf("symbol_name is required")
+	}
+
+	// Ensure we have fresh analysis
+	log.Printf("[MCP] Refreshing analysis for caller lookup: %s", args.SymbolName)
+	if err := s.refreshAnalysis(ctx); err != nil {
+		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
+		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
+	}
+
+	result, err := buildCallersResponse(s.graph, args)
+	if err != nil {
+		log.Printf("[MCP] ERROR: %v", err)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	log.Printf("[MCP] Tool completed: get_callers (took %v)", elapsed)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: result}},
+	}, nil
+}
+
+func (s *CodeContextMCPServer) getImplementations(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[GetImplementationsArgs]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	log.Printf("[MCP] Tool called: get_implementations with args: %+v", args)
+	start := time.Now()
+
+	if args.InterfaceName == "" {
+		log.Printf("[MCP] ERROR: interface_name is required")
+		return nil, fmt.Errorf("interface_name is required")
+	}
+
+	// Ensure we have fresh analysis
+	log.Printf("[MCP] Refreshing analysis for implementation lookup: %s", args.InterfaceName)
+	if err := s.refreshAnalysis(ctx); err != nil {
+		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
+		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
+	}
+
+	result, err := buildImplementationsResponse(s.graph, args)
+	if err != nil {
+		log.Printf("[MCP] ERROR: %v", err)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	log.Printf("[MCP] Tool completed: get_implementations (took %v)", elapsed)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: result}},
+	}, nil
+}
+
+func (s *CodeContextMCPServer) findDeadCode(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[FindDeadCodeArgs]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	log.Printf("[MCP] Tool called: find_dead_code with args: %+v", args)
+	start := time.Now()
+
+	// Ensure we have fresh analysis
+	log.Printf("[MCP] Refreshing analysis for dead code detection...")
+	if err := s.refreshAnalysis(ctx); err != nil {
+		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
+		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
+	}
+
+	// Allow-lists passed with the call extend the configured ones
+	options := analyzer.DeadCodeOptions{
+		AllowSymbols: append(append([]string{}, s.config.DeadCode.AllowSymbols...), args.AllowSymbols...),
+		AllowFiles:   append(append([]string{}, s.config.DeadCode.AllowFiles...), args.AllowFiles...),
+	}
+	report := analyzer.FindDeadCode(s.graph, options)
+
+	var result strings.Builder
+	result.WriteString("# Dead Code Report\n\n")
+	analyzer.WriteDeadCodeReport(&result, report, 0)
+
+	elapsed := time.Since(start)
+	log.Printf("[MCP] Tool completed: find_dead_code (took %v, %d unused exports, %d unimported files, %d unreachable functions)",
+		elapsed, len(report.UnusedExports), len(report.UnimportedFiles), len(report.UnreachableFunctions))
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil
+}
+
+func (s *CodeContextMCPServer) checkArchitecture(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[CheckArchitectureArgs]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	log.Printf("[MCP] Tool called: check_architecture with args: %+v", args)
+	start := time.Now()
+
+	if !s.config.Architecture.Enabled() {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: "No architecture layers configured. Declare layers and rules in the architecture section of .codecontext/config.yaml."}},
+		}, nil
+	}
+
+	// Ensure we have fresh analysis
+	log.Printf("[MCP] Refreshing analysis for architecture check...")
+	if err := s.refreshAnalysis(ctx); err != nil {
+		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
+		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
+	}
+
+	violations := analyzer.CheckArchitecture(s.graph, s.config.Architecture)
+	if args.Layer != "" {
+		filtered := make([]analyzer.LayerViolation, 0, len(violations))
+		for _, violation := range violations {
+			if violation.FromLayer == args.Layer {
+				filtered = append(filtered, violation)
+			}
+		}
+		violations = filtered
+	}
+
+	var result strings.Builder
+	result.WriteString("# Architecture Check\n\n")
+	analyzer.WriteLayerViolations(&result, violations, 0)
+
+	elapsed := time.Since(start)
+	log.Printf("[MCP] Tool completed: check_architecture (took %v, %d violations)", elapsed, len(violations))
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil
+}
+
+func (s *CodeContextMCPServer) findClones(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[FindClonesArgs]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	log.Printf("[MCP] Tool called: find_clones with args: %+v", args)
+	start := time.Now()
+
+	// Ensure we have fresh analysis
+	log.Printf("[MCP] Refreshing analysis for clone detection...")
+	if err := s.refreshAnalysis(ctx); err != nil {
+		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
+		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
+	}
+
+	groups := analyzer.FindClones(s.graph, analyzer.CloneOptions{MinTokens: args.MinTokens, Similarity: args.Similarity})
+	if args.FilePath != "" {
+		filtered := make([]analyzer.CloneGroup, 0, len(groups))
+		for _, group := range groups {
+			for _, member := range group.Members {
+				if strings.HasSuffix(member.File, args.FilePath) {
+					filtered = append(filtered, group)
+					break
+				}
+			}
+		}
+		groups = filtered
+	}
+
+	var result strings.Builder
+	result.WriteString("# Duplicate Code\n\n")
+	analyzer.WriteCloneReport(&result, groups, 0)
+
+	elapsed := time.Since(start)
+	log.Printf("[MCP] Tool completed: find_clones (took %v, %d groups)", elapsed, len(groups))
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil
+}
+
+func (s *CodeContextMCPServer) listRoutes(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[ListRoutesArgs]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	log.Printf("[MCP] Tool called: list_routes with args: %+v", args)
+	start := time.Now()
+
+	// Ensure we have fresh analysis
+	log.Printf("[MCP] Refreshing analysis for route listing...")
+	if err := s.refreshAnalysis(ctx); err != nil {
+		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
+		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
+	}
+
+	routes := analyzer.FindRoutes(s.graph)
+	filtered := make([]analyzer.Route, 0, len(routes))
+	for _, route := range routes {
+		if args.Method != "" && route.Method != "ANY" && !slices.Contains(strings.Split(route.Method, ","), strings.ToUpper(args.Method)) {
+			continue
+		}
+		if args.PathPrefix != "" && !strings.HasPrefix(route.Path, args.PathPrefix) {
+			continue
+		}
+		if args.FilePath != "" && !strings.HasSuffix(route.File, args.FilePath) {
+			continue
+		}
+		filtered = append(filtered, route)
+	}
+
+	var result strings.Builder
+	result.WriteString("# HTTP Routes\n\n")
+	analyzer.WriteRouteTable(&result, filtered, 0)
+
+	elapsed := time.Since(start)
+	log.Printf("[MCP] Tool completed: list_routes (took %v, %d routes)", elapsed, len(filtered))
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil
+}
+
+func (s *CodeContextMCPServer) listAnnotations(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[ListAnnotationsArgs]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	log.Printf("[MCP] Tool called: list_annotations with args: %+v", args)
+	start := time.Now()
+
+	// Ensure we have fresh analysis
+	log.Printf("[MCP] Refreshing analysis for annotation listing...")
+	if err := s.refreshAnalysis(ctx); err != nil {
+		log.Printf("[MCP] ERROR: Failed to refresh analysis: %v", err)
+		return nil, fmt.Errorf("failed to refresh analysis: %w", err)
+	}
+
+	annotations := analyzer.FindAnnotations(s.graph, analyzer.AnnotationFilter{
+		Kinds:    args.Kinds,
+		FilePath: args.FilePath,
+		Author:   args.Author,
+	})
+
+	var result strings.Builder
+	result.WriteString("# Annotations\n\n")
+	analyzer.WriteAnnotationReport(&result, annotations, 0)
+
+	elapsed := time.Since(start)
+	log.Printf("[MCP] Tool completed: list_annotations (took %v, %d annotations)", elapsed, len(annotations))
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil
+}
+
 // Helper methods
 
-func (s *CodeContextMCPServer) refreshAnalysis() error {
-	log.Printf("[MCP] Starting analysis of directory: %s", s.config.TargetDir)
-	graph, err := s.analyzer.AnalyzeDirectory(s.config.TargetDir)
+// refreshAnalysis re-analyzes the configured source; cancelling ctx, as a client does when it
+// abandons a tool call, stops the analysis and keeps the previous graph
+func (s *CodeContextMCPServer) refreshAnalysis(ctx context.Context) error {
+	var graph *types.CodeGraph
+	var err error
+	if len(s.config.Roots) > 0 {
+		log.Printf("[MCP] Starting analysis of source roots: %+v", s.config.Roots)
+		graph, err = s.analyzer.AnalyzeRootsContext(ctx, s.config.Roots)
+	} else {
+		log.Printf("[MCP] Starting analysis of directory: %s", s.config.TargetDir)
+		graph, err = s.analyzer.AnalyzeDirectoryContext(ctx, s.config.TargetDir)
+	}
 	if err != nil {
 		log.Printf("[MCP] Analysis failed: %v", err)
 		return err
 	}
 	log.Printf("[MCP] Analysis completed successfully - %d files, %d symbols", len(graph.Files), len(graph.Symbols))
+	for _, fileError := range graph.Metadata.Errors {
+		log.Printf("[MCP] Skipped %s: %s", fileError.Path, fileError.Error)
+	}
 	s.graph = graph
 	return nil
 }
@@ -812,7 +1205,7 @@ func (s *CodeContextMCPServer) Run(ctx context.Context) error {
 	log.Printf("[MCP] CodeContext MCP Server starting - will analyze %s", s.config.TargetDir)
 	
 	// Initial analysis
-	if err := s.refreshAnalysis(); err != nil {
+	if err := s.refreshAnalysis(ctx); err != nil {
 		log.Printf("[MCP] Initial analysis failed, server will not start: %v", err)
 		return fmt.Errorf("failed to perform initial analysis: %w", err)
 	}
@@ -833,4 +1226,173 @@ func (s *CodeContextMCPServer) Stop() {
 		log.Printf("[MCP] File watcher stopped")
 	}
 	log.Printf("[MCP] MCP server stopped successfully")
-}
\ No newline at end of file
+}
+
+// rankSymbolMatches returns up to limit symbols whose name contains query, most central first
+func rankSymbolMatches(graph *types.CodeGraph, query string, limit int) []*types.Symbol {
+	query = strings.ToLower(query)
+	var matches []*types.Symbol
+	for _, symbol := range graph.Symbols {
+		if strings.Contains(strings.ToLower(symbol.Name), query) {
+			matches = append(matches, symbol)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		left := analyzer.SymbolImportance(graph, matches[i].Id)
+		right := analyzer.SymbolImportance(graph, matches[j].Id)
+		if left != right {
+			return left > right
+		}
+		return matches[i].Id < matches[j].Id
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// buildCallersResponse lists the incoming calls edges of every symbol matching args
+func buildCallersResponse(graph *types.CodeGraph, args GetCallersArgs) (string, error) {
+	var targets []*types.GraphNode
+	for _, node := range graph.Nodes {
+		if node.Type != "symbol" || node.Label != args.SymbolName {
+			continue
+		}
+		if args.FilePath != "" && !strings.HasSuffix(node.FilePath, args.FilePath) {
+			continue
+		}
+		targets = append(targets, node)
+	}
+
+	if len(targets) == 0 {
+		return "", fmt.Errorf("symbol '%s' not found", args.SymbolName)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Id < targets[j].Id })
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("# Callers of %s\n\n", args.SymbolName))
+
+	for i, target := range targets {
+		if i > 0 {
+			response.WriteString("\n---\n\n")
+		}
+		response.WriteString(fmt.Sprintf("**Defined in:** %s (line %v)\n\n", target.FilePath, target.Metadata["line"]))
+
+		var incoming []*types.GraphEdge
+		for _, edge := range graph.Edges {
+			if edge.Type == "calls" && edge.To == target.Id {
+				incoming = append(incoming, edge)
+			}
+		}
+		if len(incoming) == 0 {
+			response.WriteString("No callers found.\n")
+			continue
+		}
+		sort.Slice(incoming, func(i, j int) bool { return incoming[i].Id < incoming[j].Id })
+
+		response.WriteString(fmt.Sprintf("Found %d callers:\n\n", len(incoming)))
+		for _, edge := range incoming {
+			caller := "(top level)"
+			if node := graph.Nodes[edge.From]; node != nil && node.Type == "symbol" {
+				caller = node.Label
+			}
+			response.WriteString(fmt.Sprintf("- **%s** in %v (%v)\n", caller, edge.Metadata["source_file"], edge.Metadata["resolution"]))
+			if sites, ok := edge.Metadata["call_sites"].([]analyzer.CallSite); ok {
+				for _, site := range sites {
+					response.WriteString(fmt.Sprintf("  - %s:%d:%d\n", site.File, site.Line, site.Column))
+				}
+			}
+		}
+	}
+
+	return response.String(), nil
+}
+
+// buildImplementationsResponse lists the types implementing or extending every symbol matching args,
+// following extends edges so subclasses of an implementing class are included
+func buildImplementationsResponse(graph *types.CodeGraph, args GetImplementationsArgs) (string, error) {
+	var targets []*types.GraphNode
+	for _, node := range graph.Nodes {
+		if node.Type != "symbol" || node.Label != args.InterfaceName {
+			continue
+		}
+		if args.FilePath != "" && !strings.HasSuffix(node.FilePath, args.FilePath) {
+			continue
+		}
+		targets = append(targets, node)
+	}
+
+	if len(targets) == 0 {
+		return "", fmt.Errorf("symbol '%s' not found", args.InterfaceName)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Id < targets[j].Id })
+
+	// Incoming extends/implements edges of each type
+	subtypes := make(map[types.NodeId][]*types.GraphEdge)
+	for _, edge := range graph.Edges {
+		if edge.Type == "implements" || edge.Type == "extends" {
+			subtypes[edge.To] = append(subtypes[edge.To], edge)
+		}
+	}
+	for _, edges := range subtypes {
+		sort.Slice(edges, func(i, j int) bool { return edges[i].Id < edges[j].Id })
+	}
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("# Types implementing %s\n\n", args.InterfaceName))
+
+	for i, target := range targets {
+		if i > 0 {
+			response.WriteString("\n---\n\n")
+		}
+		response.WriteString(fmt.Sprintf("**Defined in:** %s (line %v)\n\n", target.FilePath, target.Metadata["line"]))
+
+		var lines []string
+		visited := map[types.NodeId]bool{target.Id: true}
+		var collect func(id types.NodeId, via string)
+		collect = func(id types.NodeId, via string) {
+			for _, edge := range subtypes[id] {
+				if visited[edge.From] {
+					continue
+				}
+				visited[edge.From] = true
+				node := graph.Nodes[edge.From]
+				if node == nil {
+					continue
+				}
+
+				details := []string{edge.Type}
+				if edge.Metadata["resolution"] == analyzer.TypeResolutionStructural {
+					details = []string{"implicitly"}
+					if pointer, _ := edge.Metadata["pointer_receiver"].(bool); pointer {
+						details = append(details, "pointer receiver")
+					}
+				}
+				if via != "" {
+					details = append(details, "via "+via)
+				}
+				lines = append(lines, fmt.Sprintf("- **%s** in %s:%v (%s)\n", node.Label, node.FilePath, node.Metadata["line"], strings.Join(details, ", ")))
+
+				// Subtypes of an implementation implement the interface as well
+				next := via
+				if next == "" {
+					next = node.Label
+				}
+				collect(edge.From, next)
+			}
+		}
+		collect(target.Id, "")
+
+		if len(lines) == 0 {
+			response.WriteString("No implementations found.\n")
+			continue
+		}
+		response.WriteString(fmt.Sprintf("Found %d types:\n\n", len(lines)))
+		for _, line := range lines {
+			response.WriteString(line)
+		}
+	}
+
+	return response.String(), nil
+}
@@ -2,11 +2,14 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/nuthan-ms/codecontext/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -103,7 +106,7 @@ func TestMCPServerAnalysis(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test refreshAnalysis
-	err = server.refreshAnalysis()
+	err = server.refreshAnalysis(context.Background())
 	assert.NoError(t, err)
 	assert.NotNil(t, server.graph)
 
@@ -199,7 +202,7 @@ func TestGetFileAnalysis(t *testing.T) {
 	require.NoError(t, err)
 
 	// Ensure analysis is done
-	err = server.refreshAnalysis()
+	err = server.refreshAnalysis(context.Background())
 	require.NoError(t, err)
 
 	mainTSPath := filepath.Join(tmpDir, "main.ts")
@@ -277,7 +280,7 @@ func TestSearchSymbols(t *testing.T) {
 	require.NoError(t, err)
 
 	// Ensure analysis is done
-	err = server.refreshAnalysis()
+	err = server.refreshAnalysis(context.Background())
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -365,7 +368,7 @@ func TestGetSymbolInfo(t *testing.T) {
 	require.NoError(t, err)
 
 	// Ensure analysis is done
-	err = server.refreshAnalysis()
+	err = server.refreshAnalysis(context.Background())
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -441,7 +444,7 @@ func TestGetDependencies(t *testing.T) {
 	require.NoError(t, err)
 
 	// Ensure analysis is done
-	err = server.refreshAnalysis()
+	err = server.refreshAnalysis(context.Background())
 	require.NoError(t, err)
 
 	mainTSPath := filepath.Join(tmpDir, "main.ts")
@@ -694,7 +697,7 @@ func BenchmarkSearchSymbols(b *testing.B) {
 	require.NoError(b, err)
 
 	// Pre-populate analysis
-	err = server.refreshAnalysis()
+	err = server.refreshAnalysis(context.Background())
 	require.NoError(b, err)
 
 	ctx := context.Background()
@@ -770,4 +773,134 @@ export const CONSTANTS = {
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func TestBuildCallersResponse(t *testing.T) {
+	graph := &types.CodeGraph{
+		Nodes: map[types.NodeId]*types.GraphNode{
+			"symbol-func-a.ts-1": {Id: "symbol-func-a.ts-1", Type: "symbol", Label: "run", FilePath: "a.ts", Metadata: map[string]interface{}{"line": 1}},
+			"symbol-func-b.ts-3": {Id: "symbol-func-b.ts-3", Type: "symbol", Label: "format", FilePath: "b.ts", Metadata: map[string]interface{}{"line": 3}},
+		},
+		Edges: map[types.EdgeId]*types.GraphEdge{
+			"call-func-a.ts-1-func-b.ts-3": {
+				Id:   "call-func-a.ts-1-func-b.ts-3",
+				From: "symbol-func-a.ts-1",
+				To:   "symbol-func-b.ts-3",
+				Type: "calls",
+				Metadata: map[string]interface{}{
+					"source_file": "a.ts",
+					"resolution":  analyzer.CallResolutionImport,
+					"call_sites":  []analyzer.CallSite{{File: "a.ts", Line: 2, Column: 5}},
+				},
+			},
+		},
+	}
+
+	result, err := buildCallersResponse(graph, GetCallersArgs{SymbolName: "format"})
+	require.NoError(t, err)
+	assert.Contains(t, result, "# Callers of format")
+	assert.Contains(t, result, "**run** in a.ts (import)")
+	assert.Contains(t, result, "a.ts:2:5")
+
+	result, err = buildCallersResponse(graph, GetCallersArgs{SymbolName: "run"})
+	require.NoError(t, err)
+	assert.Contains(t, result, "No callers found.")
+
+	_, err = buildCallersResponse(graph, GetCallersArgs{SymbolName: "missing"})
+	assert.Error(t, err)
+}
+
+func TestBuildImplementationsResponse(t *testing.T) {
+	symbolNode := func(id, label, file string, line int) *types.GraphNode {
+		return &types.GraphNode{Id: types.NodeId(id), Type: "symbol", Label: label, FilePath: file, Metadata: map[string]interface{}{"line": line}}
+	}
+	graph := &types.CodeGraph{
+		Nodes: map[types.NodeId]*types.GraphNode{
+			"symbol-type-store.go-3":   symbolNode("symbol-type-store.go-3", "Store", "store.go", 3),
+			"symbol-type-memory.go-5":  symbolNode("symbol-type-memory.go-5", "MemoryStore", "memory.go", 5),
+			"symbol-class-Base.java-1": symbolNode("symbol-class-Base.java-1", "Base", "Base.java", 1),
+			"symbol-class-Impl.java-2": symbolNode("symbol-class-Impl.java-2", "Impl", "Impl.java", 2),
+			"symbol-class-Sub.java-4":  symbolNode("symbol-class-Sub.java-4", "Sub", "Sub.java", 4),
+		},
+		Edges: map[types.EdgeId]*types.GraphEdge{
+			"implements-type-memory.go-5-type-store.go-3": {
+				Id: "implements-type-memory.go-5-type-store.go-3", From: "symbol-type-memory.go-5", To: "symbol-type-store.go-3", Type: "implements",
+				Metadata: map[string]interface{}{"resolution": analyzer.TypeResolutionStructural, "pointer_receiver": true},
+			},
+			"implements-class-Impl.java-2-class-Base.java-1": {
+				Id: "implements-class-Impl.java-2-class-Base.java-1", From: "symbol-class-Impl.java-2", To: "symbol-class-Base.java-1", Type: "implements",
+				Metadata: map[string]interface{}{"resolution": analyzer.CallResolutionImport},
+			},
+			"extends-class-Sub.java-4-class-Impl.java-2": {
+				Id: "extends-class-Sub.java-4-class-Impl.java-2", From: "symbol-class-Sub.java-4", To: "symbol-class-Impl.java-2", Type: "extends",
+				Metadata: map[string]interface{}{"resolution": analyzer.CallResolutionLocal},
+			},
+		},
+	}
+
+	result, err := buildImplementationsResponse(graph, GetImplementationsArgs{InterfaceName: "Store"})
+	require.NoError(t, err)
+	assert.Contains(t, result, "# Types implementing Store")
+	assert.Contains(t, result, "**MemoryStore** in memory.go:5 (implicitly, pointer receiver)")
+
+	result, err = buildImplementationsResponse(graph, GetImplementationsArgs{InterfaceName: "Base"})
+	require.NoError(t, err)
+	assert.Contains(t, result, "Found 2 types")
+	assert.Contains(t, result, "**Impl** in Impl.java:2 (implements)")
+	assert.Contains(t, result, "**Sub** in Sub.java:4 (extends, via Impl)")
+
+	result, err = buildImplementationsResponse(graph, GetImplementationsArgs{InterfaceName: "Sub"})
+	require.NoError(t, err)
+	assert.Contains(t, result, "No implementations found.")
+
+	_, err = buildImplementationsResponse(graph, GetImplementationsArgs{InterfaceName: "missing"})
+	assert.Error(t, err)
+}
+
+func TestRankSymbolMatches(t *testing.T) {
+	graph := &types.CodeGraph{
+		Nodes: map[types.NodeId]*types.GraphNode{
+			"symbol-func-a.go-1": {Id: "symbol-func-a.go-1", Type: "symbol", Importance: 0.2},
+			"symbol-func-b.go-1": {Id: "symbol-func-b.go-1", Type: "symbol", Importance: 1},
+			"symbol-func-c.go-1": {Id: "symbol-func-c.go-1", Type: "symbol", Importance: 0.5},
+		},
+		Symbols: map[types.SymbolId]*types.Symbol{
+			"func-a.go-1": {Id: "func-a.go-1", Name: "parseConfig"},
+			"func-b.go-1": {Id: "func-b.go-1", Name: "ParseFile"},
+			"func-c.go-1": {Id: "func-c.go-1", Name: "parseArgs"},
+			"func-d.go-1": {Id: "func-d.go-1", Name: "render"},
+		},
+	}
+
+	matches := rankSymbolMatches(graph, "parse", 2)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "ParseFile", matches[0].Name)
+	assert.Equal(t, "parseArgs", matches[1].Name)
+}
+
+func TestRedactToolResults(t *testing.T) {
+	server, err := NewCodeContextMCPServer(createTestConfig())
+	require.NoError(t, err)
+
+	secret := "DATABASE_URL=postgres://app:s3cretPass@db:5432/app"
+	handler := server.redactToolResults(func(ctx context.Context, session *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: secret}}}, nil
+	})
+
+	result, err := handler(context.Background(), nil, "tools/call", &mcp.CallToolParamsFor[json.RawMessage]{Name: "get_file_analysis"})
+	require.NoError(t, err)
+	text := result.(*mcp.CallToolResult).Content[0].(*mcp.TextContent).Text
+	assert.Equal(t, "DATABASE_URL=postgres://app:[REDACTED:connection-string]@db:5432/app", text)
+
+	// Redaction can be disabled in the config
+	config := createTestConfig()
+	config.Redaction.Disabled = true
+	server, err = NewCodeContextMCPServer(config)
+	require.NoError(t, err)
+	handler = server.redactToolResults(func(ctx context.Context, session *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: secret}}}, nil
+	})
+	result, err = handler(context.Background(), nil, "tools/call", nil)
+	require.NoError(t, err)
+	assert.Equal(t, secret, result.(*mcp.CallToolResult).Content[0].(*mcp.TextContent).Text)
+}
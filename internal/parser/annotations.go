@@ -0,0 +1,293 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Annotation kinds
+const (
+	AnnotationTodo       = "TODO"
+	AnnotationFixme      = "FIXME"
+	AnnotationHack       = "HACK"
+	AnnotationXXX        = "XXX"
+	AnnotationDeprecated = "DEPRECATED"
+)
+
+// annotationMarker matches a marker in a comment line: TODO, FIXME, HACK and XXX in upper case, each
+// with an optional owner in parentheses, the deprecation tag of JSDoc and Javadoc, or the paragraph
+// opening a Go deprecation notice
+var annotationMarker = regexp.MustCompile(`\b(TODO|FIXME|HACK|XXX)\b(?:\(([^)]*)\))?|(@deprecated)\b|\b(Deprecated):`)
+
+// deprecationWarnings are the Python warning categories marking the function issuing them deprecated
+var deprecationWarnings = map[string]bool{"DeprecationWarning": true, "PendingDeprecationWarning": true}
+
+// annotationScopeTypes lists the AST node types whose symbols enclose the annotations inside them
+var annotationScopeTypes = map[string]bool{
+	"class_declaration":     true,
+	"class_definition":      true,
+	"class":                 true,
+	"interface_declaration": true,
+	"enum_declaration":      true,
+	"struct_item":           true,
+	"trait_item":            true,
+}
+
+// ExtractAnnotations extracts the TODO, FIXME, HACK, XXX and @deprecated markers in the comments of an
+// AST, along with the deprecations declared in code: Go deprecation notices, Java @Deprecated, Rust
+// #[deprecated] and Python functions issuing a DeprecationWarning. Each is attributed to the
+// declaration its comment or attribute documents, or else to the enclosing function, method or type.
+// Symbol ids match the ids produced by ExtractSymbols for the same AST.
+func (m *Manager) ExtractAnnotations(ast *types.AST) ([]*types.Annotation, error) {
+	if ast.Root == nil {
+		return nil, fmt.Errorf("AST root is nil")
+	}
+
+	var annotations []*types.Annotation
+	m.extractAnnotationsRecursive(ast.Root, ast, "", &annotations)
+
+	return annotations, nil
+}
+
+func (m *Manager) extractAnnotationsRecursive(node *types.ASTNode, ast *types.AST, scope types.SymbolId, annotations *[]*types.Annotation) {
+	if node == nil {
+		return
+	}
+
+	if callableNodeTypes[node.Type] || annotationScopeTypes[node.Type] {
+		if symbol := m.nodeToSymbolWithContent(node, ast.FilePath, ast.Language, ast.Content); symbol != nil {
+			scope = symbol.Id
+			if annotation := javaDeprecation(node); annotation != nil {
+				annotation.Symbol = scope
+				*annotations = append(*annotations, annotation)
+			}
+		}
+	}
+	if annotation := pythonDeprecation(node); annotation != nil {
+		annotation.Symbol = scope
+		*annotations = append(*annotations, annotation)
+	}
+
+	for i, child := range node.Children {
+		var found []*types.Annotation
+		switch {
+		case strings.Contains(child.Type, "comment"):
+			found = commentAnnotations(child)
+		case child.Type == "attribute_item":
+			if annotation := rustDeprecation(child); annotation != nil {
+				found = append(found, annotation)
+			}
+		}
+		if len(found) == 0 {
+			m.extractAnnotationsRecursive(child, ast, scope, annotations)
+			continue
+		}
+
+		symbol := scope
+		if documented := m.documentedSymbol(node.Children[i:], ast); documented != "" {
+			symbol = documented
+		}
+		for _, annotation := range found {
+			annotation.Symbol = symbol
+			*annotations = append(*annotations, annotation)
+		}
+	}
+}
+
+// documentedSymbol returns the declaration directly following a run of comments and attributes, as
+// in a doc comment above a function, or "" when they are not attached to a declaration
+func (m *Manager) documentedSymbol(siblings []*types.ASTNode, ast *types.AST) types.SymbolId {
+	end := siblings[0].Location.EndLine
+	for _, sibling := range siblings[1:] {
+		if sibling.Location.Line > end+1 {
+			return ""
+		}
+		if strings.Contains(sibling.Type, "comment") || sibling.Type == "attribute_item" {
+			end = sibling.Location.EndLine
+			continue
+		}
+		// Exported and decorated declarations wrap the declaration itself
+		if sibling.Type == "export_statement" || sibling.Type == "decorated_definition" {
+			for _, child := range sibling.Children {
+				if symbol := m.nodeToSymbolWithContent(child, ast.FilePath, ast.Language, ast.Content); symbol != nil {
+					return symbol.Id
+				}
+			}
+		}
+		if symbol := m.nodeToSymbolWithContent(sibling, ast.FilePath, ast.Language, ast.Content); symbol != nil {
+			return symbol.Id
+		}
+		return ""
+	}
+	return ""
+}
+
+// javaDeprecation returns the deprecation of a Java declaration annotated with @Deprecated, whose
+// text is the annotation arguments such as since = "2.0"
+func javaDeprecation(declaration *types.ASTNode) *types.Annotation {
+	for _, child := range declaration.Children {
+		if child.Type != "modifiers" {
+			continue
+		}
+		for _, modifier := range child.Children {
+			if (modifier.Type != "marker_annotation" && modifier.Type != "annotation") || childValue(modifier, "identifier") != "Deprecated" {
+				continue
+			}
+			text := ""
+			for _, part := range modifier.Children {
+				if part.Type == "annotation_argument_list" {
+					text = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(part.Value, "("), ")"))
+				}
+			}
+			return &types.Annotation{Kind: AnnotationDeprecated, Text: text, Location: nodeFileLocation(modifier)}
+		}
+	}
+	return nil
+}
+
+// rustDeprecation returns the deprecation declared by a #[deprecated] attribute, whose text is its
+// note when it has one
+func rustDeprecation(attribute *types.ASTNode) *types.Annotation {
+	for _, child := range attribute.Children {
+		if child.Type != "attribute" || childValue(child, "identifier") != "deprecated" {
+			continue
+		}
+		annotation := &types.Annotation{Kind: AnnotationDeprecated, Location: nodeFileLocation(attribute)}
+		for _, part := range child.Children {
+			if part.Type == "token_tree" {
+				annotation.Text = deprecationNote(part)
+			} else if part.Type == "string_literal" {
+				annotation.Text = childValue(part, "string_content") // #[deprecated = "note"]
+			}
+		}
+		return annotation
+	}
+	return nil
+}
+
+// deprecationNote returns the note of #[deprecated(since = "1.2", note = "...")], or the arguments
+// as written when there is no note
+func deprecationNote(arguments *types.ASTNode) string {
+	for i, part := range arguments.Children {
+		if part.Type == "identifier" && part.Value == "note" && i+2 < len(arguments.Children) {
+			if literal := arguments.Children[i+2]; literal.Type == "string_literal" {
+				return childValue(literal, "string_content")
+			}
+		}
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(arguments.Value, "("), ")"))
+}
+
+// pythonDeprecation returns the deprecation of a warnings.warn or warn call issuing a
+// DeprecationWarning, whose text is the warning message
+func pythonDeprecation(node *types.ASTNode) *types.Annotation {
+	if node.Type != "call" || len(node.Children) < 2 {
+		return nil
+	}
+	if function := node.Children[0].Value; function != "warn" && function != "warnings.warn" {
+		return nil
+	}
+
+	deprecated, message := false, ""
+	for _, argument := range node.Children[1].Children {
+		switch argument.Type {
+		case "identifier":
+			deprecated = deprecated || deprecationWarnings[argument.Value]
+		case "keyword_argument":
+			deprecated = deprecated || (childValue(argument, "identifier") == "category" && deprecationWarnings[lastChildValue(argument)])
+		case "string":
+			if message == "" {
+				message = childValue(argument, "string_content")
+			}
+		}
+	}
+	if !deprecated {
+		return nil
+	}
+	return &types.Annotation{Kind: AnnotationDeprecated, Text: message, Location: nodeFileLocation(node)}
+}
+
+// childValue returns the text of the first direct child of a node with the given type
+func childValue(node *types.ASTNode, nodeType string) string {
+	if child := childOfType(node, nodeType); child != nil {
+		return child.Value
+	}
+	return ""
+}
+
+// lastChildValue returns the text of the last child of a node
+func lastChildValue(node *types.ASTNode) string {
+	if len(node.Children) == 0 {
+		return ""
+	}
+	return node.Children[len(node.Children)-1].Value
+}
+
+// nodeFileLocation returns the start of a node
+func nodeFileLocation(node *types.ASTNode) types.FileLocation {
+	return types.FileLocation{Line: node.Location.Line, Column: node.Location.Column}
+}
+
+// annotationMarkersIn returns the submatch indexes of the markers in a comment line. Prose that merely
+// mentions a marker is skipped: TODO, FIXME, HACK and XXX must start the comment text or be followed
+// by a colon or an owner, and never by a comma as in a list of markers, and deprecation tags and
+// notices must start the comment text.
+func annotationMarkersIn(line string) [][]int {
+	var markers [][]int
+	for _, match := range annotationMarker.FindAllStringSubmatchIndex(line, -1) {
+		opensComment := strings.TrimLeft(line[:match[0]], " \t/*#!-<") == ""
+		switch {
+		case match[6] >= 0 || match[8] >= 0: // Deprecation tag or notice
+			if opensComment {
+				markers = append(markers, match)
+			}
+		default:
+			rest := line[match[3]:]
+			if strings.HasPrefix(rest, ",") {
+				continue
+			}
+			if opensComment || strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "(") {
+				markers = append(markers, match)
+			}
+		}
+	}
+	return markers
+}
+
+// commentAnnotations returns the markers found in the lines of a comment node. The text of a marker
+// runs to the end of its line or to the next marker on it.
+func commentAnnotations(comment *types.ASTNode) []*types.Annotation {
+	var annotations []*types.Annotation
+	for i, line := range strings.Split(comment.Value, "\n") {
+		markers := annotationMarkersIn(line)
+		for j, match := range markers {
+			end := len(line)
+			if j+1 < len(markers) {
+				end = markers[j+1][0]
+			}
+			text := strings.TrimLeft(line[match[1]:end], ":- \t")
+			annotation := &types.Annotation{
+				Kind: AnnotationDeprecated,
+				Text: strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "*/")),
+				Location: types.FileLocation{
+					Line:   comment.Location.Line + i,
+					Column: match[0] + 1,
+				},
+			}
+			if match[2] >= 0 {
+				annotation.Kind = line[match[2]:match[3]]
+			}
+			if match[4] >= 0 {
+				annotation.Author = strings.TrimSpace(line[match[4]:match[5]])
+			}
+			if i == 0 {
+				annotation.Location.Column += comment.Location.Column - 1
+			}
+			annotations = append(annotations, annotation)
+		}
+	}
+	return annotations
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestExtractAnnotations(t *testing.T) {
+	manager := NewManager()
+
+	type expectedAnnotation struct {
+		kind   string
+		text   string
+		author string
+		symbol string // Name of the symbol the annotation belongs to, empty at file level
+		line   int
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		expected []expectedAnnotation
+	}{
+		{
+			name:     "go doc, block and line comments",
+			filePath: "old.go",
+			content:  "package a\n\n// Old does things.\n//\n// Deprecated: use New. TODO(alice): remove in v2\nfunc Old() {\n\t/* FIXME: leaks\n\t   HACK around bug */\n\tx := 1 // XXX magic\n\t_ = x\n}\n\n// Markers such as TODO, FIXME and HACK in prose are not annotations\nvar count int\n",
+			expected: []expectedAnnotation{
+				{"DEPRECATED", "use New.", "", "Old", 5},
+				{"TODO", "remove in v2", "alice", "Old", 5},
+				{"FIXME", "leaks", "", "Old", 7},
+				{"HACK", "around bug", "", "Old", 8},
+				{"XXX", "magic", "", "Old", 9},
+			},
+		},
+		{
+			name:     "typescript jsdoc and method comments",
+			filePath: "store.ts",
+			content:  "class Store {\n  /** @deprecated use get() */\n  fetch(): void {\n    // TODO handle errors\n  }\n}\n// FIXME: global state\n\nlet cache = 1;\n",
+			expected: []expectedAnnotation{
+				{"DEPRECATED", "use get()", "", "fetch", 2},
+				{"TODO", "handle errors", "", "fetch", 4},
+				{"FIXME", "global state", "", "", 7},
+			},
+		},
+		{
+			name:     "python comments",
+			filePath: "run.py",
+			content:  "# TODO: module level\ndef run():\n    pass  # FIXME later\n",
+			expected: []expectedAnnotation{
+				{"TODO", "module level", "", "run", 1},
+				{"FIXME", "later", "", "run", 3},
+			},
+		},
+		{
+			name:     "python deprecation warnings",
+			filePath: "legacy.py",
+			content:  "import warnings\n\ndef old(x):\n    warnings.warn(\"use new\", DeprecationWarning, stacklevel=2)\n    return x\n\ndef m():\n    warn(\"gone\", category=PendingDeprecationWarning)\n    warn(\"slow\", RuntimeWarning)\n",
+			expected: []expectedAnnotation{
+				{"DEPRECATED", "use new", "", "old", 4},
+				{"DEPRECATED", "gone", "", "m", 8},
+			},
+		},
+		{
+			name:     "java deprecated annotations",
+			filePath: "Legacy.java",
+			content:  "@Deprecated\npublic class Legacy {\n    @Deprecated(since = \"2.0\")\n    public void old() {}\n\n    @Override\n    public String toString() { return \"\"; }\n}\n",
+			expected: []expectedAnnotation{
+				{"DEPRECATED", "", "", "Legacy", 1},
+				{"DEPRECATED", "since = \"2.0\"", "", "old", 3},
+			},
+		},
+		{
+			name:     "rust deprecated attributes",
+			filePath: "lib.rs",
+			content:  "#[deprecated(since = \"1.2.0\", note = \"use bar instead\")]\n#[inline]\npub fn foo() {}\n\n#[deprecated]\npub struct S;\n",
+			expected: []expectedAnnotation{
+				{"DEPRECATED", "use bar instead", "", "foo", 1},
+				{"DEPRECATED", "", "", "S", 5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := manager.detectLanguage(tt.filePath)
+			if lang == nil {
+				t.Fatalf("Failed to detect language for %s", tt.filePath)
+			}
+
+			ast, err := manager.parseContent(tt.content, *lang, tt.filePath)
+			if err != nil {
+				t.Fatalf("Failed to parse content: %v", err)
+			}
+
+			symbols, err := manager.ExtractSymbols(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract symbols: %v", err)
+			}
+			names := make(map[types.SymbolId]string)
+			for _, symbol := range symbols {
+				names[symbol.Id] = symbol.Name
+			}
+
+			annotations, err := manager.ExtractAnnotations(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract annotations: %v", err)
+			}
+
+			if len(annotations) != len(tt.expected) {
+				t.Fatalf("Expected %d annotations, got %d: %+v", len(tt.expected), len(annotations), annotations)
+			}
+
+			for i, expected := range tt.expected {
+				annotation := annotations[i]
+				if annotation.Kind != expected.kind || annotation.Text != expected.text || annotation.Author != expected.author {
+					t.Errorf("Annotation %d: expected %s %q by %q, got %s %q by %q", i, expected.kind, expected.text, expected.author,
+						annotation.Kind, annotation.Text, annotation.Author)
+				}
+				if names[annotation.Symbol] != expected.symbol {
+					t.Errorf("Annotation %d: expected symbol %q, got %q", i, expected.symbol, names[annotation.Symbol])
+				}
+				if annotation.Location.Line != expected.line {
+					t.Errorf("Annotation %d: expected line %d, got %d", i, expected.line, annotation.Location.Line)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// callableNodeTypes lists the AST node types whose bodies own the calls found inside them
+var callableNodeTypes = map[string]bool{
+	"function_declaration":           true, // JavaScript/TypeScript, Go
+	"function":                       true,
+	"function_expression":            true,
+	"arrow_function":                 true,
+	"generator_function_declaration": true,
+	"method_definition":              true,
+	"function_definition":            true, // Python
+	"method_declaration":             true, // Java, Go
+	"constructor_declaration":        true,
+	"function_item":                  true, // Rust
+}
+
+// ExtractCalls extracts the call sites of an AST together with the symbol enclosing each call.
+// Caller ids match the ids produced by ExtractSymbols for the same AST.
+func (m *Manager) ExtractCalls(ast *types.AST) ([]*types.Call, error) {
+	if ast.Root == nil {
+		return nil, fmt.Errorf("AST root is nil")
+	}
+
+	var calls []*types.Call
+	m.extractCallsRecursive(ast.Root, ast, "", &calls)
+
+	return calls, nil
+}
+
+func (m *Manager) extractCallsRecursive(node *types.ASTNode, ast *types.AST, caller types.SymbolId, calls *[]*types.Call) {
+	if node == nil {
+		return
+	}
+
+	// Calls inside a function body belong to that function
+	if callableNodeTypes[node.Type] {
+		if symbol := m.nodeToSymbolWithContent(node, ast.FilePath, ast.Language, ast.Content); symbol != nil {
+			caller = symbol.Id
+		}
+	}
+
+	if call := m.nodeToCall(node); call != nil {
+		call.Caller = caller
+		*calls = append(*calls, call)
+	}
+
+	for _, child := range node.Children {
+		m.extractCallsRecursive(child, ast, caller, calls)
+	}
+}
+
+// nodeToCall converts a call or constructor expression into a call site
+func (m *Manager) nodeToCall(node *types.ASTNode) *types.Call {
+	var receiver, callee string
+
+	switch node.Type {
+	case "call_expression", "call":
+		// JavaScript/TypeScript, Go and Rust use call_expression, Python uses call
+		if len(node.Children) == 0 {
+			return nil
+		}
+		receiver, callee = splitCallTarget(node.Children[0])
+
+	case "method_invocation":
+		// Java: [object "."] name argument_list
+		parts := make([]*types.ASTNode, 0, 2)
+		for _, child := range node.Children {
+			if child.Type == "argument_list" {
+				break
+			}
+			if child.Type != "." && child.Type != "type_arguments" {
+				parts = append(parts, child)
+			}
+		}
+		if len(parts) == 0 {
+			return nil
+		}
+		callee = strings.TrimSpace(parts[len(parts)-1].Value)
+		if len(parts) > 1 {
+			receiver = compactExpression(parts[0].Value)
+		}
+
+	case "new_expression", "object_creation_expression":
+		// Constructor calls name the instantiated type
+		for _, child := range node.Children {
+			switch child.Type {
+			case "identifier", "type_identifier", "member_expression", "scoped_type_identifier", "generic_type":
+				receiver, callee = splitCallTarget(child)
+			}
+			if callee != "" {
+				break
+			}
+		}
+
+	default:
+		return nil
+	}
+
+	if callee == "" || !isValidIdentifier(callee) {
+		return nil
+	}
+
+	return &types.Call{
+		Callee:   callee,
+		Receiver: receiver,
+		Location: node.Location,
+	}
+}
+
+// splitCallTarget splits the called expression into its receiver and the called name
+func splitCallTarget(target *types.ASTNode) (string, string) {
+	switch target.Type {
+	case "identifier", "type_identifier", "field_identifier", "property_identifier":
+		return "", strings.TrimSpace(target.Value)
+
+	case "member_expression", "attribute", "selector_expression", "field_expression", "scoped_type_identifier":
+		// object "." name; the name is the last named child
+		var object, name *types.ASTNode
+		for _, child := range target.Children {
+			if child.Type == "." || child.Type == "?." || child.Type == "optional_chain" {
+				continue
+			}
+			if object == nil {
+				object = child
+			} else {
+				name = child
+			}
+		}
+		if object == nil || name == nil {
+			return "", ""
+		}
+		return compactExpression(object.Value), strings.TrimSpace(name.Value)
+
+	case "scoped_identifier":
+		// Rust paths such as crate::util::fmt or Foo::new
+		path := compactExpression(target.Value)
+		if index := strings.LastIndex(path, "::"); index >= 0 {
+			return path[:index], path[index+2:]
+		}
+		return "", path
+
+	case "generic_function", "generic_type":
+		// foo::<T>() and new Foo<T>() carry type arguments after the name
+		if len(target.Children) > 0 {
+			return splitCallTarget(target.Children[0])
+		}
+	}
+
+	return "", ""
+}
+
+// compactExpression removes whitespace from a receiver expression
+func compactExpression(expression string) string {
+	return strings.Join(strings.Fields(expression), "")
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestExtractCalls(t *testing.T) {
+	manager := NewManager()
+
+	type expectedCall struct {
+		caller   string // Name of the enclosing symbol, empty at file level
+		receiver string
+		callee   string
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		expected []expectedCall
+	}{
+		{
+			name:     "typescript functions, methods and constructors",
+			filePath: "app.ts",
+			content:  "class App {\n  run() {\n    this.start();\n    ns.util.format(1);\n    new Logger();\n  }\n}\nfunction main() {\n  helper();\n}\nsetup();\n",
+			expected: []expectedCall{
+				{"run", "this", "start"},
+				{"run", "ns.util", "format"},
+				{"run", "", "Logger"},
+				{"main", "", "helper"},
+				{"", "", "setup"},
+			},
+		},
+		{
+			name:     "python calls and attributes",
+			filePath: "app.py",
+			content:  "def handle(self):\n    self.save()\n    os.path.join(a, b)\n    validate()\n",
+			expected: []expectedCall{
+				{"handle", "self", "save"},
+				{"handle", "os.path", "join"},
+				{"handle", "", "validate"},
+			},
+		},
+		{
+			name:     "go functions and methods",
+			filePath: "server.go",
+			content:  "package main\n\nfunc (s *Server) Handle(x int) error {\n\ts.store.Get(x)\n\tfmt.Println(x)\n\treturn validate(x)\n}\n",
+			expected: []expectedCall{
+				{"Handle", "s.store", "Get"},
+				{"Handle", "fmt", "Println"},
+				{"Handle", "", "validate"},
+			},
+		},
+		{
+			name:     "java method invocations",
+			filePath: "App.java",
+			content:  "class App {\n  void run() {\n    init();\n    Util.format(1);\n    new Foo();\n  }\n}\n",
+			expected: []expectedCall{
+				{"run", "", "init"},
+				{"run", "Util", "format"},
+				{"run", "", "Foo"},
+			},
+		},
+		{
+			name:     "rust paths and fields",
+			filePath: "lib.rs",
+			content:  "fn run() {\n    helper();\n    crate::util::format(1);\n    self.conn.send();\n}\n",
+			expected: []expectedCall{
+				{"run", "", "helper"},
+				{"run", "crate::util", "format"},
+				{"run", "self.conn", "send"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := manager.detectLanguage(tt.filePath)
+			if lang == nil {
+				t.Fatalf("Failed to detect language for %s", tt.filePath)
+			}
+
+			ast, err := manager.parseContent(tt.content, *lang, tt.filePath)
+			if err != nil {
+				t.Fatalf("Failed to parse content: %v", err)
+			}
+
+			symbols, err := manager.ExtractSymbols(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract symbols: %v", err)
+			}
+			names := make(map[types.SymbolId]string)
+			for _, symbol := range symbols {
+				names[symbol.Id] = symbol.Name
+			}
+
+			calls, err := manager.ExtractCalls(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract calls: %v", err)
+			}
+
+			if len(calls) != len(tt.expected) {
+				t.Fatalf("Expected %d calls, got %d: %+v", len(tt.expected), len(calls), calls)
+			}
+
+			for i, expected := range tt.expected {
+				call := calls[i]
+				if call.Callee != expected.callee || call.Receiver != expected.receiver {
+					t.Errorf("call[%d] = %s.%s, expected %s.%s", i, call.Receiver, call.Callee, expected.receiver, expected.callee)
+				}
+				if caller := names[call.Caller]; caller != expected.caller {
+					t.Errorf("call[%d] caller = %q, expected %q", i, caller, expected.caller)
+				}
+				if call.Location.Line == 0 {
+					t.Errorf("call[%d] has no location", i)
+				}
+			}
+		})
+	}
+}
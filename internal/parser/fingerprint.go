@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Fingerprint parameters
+const (
+	shingleSize   = 5  // Tokens per shingle
+	minHashValues = 64 // Hash functions in a MinHash signature
+)
+
+// minHashSeeds derive the hash functions of a MinHash signature
+var minHashSeeds = func() []uint64 {
+	seeds := make([]uint64, minHashValues)
+	for i := range seeds {
+		seeds[i] = mix64(uint64(i) + 0x9e3779b97f4a7c15)
+	}
+	return seeds
+}()
+
+// fingerprint normalises a function's tokens and summarises them with an exact hash and a MinHash
+// signature of their shingles
+func fingerprint(node *types.ASTNode) *types.Fingerprint {
+	tokens := make([]string, 0)
+	collectTokens(node, &tokens)
+
+	digest := sha256.Sum256([]byte(strings.Join(tokens, " ")))
+	return &types.Fingerprint{
+		Hash:    hex.EncodeToString(digest[:16]),
+		Tokens:  len(tokens),
+		MinHash: minHash(tokens),
+	}
+}
+
+// collectTokens appends the leaf tokens of a node, replacing identifiers with $id and literals with
+// $lit and dropping comments
+func collectTokens(node *types.ASTNode, tokens *[]string) {
+	switch {
+	case strings.Contains(node.Type, "comment"):
+		return
+	case isLiteralType(node.Type):
+		*tokens = append(*tokens, "$lit")
+		return
+	case len(node.Children) == 0:
+		if strings.HasSuffix(node.Type, "identifier") {
+			*tokens = append(*tokens, "$id")
+		} else {
+			*tokens = append(*tokens, node.Type)
+		}
+		return
+	}
+	for _, child := range node.Children {
+		collectTokens(child, tokens)
+	}
+}
+
+// isLiteralType reports whether a node is a string or number literal, kept whole as one token
+func isLiteralType(nodeType string) bool {
+	switch nodeType {
+	case "string", "number", "integer", "float", "template_string":
+		return true
+	}
+	return strings.HasSuffix(nodeType, "_literal")
+}
+
+// minHash returns the minimum hash of the token shingles under each hash function. The fraction of
+// equal values in two signatures estimates the Jaccard similarity of their shingle sets.
+func minHash(tokens []string) []uint64 {
+	signature := make([]uint64, minHashValues)
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+
+	size := min(shingleSize, len(tokens))
+	for start := 0; start+size <= len(tokens) && size > 0; start++ {
+		hasher := fnv.New64a()
+		for _, token := range tokens[start : start+size] {
+			hasher.Write([]byte(token))
+			hasher.Write([]byte{0})
+		}
+		shingle := hasher.Sum64()
+		for i, seed := range minHashSeeds {
+			if value := mix64(shingle ^ seed); value < signature[i] {
+				signature[i] = value
+			}
+		}
+	}
+	return signature
+}
+
+// mix64 is the splitmix64 finaliser, scrambling a value into an independent-looking hash
+func mix64(value uint64) uint64 {
+	value ^= value >> 30
+	value *= 0xbf58476d1ce4e5b9
+	value ^= value >> 27
+	value *= 0x94d049bb133111eb
+	value ^= value >> 31
+	return value
+}
+
+// Similarity estimates the Jaccard similarity of the token shingles of two fingerprinted functions
+func Similarity(a, b *types.Fingerprint) float64 {
+	if a == nil || b == nil || len(a.MinHash) == 0 || len(a.MinHash) != len(b.MinHash) {
+		return 0
+	}
+	if a.Hash == b.Hash {
+		return 1
+	}
+	equal := 0
+	for i := range a.MinHash {
+		if a.MinHash[i] == b.MinHash[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a.MinHash))
+}
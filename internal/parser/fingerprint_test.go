@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestFingerprint(t *testing.T) {
+	manager := NewManager()
+
+	// total and sum differ only in names, literals and comments; average adds a statement; render is
+	// unrelated. The closure inside render is nested and gets no fingerprint of its own.
+	content := `package stats
+
+func total(items []int) int {
+	result := 0
+	for _, item := range items {
+		if item > 0 {
+			result += item * 2
+		}
+	}
+	return result
+}
+
+func sum(values []int) int {
+	// Accumulate the doubled positive values
+	acc := 10
+	for _, v := range values {
+		if v > 0 {
+			acc += v * 3
+		}
+	}
+	return acc
+}
+
+func average(values []int) int {
+	acc := 0
+	for _, v := range values {
+		if v > 0 {
+			acc += v * 2
+		}
+	}
+	acc = acc / len(values)
+	return acc
+}
+
+func render(name string) string {
+	format := func(s string) string { return "<" + s + ">" }
+	return format(name)
+}
+`
+	lang := manager.detectLanguage("stats.go")
+	ast, err := manager.parseContent(content, *lang, "stats.go")
+	if err != nil {
+		t.Fatalf("Failed to parse content: %v", err)
+	}
+	symbols, err := manager.ExtractSymbols(ast)
+	if err != nil {
+		t.Fatalf("Failed to extract symbols: %v", err)
+	}
+
+	fingerprints := make(map[string]*types.Fingerprint)
+	for _, symbol := range symbols {
+		if symbol.Fingerprint != nil {
+			fingerprints[symbol.Name] = symbol.Fingerprint
+		}
+	}
+	for _, name := range []string{"total", "sum", "average", "render"} {
+		if fingerprints[name] == nil {
+			t.Fatalf("expected a fingerprint for %s, got %v", name, fingerprints)
+		}
+	}
+	if len(fingerprints) != 4 {
+		t.Errorf("expected only top-level functions to be fingerprinted, got %d", len(fingerprints))
+	}
+
+	total, sum, average, render := fingerprints["total"], fingerprints["sum"], fingerprints["average"], fingerprints["render"]
+	if total.Hash != sum.Hash || total.Tokens != sum.Tokens || Similarity(total, sum) != 1 {
+		t.Errorf("renamed copies should fingerprint identically: %+v vs %+v", total, sum)
+	}
+	if total.Hash == average.Hash {
+		t.Error("an edited copy should not hash like the original")
+	}
+	if similarity := Similarity(total, average); similarity < 0.5 || similarity >= 1 {
+		t.Errorf("Similarity(total, average) = %.2f, expected a near clone", similarity)
+	}
+	if similarity := Similarity(total, render); similarity > 0.2 {
+		t.Errorf("Similarity(total, render) = %.2f, expected unrelated functions", similarity)
+	}
+	if Similarity(total, nil) != 0 {
+		t.Error("Similarity with a missing fingerprint should be 0")
+	}
+}
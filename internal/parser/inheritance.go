@@ -0,0 +1,291 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Inheritance kinds
+const (
+	InheritanceExtends    = "extends"
+	InheritanceImplements = "implements"
+)
+
+// implementsKeyword separates the base class from the interfaces in a TypeScript heritage clause
+var implementsKeyword = regexp.MustCompile(`\bimplements\b`)
+
+// ExtractInheritance extracts the heritage clauses of an AST: base classes, implemented interfaces,
+// super interfaces and traits, and Rust trait implementations.
+// Symbol ids match the ids produced by ExtractSymbols for the same AST.
+func (m *Manager) ExtractInheritance(ast *types.AST) ([]*types.Inheritance, error) {
+	if ast.Root == nil {
+		return nil, fmt.Errorf("AST root is nil")
+	}
+
+	var inheritance []*types.Inheritance
+	m.extractInheritanceRecursive(ast.Root, ast, &inheritance)
+
+	return inheritance, nil
+}
+
+func (m *Manager) extractInheritanceRecursive(node *types.ASTNode, ast *types.AST, inheritance *[]*types.Inheritance) {
+	if node == nil {
+		return
+	}
+
+	*inheritance = append(*inheritance, m.nodeToInheritance(node, ast)...)
+
+	for _, child := range node.Children {
+		m.extractInheritanceRecursive(child, ast, inheritance)
+	}
+}
+
+// nodeToInheritance returns the heritage clauses declared by a type declaration node
+func (m *Manager) nodeToInheritance(node *types.ASTNode, ast *types.AST) []*types.Inheritance {
+	switch ast.Language {
+	case "python":
+		if node.Type == "class_definition" {
+			return m.pythonBases(node, ast)
+		}
+	case "java":
+		switch node.Type {
+		case "class_declaration", "interface_declaration":
+			return m.javaSupertypes(node, ast)
+		}
+	case "rust":
+		switch node.Type {
+		case "impl_item":
+			return m.rustTraitImpl(node, ast)
+		case "trait_item":
+			return m.rustSupertraits(node, ast)
+		}
+	case "go":
+		// Go has no declared inheritance; interface satisfaction is structural
+		return nil
+	default:
+		switch node.Type {
+		case "class_declaration", "class", "class_expression":
+			return m.jsHeritage(node, ast)
+		}
+	}
+	return nil
+}
+
+// jsHeritage parses "extends Base implements I1, I2" clauses. The JavaScript grammar used for
+// TypeScript does not understand implements lists, so the clause is read from its source text.
+func (m *Manager) jsHeritage(node *types.ASTNode, ast *types.AST) []*types.Inheritance {
+	var heritage *types.ASTNode
+	for _, child := range node.Children {
+		if child.Type == "class_heritage" {
+			heritage = child
+		}
+	}
+	symbol := m.declaringSymbol(node, ast, "identifier", "type_identifier")
+	if heritage == nil || symbol == nil {
+		return nil
+	}
+
+	clause := strings.TrimSpace(heritage.Value)
+	implementsPart := ""
+	if loc := implementsKeyword.FindStringIndex(clause); loc != nil {
+		clause, implementsPart = clause[:loc[0]], clause[loc[1]:]
+	}
+	clause = strings.TrimPrefix(strings.TrimSpace(clause), "extends")
+
+	var result []*types.Inheritance
+	for _, name := range splitTypeList(clause) {
+		result = appendInheritance(result, symbol, symbol.Name, InheritanceExtends, name, heritage.Location)
+	}
+	for _, name := range splitTypeList(implementsPart) {
+		result = appendInheritance(result, symbol, symbol.Name, InheritanceImplements, name, heritage.Location)
+	}
+	return result
+}
+
+// pythonBases reads the base classes from a class definition's argument list
+func (m *Manager) pythonBases(node *types.ASTNode, ast *types.AST) []*types.Inheritance {
+	symbol := m.declaringSymbol(node, ast, "identifier")
+	if symbol == nil {
+		return nil
+	}
+
+	var result []*types.Inheritance
+	for _, child := range node.Children {
+		if child.Type != "argument_list" {
+			continue
+		}
+		// Keyword arguments such as metaclass=ABCMeta are not bases
+		for _, base := range child.Children {
+			result = appendInheritance(result, symbol, symbol.Name, InheritanceExtends, typeReferenceName(base), base.Location)
+		}
+	}
+	return result
+}
+
+// javaSupertypes reads superclass, super_interfaces and extends_interfaces clauses
+func (m *Manager) javaSupertypes(node *types.ASTNode, ast *types.AST) []*types.Inheritance {
+	symbol := m.declaringSymbol(node, ast, "identifier")
+	if symbol == nil {
+		return nil
+	}
+
+	var result []*types.Inheritance
+	for _, child := range node.Children {
+		kind := ""
+		switch child.Type {
+		case "superclass", "extends_interfaces":
+			kind = InheritanceExtends
+		case "super_interfaces":
+			kind = InheritanceImplements
+		default:
+			continue
+		}
+
+		for _, clause := range child.Children {
+			typeNodes := []*types.ASTNode{clause}
+			if clause.Type == "type_list" {
+				typeNodes = clause.Children
+			}
+			for _, typeNode := range typeNodes {
+				result = appendInheritance(result, symbol, symbol.Name, kind, typeReferenceName(typeNode), typeNode.Location)
+			}
+		}
+	}
+	return result
+}
+
+// rustTraitImpl turns "impl Trait for Type" into an implements clause owned by the impl block
+func (m *Manager) rustTraitImpl(node *types.ASTNode, ast *types.AST) []*types.Inheritance {
+	var trait, target *types.ASTNode
+	for i, child := range node.Children {
+		if child.Type == "for" && i > 0 && i+1 < len(node.Children) {
+			trait, target = node.Children[i-1], node.Children[i+1]
+		}
+	}
+	if trait == nil {
+		// Inherent impl blocks implement no trait
+		return nil
+	}
+
+	symbol := m.nodeToSymbolWithContent(node, ast.FilePath, ast.Language, ast.Content)
+	typeName := typeReferenceName(target)
+	if symbol == nil || typeName == "" {
+		return nil
+	}
+	_, typeName = splitQualifiedName(typeName)
+
+	return appendInheritance(nil, symbol, typeName, InheritanceImplements, typeReferenceName(trait), trait.Location)
+}
+
+// rustSupertraits reads "trait Sub: Super + other::Trait" bounds
+func (m *Manager) rustSupertraits(node *types.ASTNode, ast *types.AST) []*types.Inheritance {
+	symbol := m.declaringSymbol(node, ast, "type_identifier")
+	if symbol == nil {
+		return nil
+	}
+
+	var result []*types.Inheritance
+	for _, child := range node.Children {
+		if child.Type != "trait_bounds" {
+			continue
+		}
+		// Lifetime bounds such as 'static are not traits
+		for _, bound := range child.Children {
+			result = appendInheritance(result, symbol, symbol.Name, InheritanceExtends, typeReferenceName(bound), bound.Location)
+		}
+	}
+	return result
+}
+
+// declaringSymbol returns the symbol of a named type declaration; anonymous classes have none
+func (m *Manager) declaringSymbol(node *types.ASTNode, ast *types.AST, nameTypes ...string) *types.Symbol {
+	named := false
+	for _, child := range node.Children {
+		for _, nameType := range nameTypes {
+			if child.Type == nameType {
+				named = true
+			}
+		}
+	}
+	if !named {
+		return nil
+	}
+	return m.nodeToSymbolWithContent(node, ast.FilePath, ast.Language, ast.Content)
+}
+
+// appendInheritance adds a clause for a qualified target name, skipping names that are not type references
+func appendInheritance(result []*types.Inheritance, symbol *types.Symbol, typeName, kind, targetName string, location types.FileLocation) []*types.Inheritance {
+	qualifier, target := splitQualifiedName(targetName)
+	if target == "" || !isValidIdentifier(target) {
+		return result
+	}
+	return append(result, &types.Inheritance{
+		Symbol:    symbol.Id,
+		Type:      typeName,
+		Kind:      kind,
+		Target:    target,
+		Qualifier: qualifier,
+		Location:  location,
+	})
+}
+
+// typeReferenceName returns the qualified name a type node refers to without its type arguments
+func typeReferenceName(node *types.ASTNode) string {
+	if node == nil {
+		return ""
+	}
+	switch node.Type {
+	case "identifier", "type_identifier":
+		return strings.TrimSpace(node.Value)
+	case "scoped_type_identifier", "scoped_identifier", "attribute", "member_expression":
+		return compactExpression(node.Value)
+	case "generic_type", "subscript":
+		// Base<T>, Generic[T]
+		if len(node.Children) > 0 {
+			return typeReferenceName(node.Children[0])
+		}
+	}
+	return ""
+}
+
+// splitQualifiedName splits "a.b.C" or "a::b::C" into its qualifier and the type name
+func splitQualifiedName(name string) (string, string) {
+	if index := strings.LastIndex(name, "::"); index >= 0 {
+		return name[:index], name[index+2:]
+	}
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		return name[:index], name[index+1:]
+	}
+	return "", name
+}
+
+// splitTypeList splits a comma separated list of type references, dropping type arguments
+func splitTypeList(list string) []string {
+	var names []string
+	var current strings.Builder
+	depth := 0
+	flush := func() {
+		if name := compactExpression(current.String()); name != "" {
+			names = append(names, name)
+		}
+		current.Reset()
+	}
+
+	for _, r := range list {
+		switch {
+		case r == '<' || r == '(' || r == '[':
+			depth++
+		case r == '>' || r == ')' || r == ']':
+			depth--
+		case r == ',' && depth == 0:
+			flush()
+		case depth == 0:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return names
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestExtractInheritance(t *testing.T) {
+	manager := NewManager()
+
+	type expectedClause struct {
+		typeName  string
+		kind      string
+		qualifier string
+		target    string
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		expected []expectedClause
+	}{
+		{
+			name:     "typescript extends and implements",
+			filePath: "dog.ts",
+			content:  "class Dog extends animals.Animal<T> implements Pet, ns.Named {\n  bark() {}\n}\nclass Cat extends Animal {}\nconst Anonymous = class extends Base {};\n",
+			expected: []expectedClause{
+				{"Dog", InheritanceExtends, "animals", "Animal"},
+				{"Dog", InheritanceImplements, "", "Pet"},
+				{"Dog", InheritanceImplements, "ns", "Named"},
+				{"Cat", InheritanceExtends, "", "Animal"},
+			},
+		},
+		{
+			name:     "python base classes",
+			filePath: "dog.py",
+			content:  "class Dog(Animal, mixins.Loud, metaclass=ABCMeta):\n    pass\n\nclass Box(Generic[T]):\n    pass\n",
+			expected: []expectedClause{
+				{"Dog", InheritanceExtends, "", "Animal"},
+				{"Dog", InheritanceExtends, "mixins", "Loud"},
+				{"Box", InheritanceExtends, "", "Generic"},
+			},
+		},
+		{
+			name:     "java superclass and interfaces",
+			filePath: "Dog.java",
+			content:  "class Dog extends com.acme.Animal<String> implements Pet, Named<T> {}\ninterface Pet extends Base, Other {}\n",
+			expected: []expectedClause{
+				{"Dog", InheritanceExtends, "com.acme", "Animal"},
+				{"Dog", InheritanceImplements, "", "Pet"},
+				{"Dog", InheritanceImplements, "", "Named"},
+				{"Pet", InheritanceExtends, "", "Base"},
+				{"Pet", InheritanceExtends, "", "Other"},
+			},
+		},
+		{
+			name:     "rust trait impls and supertraits",
+			filePath: "dog.rs",
+			content:  "struct Dog;\nimpl<T> fmt::Display for Dog<T> {}\nimpl Pet for Dog {}\nimpl Dog {}\ntrait Pet: Animal + fmt::Debug {}\n",
+			expected: []expectedClause{
+				{"Dog", InheritanceImplements, "fmt", "Display"},
+				{"Dog", InheritanceImplements, "", "Pet"},
+				{"Pet", InheritanceExtends, "", "Animal"},
+				{"Pet", InheritanceExtends, "fmt", "Debug"},
+			},
+		},
+		{
+			name:     "go has no declared inheritance",
+			filePath: "dog.go",
+			content:  "package pets\n\ntype Dog struct {\n\tAnimal\n}\n",
+			expected: []expectedClause{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := manager.detectLanguage(tt.filePath)
+			if lang == nil {
+				t.Fatalf("Failed to detect language for %s", tt.filePath)
+			}
+
+			ast, err := manager.parseContent(tt.content, *lang, tt.filePath)
+			if err != nil {
+				t.Fatalf("Failed to parse content: %v", err)
+			}
+
+			inheritance, err := manager.ExtractInheritance(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract inheritance: %v", err)
+			}
+
+			if len(inheritance) != len(tt.expected) {
+				t.Fatalf("Expected %d clauses, got %d: %+v", len(tt.expected), len(inheritance), inheritance)
+			}
+
+			for i, expected := range tt.expected {
+				clause := inheritance[i]
+				if clause.Type != expected.typeName || clause.Kind != expected.kind {
+					t.Errorf("clause[%d] = %s %s, expected %s %s", i, clause.Type, clause.Kind, expected.typeName, expected.kind)
+				}
+				if clause.Qualifier != expected.qualifier || clause.Target != expected.target {
+					t.Errorf("clause[%d] target = %s|%s, expected %s|%s", i, clause.Qualifier, clause.Target, expected.qualifier, expected.target)
+				}
+				if clause.Symbol == "" || clause.Location.Line == 0 {
+					t.Errorf("clause[%d] has no symbol or location: %+v", i, clause)
+				}
+			}
+		})
+	}
+}
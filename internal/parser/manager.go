@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -129,13 +130,18 @@ func (m *Manager) initLanguages() {
 
 // ParseFile parses a file and returns an AST
 func (m *Manager) ParseFile(filePath string, language types.Language) (*types.AST, error) {
+	return m.ParseFileContext(context.Background(), filePath, language)
+}
+
+// ParseFileContext parses a file like ParseFile, abandoning the parse when ctx is done
+func (m *Manager) ParseFileContext(ctx context.Context, filePath string, language types.Language) (*types.AST, error) {
 	// Read file content from disk
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
 
-	return m.parseContent(string(content), language, filePath)
+	return m.parseContentContext(ctx, string(content), language, filePath)
 }
 
 // ParseFileVersioned parses a file with version information
@@ -172,6 +178,8 @@ func (m *Manager) ExtractSymbols(ast *types.AST) ([]*types.Symbol, error) {
 
 	var symbols []*types.Symbol
 	m.extractSymbolsRecursiveWithContent(ast.Root, ast.FilePath, ast.Language, ast.Content, &symbols)
+	m.assignVisibility(ast, symbols)
+	assignMetrics(ast.Root, symbols)
 
 	return symbols, nil
 }
@@ -183,7 +191,7 @@ func (m *Manager) ExtractImports(ast *types.AST) ([]*types.Import, error) {
 	}
 
 	var imports []*types.Import
-	m.extractImportsRecursive(ast.Root, &imports)
+	m.extractImportsRecursive(ast.Root, ast.Language, &imports)
 
 	return imports, nil
 }
@@ -355,6 +363,15 @@ func (m *Manager) detectLanguage(filePath string) *types.Language {
 }
 
 func (m *Manager) parseContent(content string, language types.Language, filePath ...string) (*types.AST, error) {
+	return m.parseContentContext(context.Background(), content, language, filePath...)
+}
+
+// parseContentContext parses content, stopping tree-sitter once ctx is done
+func (m *Manager) parseContentContext(ctx context.Context, content string, language types.Language, filePath ...string) (*types.AST, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	m.mu.RLock()
 	parser, exists := m.parsers[language.Name]
 	treeSitterLang := m.languages[language.Name]
@@ -394,8 +411,24 @@ func (m *Manager) parseContent(content string, language types.Language, filePath
 		return ast, nil
 	}
 
-	// Parse using real Tree-sitter grammar
-	tree := parser.Parse([]byte(content), nil)
+	// Parse using real Tree-sitter grammar; the progress callback cancels the parse once ctx is done
+	text := []byte(content)
+	tree := parser.ParseWithOptions(func(offset int, _ sitter.Point) []byte {
+		if offset < len(text) {
+			return text[offset:]
+		}
+		return []byte{}
+	}, nil, &sitter.ParseOptions{ProgressCallback: func(sitter.ParseState) bool {
+		return ctx.Err() != nil
+	}})
+	if tree == nil {
+		// An aborted parse would otherwise be resumed by the next one on this parser
+		parser.Reset()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("tree-sitter failed to parse %s", language.Name)
+	}
 	defer tree.Close()
 
 	// Create AST with real Tree-sitter data
@@ -528,6 +561,12 @@ func (m *Manager) nodeToSymbolWithContent(node *types.ASTNode, filePath, languag
 }
 
 func (m *Manager) nodeToSymbolJS(node *types.ASTNode, filePath, language string) *types.Symbol {
+	// Keyword tokens such as "function" and "class" share their node type with declarations
+	// but have no children; they would shadow the declaration's symbol id
+	if len(node.Children) == 0 {
+		return nil
+	}
+
 	// Enhanced symbol extraction for JavaScript/TypeScript using real Tree-sitter node types
 	switch node.Type {
 	case "function_declaration", "function", "function_expression", "arrow_function":
@@ -750,11 +789,18 @@ func (m *Manager) nodeToSymbolGo(node *types.ASTNode, filePath, language string)
 			Hash:         calculateHash(node.Value),
 			LastModified: time.Now(),
 		}
-	case "type_declaration":
+	case "type_spec", "type_alias":
+		// One symbol per spec so grouped "type ( ... )" declarations keep their names
+		symbolType := types.SymbolTypeType
+		for _, child := range node.Children {
+			if child.Type == "interface_type" {
+				symbolType = types.SymbolTypeInterface
+			}
+		}
 		return &types.Symbol{
 			Id:           types.SymbolId(fmt.Sprintf("type-%s-%d", filePath, node.Location.Line)),
 			Name:         m.extractSymbolName(node),
-			Type:         types.SymbolTypeType,
+			Type:         symbolType,
 			Location:     convertLocation(node.Location),
 			Language:     language,
 			Hash:         calculateHash(node.Value),
@@ -933,23 +979,48 @@ func (m *Manager) nodeToSymbolCSharp(node *types.ASTNode, filePath, language str
 	}
 }
 
-func (m *Manager) extractImportsRecursive(node *types.ASTNode, imports *[]*types.Import) {
+func (m *Manager) extractImportsRecursive(node *types.ASTNode, language string, imports *[]*types.Import) {
 	if node == nil {
 		return
 	}
 
-	// Check if this node represents an import
-	if imp := m.nodeToImport(node); imp != nil {
-		*imports = append(*imports, imp)
+	// Check if this node represents one or more imports
+	*imports = append(*imports, m.nodeToImports(node, language)...)
+
+	// Inline Rust modules were expanded by nodeToImportsRust so nested paths keep their module prefix
+	if language == "rust" && node.Type == "mod_item" {
+		return
 	}
 
 	// Recursively extract from children
 	for _, child := range node.Children {
-		m.extractImportsRecursive(child, imports)
+		m.extractImportsRecursive(child, language, imports)
 	}
 }
 
-func (m *Manager) nodeToImport(node *types.ASTNode) *types.Import {
+// nodeToImports converts an import node into imports; some statements import several modules at once
+func (m *Manager) nodeToImports(node *types.ASTNode, language string) []*types.Import {
+	switch language {
+	case "python":
+		return m.nodeToImportsPython(node)
+	case "rust":
+		return m.nodeToImportsRust(node)
+	}
+
+	if imp := m.nodeToImport(node, language); imp != nil {
+		return []*types.Import{imp}
+	}
+	return nil
+}
+
+func (m *Manager) nodeToImport(node *types.ASTNode, language string) *types.Import {
+	switch language {
+	case "go":
+		return m.nodeToImportGo(node)
+	case "java":
+		return m.nodeToImportJava(node)
+	}
+
 	if node.Type != "import_statement" && node.Type != "import_declaration" {
 		return nil
 	}
@@ -983,6 +1054,310 @@ func (m *Manager) nodeToImport(node *types.ASTNode) *types.Import {
 	return imp
 }
 
+// nodeToImportGo extracts a single Go import from an import_spec node.
+// Grouped imports produce one import_spec per path, so each spec becomes its own import.
+func (m *Manager) nodeToImportGo(node *types.ASTNode) *types.Import {
+	if node.Type != "import_spec" {
+		return nil
+	}
+
+	imp := &types.Import{
+		Location: node.Location,
+	}
+
+	for _, child := range node.Children {
+		switch child.Type {
+		case "interpreted_string_literal", "raw_string_literal":
+			imp.Path = strings.Trim(child.Value, "\"`")
+		case "package_identifier", "dot", "blank_identifier":
+			imp.Alias = strings.TrimSpace(child.Value)
+		}
+	}
+
+	if imp.Path == "" {
+		return nil
+	}
+
+	return imp
+}
+
+// nodeToImportsPython extracts imports from Python import statements.
+// "import a, b as c" yields one import per module; "from .x import y, z" yields a single
+// import whose path keeps the leading dots and whose specifiers are the imported names.
+func (m *Manager) nodeToImportsPython(node *types.ASTNode) []*types.Import {
+	switch node.Type {
+	case "import_statement":
+		var imports []*types.Import
+		for _, child := range node.Children {
+			switch child.Type {
+			case "dotted_name":
+				imports = append(imports, &types.Import{
+					Path:     strings.TrimSpace(child.Value),
+					Location: node.Location,
+				})
+			case "aliased_import":
+				imp := &types.Import{Location: node.Location}
+				for _, part := range child.Children {
+					switch part.Type {
+					case "dotted_name":
+						imp.Path = strings.TrimSpace(part.Value)
+					case "identifier":
+						imp.Alias = strings.TrimSpace(part.Value)
+					}
+				}
+				imports = append(imports, imp)
+			}
+		}
+		return imports
+
+	case "import_from_statement":
+		imp := &types.Import{Location: node.Location}
+		seenModule := false
+		for _, child := range node.Children {
+			switch child.Type {
+			case "relative_import":
+				imp.Path = strings.ReplaceAll(child.Value, " ", "")
+				seenModule = true
+			case "dotted_name":
+				if !seenModule {
+					imp.Path = strings.TrimSpace(child.Value)
+					seenModule = true
+				} else {
+					imp.Specifiers = append(imp.Specifiers, strings.TrimSpace(child.Value))
+				}
+			case "aliased_import":
+				for _, part := range child.Children {
+					if part.Type == "dotted_name" {
+						imp.Specifiers = append(imp.Specifiers, strings.TrimSpace(part.Value))
+					}
+				}
+			case "wildcard_import":
+				imp.Specifiers = append(imp.Specifiers, "*")
+			}
+		}
+		if imp.Path == "" {
+			return nil
+		}
+		return []*types.Import{imp}
+	}
+
+	return nil
+}
+
+// nodeToImportJava extracts a Java import declaration. The path is the imported class or
+// static member; on-demand imports ("import a.b.*;") keep the package path with a "*" specifier.
+func (m *Manager) nodeToImportJava(node *types.ASTNode) *types.Import {
+	if node.Type != "import_declaration" {
+		return nil
+	}
+
+	imp := &types.Import{
+		Location: node.Location,
+	}
+
+	for _, child := range node.Children {
+		switch child.Type {
+		case "scoped_identifier", "identifier":
+			imp.Path = strings.Join(strings.Fields(child.Value), "")
+		case "asterisk":
+			imp.Specifiers = append(imp.Specifiers, "*")
+		}
+	}
+
+	if imp.Path == "" {
+		return nil
+	}
+
+	return imp
+}
+
+// nodeToImportsRust extracts imports from Rust use declarations and module declarations.
+// Use trees are flattened so every imported path becomes its own import, glob imports keep
+// the module path with a "*" specifier, and "mod foo;" becomes an import of "self::foo".
+func (m *Manager) nodeToImportsRust(node *types.ASTNode) []*types.Import {
+	switch node.Type {
+	case "use_declaration":
+		return m.rustUseImports(node, nil)
+	case "mod_item":
+		return m.rustModImports(node, nil)
+	}
+	return nil
+}
+
+// rustModImports handles a mod item nested in the given inline modules. Declarations without a
+// body load a file; inline bodies are searched for nested use and mod items.
+func (m *Manager) rustModImports(node *types.ASTNode, modules []string) []*types.Import {
+	name := ""
+	var body *types.ASTNode
+	for _, child := range node.Children {
+		switch child.Type {
+		case "identifier":
+			if name == "" {
+				name = strings.TrimSpace(child.Value)
+			}
+		case "declaration_list":
+			body = child
+		}
+	}
+	if name == "" {
+		return nil
+	}
+
+	modules = append(append([]string(nil), modules...), name)
+	if body == nil {
+		return []*types.Import{{
+			Path:     "self::" + strings.Join(modules, "::"),
+			Location: node.Location,
+		}}
+	}
+
+	var imports []*types.Import
+	m.rustInlineModuleImports(body, modules, &imports)
+	return imports
+}
+
+// rustInlineModuleImports collects the imports found anywhere inside an inline module body
+func (m *Manager) rustInlineModuleImports(node *types.ASTNode, modules []string, imports *[]*types.Import) {
+	for _, child := range node.Children {
+		switch child.Type {
+		case "use_declaration":
+			*imports = append(*imports, m.rustUseImports(child, modules)...)
+		case "mod_item":
+			*imports = append(*imports, m.rustModImports(child, modules)...)
+		default:
+			m.rustInlineModuleImports(child, modules, imports)
+		}
+	}
+}
+
+// rustUseImports flattens a use declaration. Inside inline modules, self and super paths
+// are rewritten relative to the file's module.
+func (m *Manager) rustUseImports(node *types.ASTNode, modules []string) []*types.Import {
+	var imports []*types.Import
+	for _, child := range node.Children {
+		switch child.Type {
+		case "use", ";", "visibility_modifier":
+			continue
+		}
+		imports = append(imports, m.expandRustUseTree(child, "", node.Location)...)
+	}
+
+	if len(modules) > 0 {
+		for _, imp := range imports {
+			imp.Path = rebaseRustPath(imp.Path, modules)
+		}
+	}
+	return imports
+}
+
+// expandRustUseTree turns a use tree into one import per leaf path
+func (m *Manager) expandRustUseTree(node *types.ASTNode, prefix string, location types.FileLocation) []*types.Import {
+	switch node.Type {
+	case "identifier", "scoped_identifier", "crate", "super", "metavariable":
+		return []*types.Import{{Path: joinRustPath(prefix, node.Value), Location: location}}
+
+	case "self":
+		// "use foo::{self}" imports the module foo itself
+		return []*types.Import{{Path: joinRustPath(prefix, "self"), Location: location}}
+
+	case "use_as_clause":
+		imp := &types.Import{Location: location}
+		for _, child := range node.Children {
+			switch child.Type {
+			case "as":
+				continue
+			case "identifier":
+				if imp.Path != "" {
+					imp.Alias = strings.TrimSpace(child.Value)
+					continue
+				}
+			}
+			if imp.Path == "" {
+				imp.Path = joinRustPath(prefix, child.Value)
+			}
+		}
+		return []*types.Import{imp}
+
+	case "use_wildcard":
+		path := prefix
+		for _, child := range node.Children {
+			if child.Type != "::" && child.Type != "*" {
+				path = joinRustPath(prefix, child.Value)
+			}
+		}
+		if path == "" {
+			return nil
+		}
+		return []*types.Import{{Path: path, Specifiers: []string{"*"}, Location: location}}
+
+	case "scoped_use_list":
+		path := prefix
+		var imports []*types.Import
+		for _, child := range node.Children {
+			switch child.Type {
+			case "::":
+				continue
+			case "use_list":
+				imports = append(imports, m.expandRustUseTree(child, path, location)...)
+			default:
+				path = joinRustPath(prefix, child.Value)
+			}
+		}
+		return imports
+
+	case "use_list":
+		var imports []*types.Import
+		for _, child := range node.Children {
+			switch child.Type {
+			case "{", "}", ",":
+				continue
+			}
+			imports = append(imports, m.expandRustUseTree(child, prefix, location)...)
+		}
+		return imports
+	}
+
+	return nil
+}
+
+// joinRustPath appends a use tree segment to a path prefix, dropping a trailing "self"
+func joinRustPath(prefix, segment string) string {
+	segment = strings.TrimPrefix(strings.Join(strings.Fields(segment), ""), "::")
+	switch {
+	case segment == "self" && prefix != "":
+		return prefix
+	case prefix == "":
+		return segment
+	default:
+		return prefix + "::" + segment
+	}
+}
+
+// rebaseRustPath rewrites a path used inside inline modules so it is relative to the file's module
+func rebaseRustPath(path string, modules []string) string {
+	segments := strings.Split(path, "::")
+	depth := len(modules)
+	i := 0
+	switch segments[0] {
+	case "self":
+		i = 1
+	case "super":
+		for i < len(segments) && segments[i] == "super" && depth > 0 {
+			depth--
+			i++
+		}
+		if i < len(segments) && segments[i] == "super" {
+			// Climbing above the file's module is left to the resolver
+			return strings.Join(segments[i:], "::")
+		}
+	default:
+		return path
+	}
+
+	rebased := append([]string{"self"}, modules[:depth]...)
+	return strings.Join(append(rebased, segments[i:]...), "::")
+}
+
 func (m *Manager) getExtensionsForLanguage(name string) []string {
 	switch name {
 	case "typescript":
@@ -1019,7 +1394,8 @@ func (m *Manager) extractSymbolName(node *types.ASTNode) string {
 
 	// Look for identifier children that represent the symbol name
 	for _, child := range node.Children {
-		if child.Type == "identifier" || child.Type == "type_identifier" {
+		// Go method names are field identifiers that follow the receiver list
+		if child.Type == "identifier" || child.Type == "type_identifier" || child.Type == "field_identifier" {
 			return strings.TrimSpace(child.Value)
 		}
 
@@ -1069,12 +1445,29 @@ func (m *Manager) extractFunctionSignature(node *types.ASTNode) string {
 	}
 
 	// Look for parameter list and return type
-	for _, child := range node.Children {
+	body := functionBody(node)
+	for i, child := range node.Children {
 		if child.Type == "formal_parameters" || child.Type == "parameters" {
-			return strings.TrimSpace(child.Value)
+			// Return type annotations run from the parameters to the body
+			signature := child.Value
+			if start := strings.Index(node.Value, child.Value); start >= 0 && body != nil {
+				if node.Type == "method_declaration" {
+					// Java declares the return type before the name, after any modifiers
+					start = javaDeclarationStart(node, i, start)
+				}
+				if end := strings.LastIndex(node.Value, body.Value); end > start {
+					signature = strings.TrimSuffix(strings.TrimSpace(node.Value[start:end]), ":")
+				}
+			}
+			return normalizeSignature(signature)
 		}
 	}
 
+	// Go declarations span from func to the body, possibly across lines
+	if body != nil && node.Type != "class_declaration" && strings.HasSuffix(node.Value, body.Value) {
+		return normalizeSignature(strings.TrimSuffix(node.Value, body.Value))
+	}
+
 	// Fallback: extract first line of the node
 	value := strings.TrimSpace(node.Value)
 	lines := strings.Split(value, "\n")
@@ -1090,6 +1483,40 @@ func (m *Manager) extractFunctionSignature(node *types.ASTNode) string {
 	return ""
 }
 
+// javaDeclarationStart returns the offset in a method declaration of its first child after the
+// modifiers, or paramsStart when the children before the parameters cannot be located
+func javaDeclarationStart(node *types.ASTNode, paramsIndex, paramsStart int) int {
+	for _, child := range node.Children[:paramsIndex] {
+		if child.Type == "modifiers" {
+			continue
+		}
+		if start := strings.Index(node.Value, child.Value); start >= 0 && start < paramsStart {
+			return start
+		}
+		break
+	}
+	return paramsStart
+}
+
+// normalizeSignature collapses whitespace, including the line breaks and trailing comma of a
+// parameter list split across lines
+func normalizeSignature(signature string) string {
+	signature = strings.Join(strings.Fields(signature), " ")
+	signature = strings.ReplaceAll(signature, "( ", "(")
+	signature = strings.ReplaceAll(signature, " )", ")")
+	return strings.ReplaceAll(signature, ",)", ")")
+}
+
+// functionBody returns the block holding a function's body, or nil for expression bodies
+func functionBody(node *types.ASTNode) *types.ASTNode {
+	for _, child := range node.Children {
+		if child.Type == "block" || child.Type == "statement_block" {
+			return child
+		}
+	}
+	return nil
+}
+
 // extractImportName extracts name from import nodes
 func (m *Manager) extractImportName(node *types.ASTNode) string {
 	if node == nil {
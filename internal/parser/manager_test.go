@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/nuthan-ms/codecontext/pkg/types"
@@ -283,6 +286,56 @@ func TestParseFileVersioned(t *testing.T) {
 	}
 }
 
+func TestParseContentContext(t *testing.T) {
+	manager := NewManager()
+	language := types.Language{Name: "go"}
+	content := "package main\n\nfunc main() {}\n"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := manager.parseContentContext(ctx, content, language, "main.go"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled parse to fail with context.Canceled, got %v", err)
+	}
+
+	// The parser is usable again after a cancelled parse
+	ast, err := manager.parseContentContext(context.Background(), content, language, "main.go")
+	if err != nil || ast.Root == nil {
+		t.Fatalf("parseContentContext() = %v, %v", ast, err)
+	}
+
+	// A parse cancelled part way through does not leak into the next one
+	large := "package big\n\n" + strings.Repeat("func Big() int {\n\treturn 1 + 2\n}\n\n", 20000)
+	if _, err := manager.parseContentContext(&expiringContext{Context: context.Background(), checks: 2}, large, language, "big.go"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the parse to be cancelled part way through, got %v", err)
+	}
+	ast, err = manager.parseContentContext(context.Background(), "package small\n\nfunc Small() {}\n", language, "small.go")
+	if err != nil {
+		t.Fatalf("parseContentContext() error = %v", err)
+	}
+	symbols, err := manager.ExtractSymbols(ast)
+	if err != nil {
+		t.Fatalf("ExtractSymbols() error = %v", err)
+	}
+	if len(symbols) != 1 || symbols[0].Name != "Small" {
+		t.Errorf("expected only Small after a cancelled parse, got %+v", symbols)
+	}
+}
+
+// expiringContext reports cancellation once Err has been called a given number of times, to cancel
+// a parse from its progress callback
+type expiringContext struct {
+	context.Context
+	checks int
+}
+
+func (ctx *expiringContext) Err() error {
+	if ctx.checks > 0 {
+		ctx.checks--
+		return nil
+	}
+	return context.Canceled
+}
+
 func TestFrameworkHelperFunctions(t *testing.T) {
 	manager := NewManager()
 
@@ -495,6 +548,95 @@ func TestLanguageSpecificSymbolExtraction(t *testing.T) {
 	}
 }
 
+func TestFunctionSignatureExtraction(t *testing.T) {
+	manager := NewManager()
+
+	tests := []struct {
+		name      string
+		filePath  string
+		content   string
+		symbol    string
+		signature string
+	}{
+		{
+			name:      "go function with results",
+			filePath:  "store.go",
+			content:   "package store\n\nfunc Get(ctx context.Context, key string) (*Item, error) {\n\treturn nil, nil\n}\n",
+			symbol:    "Get",
+			signature: "func Get(ctx context.Context, key string) (*Item, error)",
+		},
+		{
+			name:      "go method split across lines",
+			filePath:  "store.go",
+			content:   "package store\n\nfunc (s *Store) Keys(\n\tprefix string,\n\tlimit int,\n) []string {\n\treturn nil\n}\n",
+			symbol:    "Keys",
+			signature: "func (s *Store) Keys(prefix string, limit int) []string",
+		},
+		{
+			name:      "go variadic function",
+			filePath:  "log.go",
+			content:   "package log\n\nfunc Printf(format string, args ...any) {}\n",
+			symbol:    "Printf",
+			signature: "func Printf(format string, args ...any)",
+		},
+		{
+			name:      "typescript method with rest parameter",
+			filePath:  "store.ts",
+			content:   "class Store {\n  keys(\n    prefix: string,\n    ...rest: string[],\n  ): Promise<string[]> {\n    return [];\n  }\n}\n",
+			symbol:    "keys",
+			signature: "(prefix: string, ...rest: string[]): Promise<string[]>",
+		},
+		{
+			name:      "python function with return annotation",
+			filePath:  "store.py",
+			content:   "def get(key: str, default: int = 0) -> Optional[Item]:\n    return None\n",
+			symbol:    "get",
+			signature: "(key: str, default: int = 0) -> Optional[Item]",
+		},
+		{
+			name:      "java method with return type",
+			filePath:  "Store.java",
+			content:   "public class Store {\n    @Override\n    public Optional<Item> get(String key, int limit) throws IOException {\n        return null;\n    }\n}\n",
+			symbol:    "get",
+			signature: "Optional<Item> get(String key, int limit) throws IOException",
+		},
+		{
+			name:      "java varargs method",
+			filePath:  "Store.java",
+			content:   "public class Store {\n    public static List<String> keys(String... prefixes) {\n        return null;\n    }\n}\n",
+			symbol:    "keys",
+			signature: "List<String> keys(String... prefixes)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := manager.detectLanguage(tt.filePath)
+			if lang == nil {
+				t.Fatalf("Failed to detect language for %s", tt.filePath)
+			}
+			ast, err := manager.parseContent(tt.content, *lang, tt.filePath)
+			if err != nil {
+				t.Fatalf("Failed to parse content: %v", err)
+			}
+			symbols, err := manager.ExtractSymbols(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract symbols: %v", err)
+			}
+
+			for _, symbol := range symbols {
+				if symbol.Name == tt.symbol {
+					if symbol.Signature != tt.signature {
+						t.Errorf("Signature = %q, expected %q", symbol.Signature, tt.signature)
+					}
+					return
+				}
+			}
+			t.Errorf("Symbol %s not found in %v", tt.symbol, symbols)
+		})
+	}
+}
+
 func TestFrameworkDetection(t *testing.T) {
 	manager := NewManager()
 
@@ -693,3 +835,75 @@ func TestFrameworkSpecificSymbolExtraction(t *testing.T) {
 		})
 	}
 }
+
+func TestLanguageSpecificImportExtraction(t *testing.T) {
+	manager := NewManager()
+
+	tests := []struct {
+		name          string
+		filePath      string
+		content       string
+		expectedPaths []string
+		expectedAlias map[string]string
+	}{
+		{
+			name:          "go grouped and single imports",
+			filePath:      "main.go",
+			content:       "package main\n\nimport (\n\t\"fmt\"\n\tstore \"github.com/acme/app/internal/store\"\n\t_ \"embed\"\n)\n\nimport \"os\"\n",
+			expectedPaths: []string{"fmt", "github.com/acme/app/internal/store", "embed", "os"},
+			expectedAlias: map[string]string{"github.com/acme/app/internal/store": "store", "embed": "_"},
+		},
+		{
+			name:          "python absolute and relative imports",
+			filePath:      "views.py",
+			content:       "import os, app.models as models\nfrom . import forms\nfrom ..core.db import session, Base as B\nfrom .utils import *\n",
+			expectedPaths: []string{"os", "app.models", ".", "..core.db", ".utils"},
+			expectedAlias: map[string]string{"app.models": "models"},
+		},
+		{
+			name:          "java single, on-demand and static imports",
+			filePath:      "App.java",
+			content:       "package com.acme.app;\n\nimport com.acme.foo.Bar;\nimport com.acme.util.*;\nimport static com.acme.util.Strings.trim;\n\npublic class App {}\n",
+			expectedPaths: []string{"com.acme.foo.Bar", "com.acme.util", "com.acme.util.Strings.trim"},
+		},
+		{
+			name:          "rust use trees and module declarations",
+			filePath:      "lib.rs",
+			content:       "mod config;\nuse crate::config::Settings;\nuse super::db::{self, Pool, Conn as C};\nuse std::io::*;\nmod net {\n    mod tcp;\n    use super::config;\n}\n",
+			expectedPaths: []string{"self::config", "crate::config::Settings", "super::db", "super::db::Pool", "super::db::Conn", "std::io", "self::net::tcp", "self::config"},
+			expectedAlias: map[string]string{"super::db::Conn": "C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := manager.detectLanguage(tt.filePath)
+			if lang == nil {
+				t.Fatalf("Failed to detect language for %s", tt.filePath)
+			}
+
+			ast, err := manager.parseContent(tt.content, *lang, tt.filePath)
+			if err != nil {
+				t.Fatalf("Failed to parse content: %v", err)
+			}
+
+			imports, err := manager.ExtractImports(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract imports: %v", err)
+			}
+
+			if len(imports) != len(tt.expectedPaths) {
+				t.Fatalf("Expected %d imports, got %d: %+v", len(tt.expectedPaths), len(imports), imports)
+			}
+
+			for i, expected := range tt.expectedPaths {
+				if imports[i].Path != expected {
+					t.Errorf("import[%d].Path = %q, expected %q", i, imports[i].Path, expected)
+				}
+				if alias, ok := tt.expectedAlias[expected]; ok && imports[i].Alias != alias {
+					t.Errorf("import %q alias = %q, expected %q", expected, imports[i].Alias, alias)
+				}
+			}
+		})
+	}
+}
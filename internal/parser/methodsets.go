@@ -0,0 +1,225 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Type definition kinds
+const (
+	TypeKindStruct    = "struct"
+	TypeKindInterface = "interface"
+	TypeKindNamed     = "named"
+)
+
+// ExtractTypeDefinitions extracts the named types of a Go AST together with their interface methods
+// and embedded types. Other languages declare inheritance explicitly and return no definitions.
+func (m *Manager) ExtractTypeDefinitions(ast *types.AST) ([]*types.TypeDefinition, error) {
+	if ast.Root == nil {
+		return nil, fmt.Errorf("AST root is nil")
+	}
+	if ast.Language != "go" {
+		return nil, nil
+	}
+
+	var definitions []*types.TypeDefinition
+	m.walkNodes(ast.Root, func(node *types.ASTNode) {
+		if node.Type == "type_spec" {
+			if definition := m.goTypeDefinition(node, ast); definition != nil {
+				definitions = append(definitions, definition)
+			}
+		}
+	})
+
+	return definitions, nil
+}
+
+// ExtractMethods extracts the Go method declarations of an AST with their receiver types
+func (m *Manager) ExtractMethods(ast *types.AST) ([]*types.MethodSpec, error) {
+	if ast.Root == nil {
+		return nil, fmt.Errorf("AST root is nil")
+	}
+	if ast.Language != "go" {
+		return nil, nil
+	}
+
+	var methods []*types.MethodSpec
+	m.walkNodes(ast.Root, func(node *types.ASTNode) {
+		if node.Type == "method_declaration" {
+			if method := m.goMethodDeclaration(node, ast); method != nil {
+				methods = append(methods, method)
+			}
+		}
+	})
+
+	return methods, nil
+}
+
+// walkNodes calls visit for every node of a tree in document order
+func (m *Manager) walkNodes(node *types.ASTNode, visit func(*types.ASTNode)) {
+	if node == nil {
+		return
+	}
+	visit(node)
+	for _, child := range node.Children {
+		m.walkNodes(child, visit)
+	}
+}
+
+// goTypeDefinition describes a type_spec: name [type parameters] underlying type
+func (m *Manager) goTypeDefinition(node *types.ASTNode, ast *types.AST) *types.TypeDefinition {
+	symbol := m.nodeToSymbolWithContent(node, ast.FilePath, ast.Language, ast.Content)
+	if symbol == nil {
+		return nil
+	}
+
+	definition := &types.TypeDefinition{
+		Symbol:   symbol.Id,
+		Name:     symbol.Name,
+		Kind:     TypeKindNamed,
+		Location: node.Location,
+	}
+
+	for _, child := range node.Children {
+		switch child.Type {
+		case "struct_type":
+			definition.Kind = TypeKindStruct
+			definition.Embedded = goEmbeddedFields(child)
+		case "interface_type":
+			definition.Kind = TypeKindInterface
+			for _, element := range child.Children {
+				switch element.Type {
+				case "method_elem":
+					if method := goMethodSpec(element.Children); method != nil {
+						definition.Methods = append(definition.Methods, method)
+					}
+				case "type_elem":
+					// Embedded interfaces are a single type; unions and ~T make a constraint
+					definition.Embedded = append(definition.Embedded, compactExpression(element.Value))
+				}
+			}
+		}
+	}
+	return definition
+}
+
+// goEmbeddedFields returns the embedded fields of a struct, i.e. fields declared without a name
+func goEmbeddedFields(structType *types.ASTNode) []string {
+	var embedded []string
+	for _, list := range structType.Children {
+		if list.Type != "field_declaration_list" {
+			continue
+		}
+		for _, field := range list.Children {
+			if field.Type != "field_declaration" {
+				continue
+			}
+			named := false
+			for _, part := range field.Children {
+				if part.Type == "field_identifier" {
+					named = true
+				}
+			}
+			// Struct tags follow the embedded type
+			if fields := strings.Fields(field.Value); !named && len(fields) > 0 {
+				embedded = append(embedded, fields[0])
+			}
+		}
+	}
+	return embedded
+}
+
+// goMethodDeclaration describes a method: func (receiver) name parameters [result] body
+func (m *Manager) goMethodDeclaration(node *types.ASTNode, ast *types.AST) *types.MethodSpec {
+	if len(node.Children) < 2 || node.Children[1].Type != "parameter_list" {
+		return nil
+	}
+
+	method := goMethodSpec(node.Children[2:])
+	if method == nil {
+		return nil
+	}
+	if symbol := m.nodeToSymbolWithContent(node, ast.FilePath, ast.Language, ast.Content); symbol != nil {
+		method.Symbol = symbol.Id
+	}
+
+	for _, parameter := range node.Children[1].Children {
+		if parameter.Type != "parameter_declaration" {
+			continue
+		}
+		receiverType := parameter.Children[len(parameter.Children)-1]
+		if receiverType.Type == "pointer_type" {
+			method.Pointer = true
+			receiverType = receiverType.Children[len(receiverType.Children)-1]
+		}
+		if receiverType.Type == "generic_type" && len(receiverType.Children) > 0 {
+			receiverType = receiverType.Children[0]
+		}
+		method.Receiver = strings.TrimSpace(receiverType.Value)
+	}
+	if method.Receiver == "" {
+		return nil
+	}
+	return method
+}
+
+// goMethodSpec reads "name parameters [result]" shared by method_elem and method_declaration
+func goMethodSpec(parts []*types.ASTNode) *types.MethodSpec {
+	if len(parts) < 2 || parts[0].Type != "field_identifier" || parts[1].Type != "parameter_list" {
+		return nil
+	}
+
+	parameters := goParameterTypes(parts[1])
+	var results []string
+	if len(parts) > 2 {
+		switch parts[2].Type {
+		case "block":
+		case "parameter_list":
+			results = goParameterTypes(parts[2])
+		default:
+			results = []string{normalizeGoType(parts[2].Value)}
+		}
+	}
+
+	return &types.MethodSpec{
+		Name:      strings.TrimSpace(parts[0].Value),
+		Signature: "(" + strings.Join(parameters, ",") + ")(" + strings.Join(results, ",") + ")",
+	}
+}
+
+// goParameterTypes lists the type of every parameter in a parameter list, repeating shared types
+// so that "a, b int" and "int, int" compare equal
+func goParameterTypes(list *types.ASTNode) []string {
+	var parameterTypes []string
+	for _, parameter := range list.Children {
+		if parameter.Type != "parameter_declaration" && parameter.Type != "variadic_parameter_declaration" {
+			continue
+		}
+
+		names := 0
+		typeText := ""
+		for _, part := range parameter.Children {
+			switch part.Type {
+			case "identifier":
+				names++
+			case ",":
+			case "...":
+				typeText = "..."
+			default:
+				typeText += normalizeGoType(part.Value)
+			}
+		}
+		for i := 0; i < names || i == 0; i++ {
+			parameterTypes = append(parameterTypes, typeText)
+		}
+	}
+	return parameterTypes
+}
+
+// normalizeGoType removes whitespace from a type expression. Package qualifiers stay, since io.Reader
+// and a Reader declared next to the method are different types; the analyzer resolves them.
+func normalizeGoType(typeText string) string {
+	return compactExpression(typeText)
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestExtractTypeDefinitionsAndMethods(t *testing.T) {
+	manager := NewManager()
+	content := `package store
+
+type Store interface {
+	io.Closer
+	Get(ctx context.Context, id string) (*Item, error)
+	Keys() []string
+}
+
+type (
+	base struct{ id int }
+	Memory struct {
+		base
+		*sync.Mutex ` + "`json:\"-\"`" + `
+		items map[string]*Item
+	}
+)
+
+func (m *Memory) Get(c context.Context, key string) (item *Item, err error) { return nil, nil }
+func (Memory) Keys() []string { return nil }
+func (b base[T]) Close() error { return nil }
+`
+
+	lang := manager.detectLanguage("store.go")
+	ast, err := manager.parseContent(content, *lang, "store.go")
+	if err != nil {
+		t.Fatalf("Failed to parse content: %v", err)
+	}
+
+	definitions, err := manager.ExtractTypeDefinitions(ast)
+	if err != nil {
+		t.Fatalf("Failed to extract type definitions: %v", err)
+	}
+
+	expectedTypes := []struct {
+		name     string
+		kind     string
+		embedded string
+	}{
+		{"Store", TypeKindInterface, "io.Closer"},
+		{"base", TypeKindStruct, ""},
+		{"Memory", TypeKindStruct, "base,*sync.Mutex"},
+	}
+	if len(definitions) != len(expectedTypes) {
+		t.Fatalf("Expected %d type definitions, got %d: %+v", len(expectedTypes), len(definitions), definitions)
+	}
+	for i, expected := range expectedTypes {
+		definition := definitions[i]
+		if definition.Name != expected.name || definition.Kind != expected.kind {
+			t.Errorf("type[%d] = %s %s, expected %s %s", i, definition.Name, definition.Kind, expected.name, expected.kind)
+		}
+		if embedded := strings.Join(definition.Embedded, ","); embedded != expected.embedded {
+			t.Errorf("type %s embeds %q, expected %q", definition.Name, embedded, expected.embedded)
+		}
+		if definition.Symbol == "" {
+			t.Errorf("type %s has no symbol", definition.Name)
+		}
+	}
+
+	interfaceMethods := definitions[0].Methods
+	if len(interfaceMethods) != 2 ||
+		interfaceMethods[0].Signature != "(context.Context,string)(*Item,error)" ||
+		interfaceMethods[1].Signature != "()([]string)" {
+		t.Errorf("unexpected interface methods: %+v %+v", interfaceMethods[0], interfaceMethods[1])
+	}
+
+	methods, err := manager.ExtractMethods(ast)
+	if err != nil {
+		t.Fatalf("Failed to extract methods: %v", err)
+	}
+
+	expectedMethods := []types.MethodSpec{
+		{Name: "Get", Receiver: "Memory", Pointer: true, Signature: "(context.Context,string)(*Item,error)"},
+		{Name: "Keys", Receiver: "Memory", Signature: "()([]string)"},
+		{Name: "Close", Receiver: "base", Signature: "()(error)"},
+	}
+	if len(methods) != len(expectedMethods) {
+		t.Fatalf("Expected %d methods, got %d", len(expectedMethods), len(methods))
+	}
+	for i, expected := range expectedMethods {
+		method := methods[i]
+		if method.Name != expected.Name || method.Receiver != expected.Receiver ||
+			method.Pointer != expected.Pointer || method.Signature != expected.Signature {
+			t.Errorf("method[%d] = %+v, expected %+v", i, *method, expected)
+		}
+		if method.Symbol == "" {
+			t.Errorf("method %s has no symbol", method.Name)
+		}
+	}
+}
+
+func TestGoTypeSpecSymbols(t *testing.T) {
+	manager := NewManager()
+	content := "package shapes\n\ntype (\n\tShape interface{ Area() float64 }\n\tSquare struct{}\n)\n"
+
+	lang := manager.detectLanguage("shapes.go")
+	ast, err := manager.parseContent(content, *lang, "shapes.go")
+	if err != nil {
+		t.Fatalf("Failed to parse content: %v", err)
+	}
+
+	symbols, err := manager.ExtractSymbols(ast)
+	if err != nil {
+		t.Fatalf("Failed to extract symbols: %v", err)
+	}
+
+	found := make(map[string]types.SymbolType)
+	for _, symbol := range symbols {
+		found[symbol.Name] = symbol.Type
+	}
+	if found["Shape"] != types.SymbolTypeInterface || found["Square"] != types.SymbolTypeType {
+		t.Errorf("grouped type declarations should yield one symbol per spec, got %v", found)
+	}
+}
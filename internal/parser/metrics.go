@@ -0,0 +1,340 @@
+package parser
+
+import (
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// ComplexCognitiveThreshold is the cognitive complexity above which a function is hard to change
+const ComplexCognitiveThreshold = 15
+
+// functionNodeTypes are the declarations and expressions whose bodies metrics are computed for
+var functionNodeTypes = map[string]bool{
+	// Go
+	"function_declaration": true, "method_declaration": true, "func_literal": true,
+	// JavaScript and TypeScript
+	"function": true, "function_expression": true, "arrow_function": true, "method_definition": true,
+	"generator_function_declaration": true,
+	// Python
+	"function_definition": true,
+	// Java
+	"constructor_declaration": true,
+	// Rust
+	"function_item": true, "closure_expression": true,
+}
+
+// ifNodeTypes are conditionals; an if in the else branch of another is an else-if
+var ifNodeTypes = map[string]bool{
+	"if_statement":  true,
+	"if_expression": true,
+}
+
+// nestingNodeTypes are control flow structures that increase nesting: loops, switches, catches and
+// conditional expressions. Ifs are handled separately because else-ifs do not nest.
+var nestingNodeTypes = map[string]bool{
+	"for_statement": true, "for_in_statement": true, "enhanced_for_statement": true, "while_statement": true,
+	"do_statement": true, "for_expression": true, "while_expression": true, "loop_expression": true,
+	"switch_statement": true, "switch_expression": true, "expression_switch_statement": true,
+	"type_switch_statement": true, "select_statement": true, "match_expression": true, "match_statement": true,
+	"catch_clause": true, "except_clause": true,
+	"ternary_expression": true, "conditional_expression": true,
+}
+
+// caseNodeTypes are the branches of switches and matches, each an extra path through the function
+var caseNodeTypes = map[string]bool{
+	"switch_case": true, "expression_case": true, "type_case": true, "communication_case": true,
+	"switch_label": true, "match_arm": true, "case_clause": true,
+}
+
+// logicalOperators are the short-circuiting boolean operators
+var logicalOperators = map[string]bool{
+	"&&": true, "||": true, "??": true, "and": true, "or": true,
+}
+
+// parameterListTypes are the nodes holding a function's parameters
+var parameterListTypes = map[string]bool{
+	"parameter_list":    true,
+	"formal_parameters": true,
+	"parameters":        true,
+}
+
+// assignMetrics computes the size and complexity of every function and method symbol from the body
+// of the node it was extracted from, and fingerprints the bodies of functions not nested in another
+// for clone detection
+func assignMetrics(root *types.ASTNode, symbols []*types.Symbol) {
+	functions := make(map[[2]int]*types.ASTNode)
+	nested := make(map[*types.ASTNode]bool)
+	collectFunctions(root, false, functions, nested)
+
+	for _, symbol := range symbols {
+		if symbol.Type != types.SymbolTypeFunction && symbol.Type != types.SymbolTypeMethod {
+			continue
+		}
+		node := functions[[2]int{symbol.Location.StartLine, symbol.Location.StartColumn}]
+		if node == nil {
+			continue
+		}
+		symbol.Metrics = functionMetrics(node)
+		if !nested[node] {
+			symbol.Fingerprint = fingerprint(node)
+		}
+	}
+}
+
+// collectFunctions indexes function nodes by start position, keeping the innermost at each position,
+// and marks the ones declared inside another function
+func collectFunctions(node *types.ASTNode, enclosed bool, functions map[[2]int]*types.ASTNode, nested map[*types.ASTNode]bool) {
+	if node == nil {
+		return
+	}
+	// The function keyword token shares its node type with JavaScript function expressions
+	if functionNodeTypes[node.Type] && len(node.Children) > 0 {
+		functions[[2]int{node.Location.Line, node.Location.Column}] = node
+		nested[node] = enclosed
+		enclosed = true
+	}
+	for _, child := range node.Children {
+		collectFunctions(child, enclosed, functions, nested)
+	}
+}
+
+// functionMetrics measures a function node. Nested functions and closures count toward the enclosing
+// function and increase its nesting, as they do when reading it.
+func functionMetrics(node *types.ASTNode) *types.CodeMetrics {
+	metrics := &types.CodeMetrics{
+		Lines:      node.Location.EndLine - node.Location.Line + 1,
+		Cyclomatic: 1,
+		Parameters: countParameters(node),
+	}
+	if metrics.Lines < 1 {
+		metrics.Lines = 1
+	}
+	for _, child := range node.Children {
+		measureComplexity(child, node, 0, metrics)
+	}
+	return metrics
+}
+
+// measureComplexity adds a node's contribution to cyclomatic and cognitive complexity. Structures
+// add one cognitive point plus their nesting level, else and else-if branches add one point flat,
+// and each run of like logical operators adds one point.
+func measureComplexity(node, parent *types.ASTNode, nesting int, metrics *types.CodeMetrics) {
+	childNesting := nesting
+
+	switch {
+	case ifNodeTypes[node.Type]:
+		metrics.Cyclomatic++
+		if isElseIf(node, parent) {
+			metrics.Cognitive++
+		} else {
+			metrics.Cognitive += 1 + nesting
+			childNesting = nesting + 1
+			metrics.MaxNesting = max(metrics.MaxNesting, childNesting)
+		}
+	case node.Type == "elif_clause":
+		metrics.Cyclomatic++
+		metrics.Cognitive++
+	case node.Type == "else_clause":
+		if !hasChildOfTypes(node, ifNodeTypes) {
+			metrics.Cognitive++
+		}
+	case node.Type == "else" && parent != nil && ifNodeTypes[parent.Type]:
+		// Go and Java put the else keyword directly in the if
+		if next := nextSibling(parent, node); next == nil || !ifNodeTypes[next.Type] {
+			metrics.Cognitive++
+		}
+	case nestingNodeTypes[node.Type]:
+		if !isSwitch(node.Type) {
+			metrics.Cyclomatic++
+		}
+		metrics.Cognitive += 1 + nesting
+		childNesting = nesting + 1
+		metrics.MaxNesting = max(metrics.MaxNesting, childNesting)
+	case caseNodeTypes[node.Type]:
+		if !isDefaultCase(node) {
+			metrics.Cyclomatic++
+		}
+	case functionNodeTypes[node.Type]:
+		childNesting = nesting + 1
+	default:
+		if operator := logicalOperator(node); operator != "" {
+			metrics.Cyclomatic++
+			if parent == nil || logicalOperator(parent) != operator {
+				metrics.Cognitive++
+			}
+		}
+	}
+
+	for _, child := range node.Children {
+		measureComplexity(child, node, childNesting, metrics)
+	}
+}
+
+// isElseIf reports whether an if is the else branch of another if
+func isElseIf(node, parent *types.ASTNode) bool {
+	if parent == nil {
+		return false
+	}
+	if parent.Type == "else_clause" {
+		return true
+	}
+	// Go and Java nest the else-if directly after the else keyword
+	if ifNodeTypes[parent.Type] {
+		previous := previousSibling(parent, node)
+		return previous != nil && previous.Type == "else"
+	}
+	return false
+}
+
+// isSwitch reports whether a structure branches through its cases, which count instead
+func isSwitch(nodeType string) bool {
+	switch nodeType {
+	case "switch_statement", "switch_expression", "expression_switch_statement", "type_switch_statement",
+		"select_statement", "match_expression", "match_statement":
+		return true
+	}
+	return false
+}
+
+// isDefaultCase reports whether a case is the fallback branch, which adds no path of its own
+func isDefaultCase(node *types.ASTNode) bool {
+	switch node.Type {
+	case "switch_label":
+		return childOfType(node, "default") != nil
+	case "match_arm", "case_clause":
+		for _, child := range node.Children {
+			if child.Type == "match_pattern" || child.Type == "case_pattern" {
+				return child.Value == "_"
+			}
+		}
+	}
+	return false
+}
+
+// logicalOperator returns the operator of a short-circuiting boolean expression, or ""
+func logicalOperator(node *types.ASTNode) string {
+	if node.Type != "binary_expression" && node.Type != "boolean_operator" {
+		return ""
+	}
+	for _, child := range node.Children {
+		if logicalOperators[child.Type] {
+			return child.Type
+		}
+	}
+	return ""
+}
+
+// countParameters counts the declared parameters of a function, excluding receivers and self
+func countParameters(node *types.ASTNode) int {
+	// The parameter list follows the name; a Go method's receiver list precedes it
+	var list *types.ASTNode
+	for _, child := range node.Children {
+		if parameterListTypes[child.Type] {
+			list = child
+			break
+		}
+	}
+	for i, child := range node.Children {
+		if child.Type != "identifier" && child.Type != "field_identifier" && child.Type != "property_identifier" {
+			continue
+		}
+		if node.Type == "arrow_function" && list == nil {
+			// A single arrow function parameter without parentheses
+			return 1
+		}
+		for _, next := range node.Children[i+1:] {
+			if parameterListTypes[next.Type] {
+				list = next
+				break
+			}
+		}
+		break
+	}
+	if list == nil {
+		return 0
+	}
+
+	count := 0
+	for i, parameter := range list.Children {
+		switch parameter.Type {
+		case "(", ")", ",", "comment", "self_parameter":
+			continue
+		case "parameter_declaration", "variadic_parameter_declaration":
+			// Go declares several names with one type: a, b int
+			names := 0
+			for _, child := range parameter.Children {
+				if child.Type == "identifier" {
+					names++
+				}
+			}
+			count += max(names, 1)
+			continue
+		case "identifier":
+			if firstParameter(list, i) && (parameter.Value == "self" || parameter.Value == "cls") {
+				continue
+			}
+		}
+		count++
+	}
+	return count
+}
+
+// firstParameter reports whether the child at index is the first parameter of a list
+func firstParameter(list *types.ASTNode, index int) bool {
+	for _, child := range list.Children[:index] {
+		if child.Type != "(" {
+			return false
+		}
+	}
+	return true
+}
+
+// hasChildOfTypes reports whether any direct child has one of the types
+func hasChildOfTypes(node *types.ASTNode, nodeTypes map[string]bool) bool {
+	for _, child := range node.Children {
+		if nodeTypes[child.Type] {
+			return true
+		}
+	}
+	return false
+}
+
+// previousSibling returns the child of parent before node, or nil
+func previousSibling(parent, node *types.ASTNode) *types.ASTNode {
+	for i, child := range parent.Children {
+		if child == node && i > 0 {
+			return parent.Children[i-1]
+		}
+	}
+	return nil
+}
+
+// nextSibling returns the child of parent after node, or nil
+func nextSibling(parent, node *types.ASTNode) *types.ASTNode {
+	for i, child := range parent.Children {
+		if child == node && i+1 < len(parent.Children) {
+			return parent.Children[i+1]
+		}
+	}
+	return nil
+}
+
+// SummarizeMetrics aggregates the metrics of a file's functions and methods, or returns nil when it
+// declares none
+func SummarizeMetrics(symbols []*types.Symbol) *types.FileMetrics {
+	var summary *types.FileMetrics
+	for _, symbol := range symbols {
+		if symbol.Metrics == nil {
+			continue
+		}
+		if summary == nil {
+			summary = &types.FileMetrics{}
+		}
+		summary.Functions++
+		summary.TotalCyclomatic += symbol.Metrics.Cyclomatic
+		summary.TotalCognitive += symbol.Metrics.Cognitive
+		summary.MaxCyclomatic = max(summary.MaxCyclomatic, symbol.Metrics.Cyclomatic)
+		summary.MaxCognitive = max(summary.MaxCognitive, symbol.Metrics.Cognitive)
+		summary.MaxNesting = max(summary.MaxNesting, symbol.Metrics.MaxNesting)
+	}
+	return summary
+}
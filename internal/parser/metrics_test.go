@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+func TestSymbolMetrics(t *testing.T) {
+	manager := NewManager()
+
+	tests := []struct {
+		file     string
+		content  string
+		symbol   string
+		expected types.CodeMetrics
+	}{
+		{
+			// if, &&, ||, for, else-if and one non-default case; the else-if and else do not nest
+			file: "handler.go",
+			content: `package handler
+
+func (s *Server) Handle(a, b int, name string) error {
+	if a > 0 && b > 0 || name == "" {
+		for i := 0; i < a; i++ {
+		}
+	} else if b > 1 {
+	} else {
+	}
+	switch a {
+	case 1:
+	default:
+	}
+	return nil
+}
+`,
+			symbol:   "Handle",
+			expected: types.CodeMetrics{Lines: 13, Cyclomatic: 7, Cognitive: 8, MaxNesting: 2, Parameters: 3},
+		},
+		{
+			file: "models.py",
+			content: `class User:
+    def load(self, a, b=1, *args, **kwargs):
+        if a and b or not a:
+            pass
+        elif b:
+            pass
+        else:
+            pass
+        try:
+            x = 1 if a else 2
+        except KeyError:
+            pass
+`,
+			symbol:   "load",
+			expected: types.CodeMetrics{Lines: 11, Cyclomatic: 7, Cognitive: 7, MaxNesting: 1, Parameters: 4},
+		},
+		{
+			// Nested structures cost more the deeper they are
+			file:     "util.js",
+			content:  "function walk(items) {\n  for (const item of items) {\n    if (item) {\n      while (item.next) {}\n    }\n  }\n}\n",
+			symbol:   "walk",
+			expected: types.CodeMetrics{Lines: 7, Cyclomatic: 4, Cognitive: 6, MaxNesting: 3, Parameters: 1},
+		},
+		{
+			file:     "Shape.java",
+			content:  "class Shape {\n  int sides(int a, String... names) {\n    switch (a) { case 1: return 1; default: return 0; }\n  }\n}\n",
+			symbol:   "sides",
+			expected: types.CodeMetrics{Lines: 3, Cyclomatic: 2, Cognitive: 1, MaxNesting: 1, Parameters: 2},
+		},
+		{
+			file:     "lib.rs",
+			content:  "impl S {\n    fn area(&self, scale: f64) -> f64 {\n        match self.kind { 1 => 2.0, _ => 0.0 }\n    }\n}\n",
+			symbol:   "area",
+			expected: types.CodeMetrics{Lines: 3, Cyclomatic: 2, Cognitive: 1, MaxNesting: 1, Parameters: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			lang := manager.detectLanguage(tt.file)
+			ast, err := manager.parseContent(tt.content, *lang, tt.file)
+			if err != nil {
+				t.Fatalf("Failed to parse content: %v", err)
+			}
+			symbols, err := manager.ExtractSymbols(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract symbols: %v", err)
+			}
+
+			var found *types.Symbol
+			for _, symbol := range symbols {
+				if symbol.Name == tt.symbol {
+					found = symbol
+				} else if symbol.Type != types.SymbolTypeFunction && symbol.Type != types.SymbolTypeMethod && symbol.Metrics != nil {
+					t.Errorf("%s %s should not have metrics", symbol.Type, symbol.Name)
+				}
+			}
+			if found == nil || found.Metrics == nil {
+				t.Fatalf("expected metrics for %s, got symbol %+v", tt.symbol, found)
+			}
+			if *found.Metrics != tt.expected {
+				t.Errorf("metrics = %+v, expected %+v", *found.Metrics, tt.expected)
+			}
+
+			summary := SummarizeMetrics(symbols)
+			if summary == nil || summary.MaxCognitive != tt.expected.Cognitive || summary.MaxNesting != tt.expected.MaxNesting {
+				t.Errorf("SummarizeMetrics() = %+v, expected the maximum of %s", summary, tt.symbol)
+			}
+		})
+	}
+
+	if summary := SummarizeMetrics([]*types.Symbol{{Name: "Shape", Type: types.SymbolTypeClass}}); summary != nil {
+		t.Errorf("SummarizeMetrics() without functions = %+v, expected nil", summary)
+	}
+}
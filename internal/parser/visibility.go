@@ -0,0 +1,212 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/nuthan-ms/codecontext/pkg/types"
+)
+
+// Symbol visibility values
+const (
+	VisibilityPublic    = "public"
+	VisibilityProtected = "protected"
+	VisibilityPackage   = "package" // Java members without an access modifier
+	VisibilityPrivate   = "private"
+)
+
+// IsExported reports whether a symbol can be used from outside its file or package
+func IsExported(symbol *types.Symbol) bool {
+	return symbol.Visibility == VisibilityPublic || symbol.Visibility == VisibilityProtected
+}
+
+// visibilityIndex locates the declaration behind each symbol and the JavaScript exports of a file
+type visibilityIndex struct {
+	nodes         map[[2]int][]*types.ASTNode // Nodes by start position, outermost first
+	parents       map[*types.ASTNode]*types.ASTNode
+	exported      map[*types.ASTNode]bool // Nodes inside an export statement or CommonJS export
+	exportedNames map[string]bool         // Names listed by export clauses and CommonJS exports
+}
+
+// assignVisibility sets the visibility of extracted symbols from the language's export rules:
+// capitalisation in Go, a leading underscore in Python, access modifiers in Java, pub in Rust and
+// export statements or CommonJS exports in JavaScript and TypeScript
+func (m *Manager) assignVisibility(ast *types.AST, symbols []*types.Symbol) {
+	index := &visibilityIndex{
+		nodes:         make(map[[2]int][]*types.ASTNode),
+		parents:       make(map[*types.ASTNode]*types.ASTNode),
+		exported:      make(map[*types.ASTNode]bool),
+		exportedNames: make(map[string]bool),
+	}
+	index.collect(ast.Root, nil, false)
+
+	for _, symbol := range symbols {
+		if symbol.Type == types.SymbolTypeImport {
+			continue
+		}
+		// Symbols are created from a node starting where the symbol starts
+		position := [2]int{symbol.Location.StartLine, symbol.Location.StartColumn}
+
+		switch ast.Language {
+		case "go":
+			symbol.Visibility = goVisibility(symbol.Name)
+		case "python":
+			symbol.Visibility = pythonVisibility(symbol.Name)
+		case "java":
+			symbol.Visibility = index.javaVisibility(index.declarationAt(position, "_declaration"))
+		case "rust":
+			symbol.Visibility = index.rustVisibility(index.declarationAt(position, "_item"))
+		default:
+			symbol.Visibility = VisibilityPrivate
+			if symbol.Type == types.SymbolTypeNamespace || index.exportedAt(position) || index.exportedNames[symbol.Name] {
+				symbol.Visibility = VisibilityPublic
+			}
+		}
+	}
+}
+
+// collect walks the tree recording positions, parents and JavaScript exports
+func (index *visibilityIndex) collect(node, parent *types.ASTNode, inExport bool) {
+	if node == nil {
+		return
+	}
+	key := [2]int{node.Location.Line, node.Location.Column}
+	index.nodes[key] = append(index.nodes[key], node)
+	index.parents[node] = parent
+
+	switch node.Type {
+	case "export_statement":
+		inExport = true
+	case "export_specifier":
+		// export { local as alias } exports the local name
+		if name := childOfType(node, "identifier"); name != nil {
+			index.exportedNames[name.Value] = true
+		}
+	case "assignment_expression":
+		if len(node.Children) > 0 && isCommonJSExport(node.Children[0].Value) {
+			inExport = true
+			index.collectCommonJSNames(node.Children[len(node.Children)-1])
+		}
+	}
+	if inExport {
+		index.exported[node] = true
+	}
+
+	for _, child := range node.Children {
+		index.collect(child, node, inExport)
+	}
+}
+
+// declarationAt returns the innermost node starting at a position whose type has the given suffix
+func (index *visibilityIndex) declarationAt(position [2]int, suffix string) *types.ASTNode {
+	candidates := index.nodes[position]
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if strings.HasSuffix(candidates[i].Type, suffix) {
+			return candidates[i]
+		}
+	}
+	return nil
+}
+
+// exportedAt reports whether any node starting at a position is exported
+func (index *visibilityIndex) exportedAt(position [2]int) bool {
+	for _, node := range index.nodes[position] {
+		if index.exported[node] {
+			return true
+		}
+	}
+	return false
+}
+
+// collectCommonJSNames records the local names assigned to module.exports
+func (index *visibilityIndex) collectCommonJSNames(value *types.ASTNode) {
+	switch value.Type {
+	case "identifier":
+		index.exportedNames[value.Value] = true
+	case "object":
+		for _, property := range value.Children {
+			if property.Type == "shorthand_property_identifier" {
+				index.exportedNames[property.Value] = true
+			} else if property.Type == "pair" {
+				if target := property.Children[len(property.Children)-1]; target.Type == "identifier" {
+					index.exportedNames[target.Value] = true
+				}
+			}
+		}
+	}
+}
+
+// isCommonJSExport reports whether an assignment target is module.exports or one of its properties
+func isCommonJSExport(target string) bool {
+	return target == "module.exports" || strings.HasPrefix(target, "module.exports.") || strings.HasPrefix(target, "exports.")
+}
+
+// goVisibility exports identifiers starting with an upper-case letter
+func goVisibility(name string) string {
+	for _, r := range name {
+		if unicode.IsUpper(r) {
+			return VisibilityPublic
+		}
+		break
+	}
+	return VisibilityPrivate
+}
+
+// pythonVisibility treats names with a leading underscore as private, except dunder methods the
+// runtime calls
+func pythonVisibility(name string) string {
+	if strings.HasPrefix(name, "_") && !(strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__")) {
+		return VisibilityPrivate
+	}
+	return VisibilityPublic
+}
+
+// javaVisibility reads the access modifier of a declaration; interface members are implicitly public
+func (index *visibilityIndex) javaVisibility(node *types.ASTNode) string {
+	if node == nil {
+		return VisibilityPackage
+	}
+	if modifiers := childOfType(node, "modifiers"); modifiers != nil {
+		for _, modifier := range modifiers.Children {
+			switch modifier.Type {
+			case "public":
+				return VisibilityPublic
+			case "protected":
+				return VisibilityProtected
+			case "private":
+				return VisibilityPrivate
+			}
+		}
+	}
+	if parent := index.parents[node]; parent != nil && parent.Type == "interface_body" {
+		return VisibilityPublic
+	}
+	return VisibilityPackage
+}
+
+// rustVisibility exports items with a pub modifier and methods of trait implementations, which are
+// as visible as the trait
+func (index *visibilityIndex) rustVisibility(node *types.ASTNode) string {
+	if node == nil {
+		return VisibilityPrivate
+	}
+	if childOfType(node, "visibility_modifier") != nil {
+		return VisibilityPublic
+	}
+	if list := index.parents[node]; list != nil && list.Type == "declaration_list" {
+		if impl := index.parents[list]; impl != nil && impl.Type == "impl_item" && childOfType(impl, "for") != nil {
+			return VisibilityPublic
+		}
+	}
+	return VisibilityPrivate
+}
+
+// childOfType returns the first direct child of a node with the given type
+func childOfType(node *types.ASTNode, nodeType string) *types.ASTNode {
+	for _, child := range node.Children {
+		if child.Type == nodeType {
+			return child
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestSymbolVisibility(t *testing.T) {
+	manager := NewManager()
+
+	tests := []struct {
+		file     string
+		content  string
+		expected map[string]string
+	}{
+		{
+			file:     "shapes.go",
+			content:  "package shapes\n\nfunc Area() {}\nfunc scale() {}\ntype Shape interface{}\n",
+			expected: map[string]string{"Area": VisibilityPublic, "scale": VisibilityPrivate, "Shape": VisibilityPublic},
+		},
+		{
+			file:    "util.ts",
+			content: "export function a() {}\nfunction c() {}\nfunction hidden() {}\nexport default class D {}\nexport { c as cc };\n",
+			expected: map[string]string{
+				"a": VisibilityPublic, "c": VisibilityPublic, "hidden": VisibilityPrivate, "D": VisibilityPublic,
+			},
+		},
+		{
+			file:     "legacy.js",
+			content:  "function a() {}\nfunction b() {}\nfunction c() {}\nmodule.exports = { a, renamed: b };\n",
+			expected: map[string]string{"a": VisibilityPublic, "b": VisibilityPublic, "c": VisibilityPrivate},
+		},
+		{
+			file:     "models.py",
+			content:  "class User:\n    def __init__(self):\n        pass\n    def _cache(self):\n        pass\n",
+			expected: map[string]string{"User": VisibilityPublic, "__init__": VisibilityPublic, "_cache": VisibilityPrivate},
+		},
+		{
+			file:    "A.java",
+			content: "public class A {\n  protected void m() {}\n  void n() {}\n  private void p() {}\n}\ninterface B { void q(); }\n",
+			expected: map[string]string{
+				"A": VisibilityPublic, "m": VisibilityProtected, "n": VisibilityPackage, "p": VisibilityPrivate,
+				"B": VisibilityPackage, "q": VisibilityPublic,
+			},
+		},
+		{
+			file:     "lib.rs",
+			content:  "pub fn a() {}\nfn b() {}\nstruct S;\nimpl Shape for S {\n    fn area(&self) -> f64 { 0.0 }\n}\n",
+			expected: map[string]string{"a": VisibilityPublic, "b": VisibilityPrivate, "S": VisibilityPrivate, "area": VisibilityPublic},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			lang := manager.detectLanguage(tt.file)
+			ast, err := manager.parseContent(tt.content, *lang, tt.file)
+			if err != nil {
+				t.Fatalf("Failed to parse content: %v", err)
+			}
+			symbols, err := manager.ExtractSymbols(ast)
+			if err != nil {
+				t.Fatalf("Failed to extract symbols: %v", err)
+			}
+
+			found := make(map[string]string)
+			for _, symbol := range symbols {
+				if _, wanted := tt.expected[symbol.Name]; wanted {
+					found[symbol.Name] = symbol.Visibility
+				}
+			}
+			for name, visibility := range tt.expected {
+				if found[name] != visibility {
+					t.Errorf("%s visibility = %q, expected %q", name, found[name], visibility)
+				}
+			}
+		})
+	}
+}
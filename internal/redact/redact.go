@@ -0,0 +1,274 @@
+package redact
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Config configures redaction; it is read from the redaction section of the config file
+type Config struct {
+	Disabled bool      `json:"disabled" mapstructure:"disabled"`
+	Patterns []Pattern `json:"patterns" mapstructure:"patterns"` // Checked before the built-in detectors
+	Allow    []string  `json:"allow" mapstructure:"allow"`       // Regular expressions matching values never redacted
+}
+
+// Pattern is a user-defined detector. When the expression has a capture group only the first group
+// is redacted, so the name of a setting can be kept while its value is hidden.
+type Pattern struct {
+	Name    string `json:"name" mapstructure:"name"`
+	Pattern string `json:"pattern" mapstructure:"pattern"`
+}
+
+// Finding records one redacted value. The value itself is never kept; Preview shows at most its
+// first few characters and its length.
+type Finding struct {
+	Detector string `json:"detector"`
+	Source   string `json:"source"`
+	Line     int    `json:"line"`
+	Preview  string `json:"preview"`
+}
+
+// Redactor replaces secrets and other sensitive values in generated text. A nil Redactor leaves text
+// unchanged. Redactors hold no state and are safe for concurrent use.
+type Redactor struct {
+	detectors []detector
+	allow     []*regexp.Regexp
+}
+
+// detector finds one kind of sensitive value; the first capture group, when there is one, is the value
+type detector struct {
+	name    string
+	pattern *regexp.Regexp
+	accept  func(value string) bool // Rejects matches that are not secrets; nil accepts all
+}
+
+// Minimum length and Shannon entropy, in bits per character, of a token treated as a random secret
+const (
+	minEntropyLength = 20
+	minEntropy       = 4.0
+)
+
+// builtinDetectors recognise private keys, credentials in URLs, the key formats of common services,
+// secret settings in .env files and assignments, and long random-looking tokens
+var builtinDetectors = []detector{
+	{name: "private-key", pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{name: "connection-string", pattern: regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^\s:/@"'<>]+:([^\s@/"'<>]+)@`), accept: notPlaceholder},
+	{name: "aws-access-key", pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{name: "github-token", pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{name: "slack-token", pattern: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{name: "stripe-key", pattern: regexp.MustCompile(`\b[rs]k_(?:live|test)_[A-Za-z0-9]{16,}\b`)},
+	{name: "google-api-key", pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{name: "jwt", pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{
+		// NAME=value lines of .env files whose name marks a secret
+		name:    "dotenv",
+		pattern: regexp.MustCompile(`(?m)^[ \t]*(?:export[ \t]+)?[A-Z0-9_]*(?:SECRET|TOKEN|PASSWORD|PASSWD|PWD|API_?KEY|ACCESS_?KEY|PRIVATE_?KEY|CREDENTIALS?|AUTH)[A-Z0-9_]*[ \t]*=[ \t]*["']?([^\s"'#]{4,})`),
+		accept:  func(value string) bool { return notPlaceholder(value) && !strings.ContainsAny(value, "([") },
+	},
+	{
+		// Settings and constants such as password: "s3cret99" or apiKey = 'k3y...'
+		name:    "secret-assignment",
+		pattern: regexp.MustCompile(`(?i)[\w.-]*(?:secret|token|passw(?:or)?d|api[_-]?key|access[_-]?key|private[_-]?key|credentials?)[\w.-]*["']?[ \t]*(?::=|[:=])[ \t]*["']?([^\s"'` + "`" + `,;]{8,})`),
+		accept: func(value string) bool {
+			return notPlaceholder(value) && !strings.ContainsAny(value, "([{") && hasLetterAndDigit(value)
+		},
+	},
+}
+
+// entropyDetector runs after all others, so a token another detector recognised is reported by name.
+// Slashes end a token, since paths are far more common in generated output than base64 containing them.
+var entropyDetector = detector{name: "high-entropy", pattern: regexp.MustCompile(`[A-Za-z0-9+_-]{20,}={0,2}`), accept: isRandomToken}
+
+// New creates a redactor with the built-in detectors and the patterns of a config. It returns nil,
+// which redacts nothing, when redaction is disabled.
+func New(config Config) (*Redactor, error) {
+	if config.Disabled {
+		return nil, nil
+	}
+
+	r := &Redactor{}
+	for _, pattern := range config.Patterns {
+		if pattern.Name == "" {
+			return nil, fmt.Errorf("redaction pattern %q has no name", pattern.Pattern)
+		}
+		expression, err := regexp.Compile(pattern.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %s: %w", pattern.Name, err)
+		}
+		r.detectors = append(r.detectors, detector{name: pattern.Name, pattern: expression})
+	}
+	r.detectors = append(r.detectors, builtinDetectors...)
+	r.detectors = append(r.detectors, entropyDetector)
+
+	for _, allow := range config.Allow {
+		expression, err := regexp.Compile(allow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction allow pattern %q: %w", allow, err)
+		}
+		r.allow = append(r.allow, expression)
+	}
+	return r, nil
+}
+
+// match is a value to redact, as a byte range of the text
+type match struct {
+	start, end int
+	detector   string
+}
+
+// Redact replaces every sensitive value in text with a [REDACTED:detector] marker. Source names the
+// text in the returned findings, whose lines are those of the original text.
+func (r *Redactor) Redact(source, text string) (string, []Finding) {
+	if r == nil {
+		return text, nil
+	}
+
+	matches := make([]match, 0)
+	for _, d := range r.detectors {
+		for _, location := range d.pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := location[0], location[1]
+			if len(location) >= 4 && location[2] >= 0 {
+				start, end = location[2], location[3]
+			}
+			value := text[start:end]
+			if value == "" || (d.accept != nil && !d.accept(value)) || r.allowed(value) {
+				continue
+			}
+			matches = append(matches, match{start: start, end: end, detector: d.name})
+		}
+	}
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	// Of overlapping values the first one is redacted; values starting together go to the earlier detector
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	var sb strings.Builder
+	findings := make([]Finding, 0, len(matches))
+	position, line, lineStart := 0, 1, 0
+	for _, m := range matches {
+		if m.start < position {
+			continue
+		}
+		line += strings.Count(text[lineStart:m.start], "\n")
+		lineStart = m.start
+
+		sb.WriteString(text[position:m.start])
+		sb.WriteString("[REDACTED:" + m.detector + "]")
+		findings = append(findings, Finding{Detector: m.detector, Source: source, Line: line, Preview: preview(text[m.start:m.end])})
+		position = m.end
+	}
+	sb.WriteString(text[position:])
+	return sb.String(), findings
+}
+
+// allowed reports whether a value matches one of the allow patterns
+func (r *Redactor) allowed(value string) bool {
+	for _, allow := range r.allow {
+		if allow.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// preview shows the start of a redacted value, revealing at most a quarter of it
+func preview(value string) string {
+	runes := []rune(value)
+	shown := len(runes) / 4
+	if shown > 4 {
+		shown = 4
+	}
+	return fmt.Sprintf("%s… (%d chars)", string(runes[:shown]), len(runes))
+}
+
+// notPlaceholder rejects template references and masked examples such as ${DB_PASSWORD}, <token>
+// and ****
+func notPlaceholder(value string) bool {
+	if strings.HasPrefix(value, "$") || strings.HasPrefix(value, "{") || strings.HasPrefix(value, "<") || strings.HasPrefix(value, "%") {
+		return false
+	}
+	return strings.Trim(value, string(value[0])) != ""
+}
+
+// hasLetterAndDigit reports whether a value mixes letters and digits, as generated secrets and
+// passwords do but names and messages rarely do
+func hasLetterAndDigit(value string) bool {
+	return strings.IndexFunc(value, unicode.IsLetter) >= 0 && strings.IndexFunc(value, unicode.IsDigit) >= 0
+}
+
+// isRandomToken reports whether a token looks randomly generated: long, mixing upper case, lower
+// case and digits scattered through it, with high Shannon entropy. Identifiers fall below the
+// threshold, and names followed by a counter, such as TestParse1234567, have a single digit group.
+func isRandomToken(token string) bool {
+	if len(token) < minEntropyLength || digitGroups(token) < 2 ||
+		strings.IndexFunc(token, unicode.IsUpper) < 0 || strings.IndexFunc(token, unicode.IsLower) < 0 {
+		return false
+	}
+	return entropy(token) >= minEntropy
+}
+
+// digitGroups counts the runs of consecutive digits in a string
+func digitGroups(value string) int {
+	groups, inGroup := 0, false
+	for _, r := range value {
+		isDigit := unicode.IsDigit(r)
+		if isDigit && !inGroup {
+			groups++
+		}
+		inGroup = isDigit
+	}
+	return groups
+}
+
+// entropy returns the Shannon entropy of a string in bits per character
+func entropy(value string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range value {
+		counts[r]++
+	}
+	total := float64(len([]rune(value)))
+	bits := 0.0
+	for _, count := range counts {
+		p := float64(count) / total
+		bits -= p * math.Log2(p)
+	}
+	return bits
+}
+
+// WriteReport writes the redacted values as markdown. A positive limit caps the number listed.
+func WriteReport(sb *strings.Builder, findings []Finding, limit int) {
+	if len(findings) == 0 {
+		sb.WriteString("*No sensitive values found.*\n")
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		counts[finding.Detector]++
+	}
+	detectors := make([]string, 0, len(counts))
+	for name := range counts {
+		detectors = append(detectors, name)
+	}
+	sort.Strings(detectors)
+	summary := make([]string, 0, len(detectors))
+	for _, name := range detectors {
+		summary = append(summary, fmt.Sprintf("%d %s", counts[name], name))
+	}
+	sb.WriteString(fmt.Sprintf("%d sensitive values redacted (%s).\n\n", len(findings), strings.Join(summary, ", ")))
+
+	sb.WriteString("| Detector | Value | Location |\n")
+	sb.WriteString("|----------|-------|----------|\n")
+	for i, finding := range findings {
+		if limit > 0 && i >= limit {
+			sb.WriteString(fmt.Sprintf("\n*... and %d more redacted values*\n", len(findings)-limit))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %s:%d |\n", finding.Detector, finding.Preview, finding.Source, finding.Line))
+	}
+}
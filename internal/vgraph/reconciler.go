@@ -239,12 +239,19 @@ func (r *Reconciler) generateFilePatches(actual, shadow *types.CodeGraph, plan *
 			}
 			plan.Patches = append(plan.Patches, patch)
 		} else if r.filesAreDifferent(actualFile, shadowFile) {
-			// File modified in shadow
+			// File modified in shadow; the node itself is carried so that fields without a
+			// property change, such as annotations and metrics, reach the actual graph
+			changes := r.generateFilePropertyChanges(actualFile, shadowFile)
+			changes = append(changes, PropertyChange{
+				Property: "file",
+				OldValue: actualFile,
+				NewValue: shadowFile,
+			})
 			patch := GraphPatch{
 				ID:         fmt.Sprintf("file-mod-%s", filePath),
 				Type:       PatchTypeModify,
 				TargetNode: types.NodeId(fmt.Sprintf("file-%s", filePath)),
-				Changes:    r.generateFilePropertyChanges(actualFile, shadowFile),
+				Changes:    changes,
 				Priority:   2,
 			}
 			plan.Patches = append(plan.Patches, patch)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/nuthan-ms/codecontext/internal/analyzer"
+	"github.com/nuthan-ms/codecontext/internal/redact"
 )
 
 // FileWatcher monitors filesystem changes and triggers incremental updates
@@ -21,6 +22,8 @@ type FileWatcher struct {
 	debounce   time.Duration
 	changes    chan FileChange
 	done       chan struct{}
+	cancel     context.CancelFunc // Cancels an analysis in progress when the watcher stops
+	redactor   *redact.Redactor
 
 	// Configuration
 	excludePatterns []string
@@ -41,10 +44,16 @@ type Config struct {
 	DebounceTime    time.Duration
 	ExcludePatterns []string
 	IncludeExts     []string
+	Redaction       redact.Config // Secrets redacted from the context map
 }
 
 // NewFileWatcher creates a new file watcher instance
 func NewFileWatcher(config Config) (*FileWatcher, error) {
+	redactor, err := redact.New(config.Redaction)
+	if err != nil {
+		return nil, err
+	}
+
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create file watcher: %w", err)
@@ -81,6 +90,7 @@ func NewFileWatcher(config Config) (*FileWatcher, error) {
 		done:            make(chan struct{}),
 		excludePatterns: config.ExcludePatterns,
 		includeExts:     config.IncludeExts,
+		redactor:        redactor,
 	}, nil
 }
 
@@ -92,6 +102,9 @@ func (fw *FileWatcher) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to add directory to watcher: %w", err)
 	}
 
+	// Stopping the watcher also cancels a running analysis
+	ctx, fw.cancel = context.WithCancel(ctx)
+
 	// Start change processor
 	go fw.processChanges(ctx)
 
@@ -107,6 +120,9 @@ func (fw *FileWatcher) Start(ctx context.Context) error {
 
 // Stop stops the file watcher
 func (fw *FileWatcher) Stop() error {
+	if fw.cancel != nil {
+		fw.cancel()
+	}
 	close(fw.done)
 	return fw.watcher.Close()
 }
@@ -223,8 +239,8 @@ func (fw *FileWatcher) processChanges(ctx context.Context) {
 
 		case <-timer.C:
 			if len(pendingChanges) > 0 {
-				err := fw.processFileChanges(pendingChanges)
-				if err != nil {
+				err := fw.processFileChanges(ctx, pendingChanges)
+				if err != nil && ctx.Err() == nil {
 					fmt.Printf("❌ Error processing file changes: %v\n", err)
 				}
 				pendingChanges = nil
@@ -234,7 +250,7 @@ func (fw *FileWatcher) processChanges(ctx context.Context) {
 }
 
 // processFileChanges performs incremental analysis on changed files
-func (fw *FileWatcher) processFileChanges(changes []FileChange) error {
+func (fw *FileWatcher) processFileChanges(ctx context.Context, changes []FileChange) error {
 	start := time.Now()
 
 	fmt.Printf("🔄 Processing %d file changes...\n", len(changes))
@@ -246,13 +262,14 @@ func (fw *FileWatcher) processFileChanges(changes []FileChange) error {
 	}
 
 	// Perform incremental analysis
-	graph, err := fw.analyzer.AnalyzeDirectory(fw.targetDir)
+	graph, err := fw.analyzer.AnalyzeDirectoryContext(ctx, fw.targetDir)
 	if err != nil {
 		return fmt.Errorf("failed to analyze directory: %w", err)
 	}
 
 	// Generate updated context map
 	generator := analyzer.NewMarkdownGenerator(graph)
+	generator.SetRedactor(fw.redactor)
 	content := generator.GenerateContextMap()
 
 	// Write to output file
@@ -50,18 +50,47 @@ type FileLocation struct {
 
 // Symbol represents a code symbol
 type Symbol struct {
-	Id                 SymbolId   `json:"id"`
-	Name               string     `json:"name"`
-	Type               SymbolType `json:"type"`
-	Kind               string     `json:"kind"` // For diff compatibility
-	FullyQualifiedName string     `json:"fully_qualified_name"`
-	Location           Location   `json:"location"`
-	Signature          string     `json:"signature,omitempty"`
-	Documentation      string     `json:"documentation,omitempty"`
-	Visibility         string     `json:"visibility,omitempty"`
-	Language           string     `json:"language"`
-	Hash               string     `json:"hash"`
-	LastModified       time.Time  `json:"last_modified"`
+	Id                 SymbolId     `json:"id"`
+	Name               string       `json:"name"`
+	Type               SymbolType   `json:"type"`
+	Kind               string       `json:"kind"` // For diff compatibility
+	FullyQualifiedName string       `json:"fully_qualified_name"`
+	Location           Location     `json:"location"`
+	Signature          string       `json:"signature,omitempty"`
+	Documentation      string       `json:"documentation,omitempty"`
+	Visibility         string       `json:"visibility,omitempty"`
+	Language           string       `json:"language"`
+	Hash               string       `json:"hash"`
+	LastModified       time.Time    `json:"last_modified"`
+	Metrics            *CodeMetrics `json:"metrics,omitempty"`     // Functions and methods only
+	Fingerprint        *Fingerprint `json:"fingerprint,omitempty"` // Functions and methods not nested in another
+}
+
+// Fingerprint summarises a function body with identifiers and literals abstracted away, so copies
+// that were renamed or had constants changed still match
+type Fingerprint struct {
+	Hash    string   `json:"hash"` // Hash of the normalised token sequence
+	Tokens  int      `json:"tokens"`
+	MinHash []uint64 `json:"min_hash"` // Signature estimating the similarity of token shingles
+}
+
+// CodeMetrics holds the size and complexity of a function or method body
+type CodeMetrics struct {
+	Lines      int `json:"lines"`
+	Cyclomatic int `json:"cyclomatic"`  // Independent paths: 1 plus each branch, loop, case, catch and logical operator
+	Cognitive  int `json:"cognitive"`   // Effort to understand: control flow weighted by how deeply it is nested
+	MaxNesting int `json:"max_nesting"` // Deepest nesting of control flow structures
+	Parameters int `json:"parameters"`
+}
+
+// FileMetrics aggregates the metrics of the functions and methods declared in a file
+type FileMetrics struct {
+	Functions       int `json:"functions"`
+	TotalCyclomatic int `json:"total_cyclomatic"`
+	MaxCyclomatic   int `json:"max_cyclomatic"`
+	TotalCognitive  int `json:"total_cognitive"`
+	MaxCognitive    int `json:"max_cognitive"`
+	MaxNesting      int `json:"max_nesting"`
 }
 
 // GraphNode represents a node in the code graph
@@ -112,6 +141,20 @@ type GraphMetadata struct {
 	Version        string                 `json:"version"`
 	TokenCount     int                    `json:"token_count"`
 	Configuration  map[string]interface{} `json:"configuration,omitempty"`
+	Roots          []SourceRoot           `json:"roots,omitempty"`
+	Errors         []FileError            `json:"errors,omitempty"` // Files skipped because they failed to analyze
+}
+
+// FileError records a file that could not be analyzed
+type FileError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// SourceRoot is one of several directories analyzed into a single graph
+type SourceRoot struct {
+	Label string `json:"label"`
+	Path  string `json:"path"`
 }
 
 // CodeGraph represents the complete code graph
@@ -152,6 +195,54 @@ type Import struct {
 	Location   FileLocation `json:"location"`
 }
 
+// Call represents a call site found inside a function body
+type Call struct {
+	Caller   SymbolId     `json:"caller,omitempty"`   // Enclosing function or method, empty at file level
+	Callee   string       `json:"callee"`             // Name of the called function, method or constructor
+	Receiver string       `json:"receiver,omitempty"` // Qualifier before the callee, e.g. "fmt" or "this.store"
+	Location FileLocation `json:"location"`
+}
+
+// Annotation is a TODO, FIXME, HACK, XXX or @deprecated marker found in a comment
+type Annotation struct {
+	Kind     string       `json:"kind"`             // TODO, FIXME, HACK, XXX or DEPRECATED
+	Text     string       `json:"text"`             // Rest of the comment line after the marker
+	Symbol   SymbolId     `json:"symbol,omitempty"` // Enclosing symbol, or the declaration the comment documents
+	Author   string       `json:"author,omitempty"` // The owner in TODO(owner), else from git blame
+	Date     time.Time    `json:"date,omitempty"`   // When the line was last changed, from git blame
+	Location FileLocation `json:"location"`
+}
+
+// Inheritance represents a heritage clause: a type extending a base type or implementing an interface
+type Inheritance struct {
+	Symbol    SymbolId     `json:"symbol"`              // Declaring class or interface; the impl block for Rust trait impls
+	Type      string       `json:"type"`                // Name of the inheriting type
+	Kind      string       `json:"kind"`                // "extends" or "implements"
+	Target    string       `json:"target"`              // Name of the base type or interface
+	Qualifier string       `json:"qualifier,omitempty"` // Qualifier before the target, e.g. "models" or "std::fmt"
+	Location  FileLocation `json:"location"`
+}
+
+// TypeDefinition describes a named type's declared members, used to match types against
+// interfaces structurally
+type TypeDefinition struct {
+	Symbol   SymbolId      `json:"symbol"`
+	Name     string        `json:"name"`
+	Kind     string        `json:"kind"`               // "struct", "interface" or "named" for other named types
+	Methods  []*MethodSpec `json:"methods,omitempty"`  // Interface methods; concrete types declare methods separately
+	Embedded []string      `json:"embedded,omitempty"` // Embedded types as written, e.g. "Base", "*Base" or "io.Closer"
+	Location FileLocation  `json:"location"`
+}
+
+// MethodSpec is an interface method or a method declared on a receiver type
+type MethodSpec struct {
+	Symbol    SymbolId `json:"symbol,omitempty"`
+	Name      string   `json:"name"`
+	Signature string   `json:"signature"`          // Parameter and result types without names, e.g. "([]byte)(int,error)" or "(io.Reader)(error)"
+	Receiver  string   `json:"receiver,omitempty"` // Receiver type name of a method declaration
+	Pointer   bool     `json:"pointer,omitempty"`  // Declared on a pointer receiver
+}
+
 // Language represents a programming language configuration
 type Language struct {
 	Name       string   `json:"name"`
@@ -187,17 +278,24 @@ type GraphEdge struct {
 
 // FileNode represents a file in the codebase
 type FileNode struct {
-	Path         string     `json:"path"`
-	Language     string     `json:"language"`
-	Size         int        `json:"size"`
-	Lines        int        `json:"lines"`
-	SymbolCount  int        `json:"symbol_count"`
-	ImportCount  int        `json:"import_count"`
-	IsTest       bool       `json:"is_test"`
-	IsGenerated  bool       `json:"is_generated"`
-	LastModified time.Time  `json:"last_modified"`
-	Symbols      []SymbolId `json:"symbols"`
-	Imports      []*Import  `json:"imports"`
+	Path         string            `json:"path"`
+	Language     string            `json:"language"`
+	Size         int               `json:"size"`
+	Lines        int               `json:"lines"`
+	SymbolCount  int               `json:"symbol_count"`
+	ImportCount  int               `json:"import_count"`
+	IsTest       bool              `json:"is_test"`
+	IsGenerated  bool              `json:"is_generated"`
+	LastModified time.Time         `json:"last_modified"`
+	Symbols      []SymbolId        `json:"symbols"`
+	Imports      []*Import         `json:"imports"`
+	Calls        []*Call           `json:"calls,omitempty"`
+	Inheritance  []*Inheritance    `json:"inheritance,omitempty"`
+	Types        []*TypeDefinition `json:"types,omitempty"`
+	Methods      []*MethodSpec     `json:"methods,omitempty"`
+	Metrics      *FileMetrics      `json:"metrics,omitempty"`
+	Annotations  []*Annotation     `json:"annotations,omitempty"`
+	Root         string            `json:"root,omitempty"` // Label of the source root, in multi-root analysis
 }
 
 // FileInfo represents file information for diff operations